@@ -0,0 +1,197 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/huaweicloud/golangsdk"
+)
+
+// snapshotV2 is a volume snapshot, as returned by the EVS v2 API.
+type snapshotV2 struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	VolumeID    string            `json:"volume_id"`
+	Status      string            `json:"status"`
+	Size        int               `json:"size"`
+	Metadata    map[string]string `json:"metadata"`
+	CreatedAt   string            `json:"created_at"`
+	UpdatedAt   string            `json:"updated_at"`
+}
+
+// snapshotV2CreateOpts contains the values needed to create a snapshot.
+type snapshotV2CreateOpts struct {
+	VolumeID    string `json:"volume_id" required:"true"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	// Force allows a snapshot of a volume which is attached to a server.
+	Force bool `json:"force,omitempty"`
+
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// ToSnapshotCreateMap builds a request body from snapshotV2CreateOpts.
+func (opts snapshotV2CreateOpts) ToSnapshotCreateMap() (map[string]interface{}, error) {
+	return golangsdk.BuildRequestBody(opts, "snapshot")
+}
+
+// snapshotV2UpdateOpts contains the values of a snapshot which can be
+// updated.
+type snapshotV2UpdateOpts struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// ToSnapshotUpdateMap builds a request body from snapshotV2UpdateOpts.
+func (opts snapshotV2UpdateOpts) ToSnapshotUpdateMap() (map[string]interface{}, error) {
+	return golangsdk.BuildRequestBody(opts, "snapshot")
+}
+
+// snapshotV2ListOpts filters the snapshots returned by listSnapshotsV2.
+type snapshotV2ListOpts struct {
+	Name     string `q:"name"`
+	Status   string `q:"status"`
+	VolumeID string `q:"volume_id"`
+}
+
+func snapshotsV2URL(client *golangsdk.ServiceClient) string {
+	return client.ServiceURL("snapshots")
+}
+
+func snapshotV2URL(client *golangsdk.ServiceClient, id string) string {
+	return client.ServiceURL("snapshots", id)
+}
+
+func snapshotV2MetadataURL(client *golangsdk.ServiceClient, id string) string {
+	return client.ServiceURL("snapshots", id, "metadata")
+}
+
+// createSnapshotV2 creates a snapshot of a volume.
+func createSnapshotV2(client *golangsdk.ServiceClient, opts snapshotV2CreateOpts) (*snapshotV2, error) {
+	b, err := opts.ToSnapshotCreateMap()
+	if err != nil {
+		return nil, err
+	}
+
+	var r struct {
+		Snapshot snapshotV2 `json:"snapshot"`
+	}
+	_, err = client.Post(snapshotsV2URL(client), b, &r, &golangsdk.RequestOpts{
+		OkCodes: []int{200, 202},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &r.Snapshot, nil
+}
+
+// getSnapshotV2 retrieves a snapshot.
+func getSnapshotV2(client *golangsdk.ServiceClient, id string) (*snapshotV2, error) {
+	var r struct {
+		Snapshot snapshotV2 `json:"snapshot"`
+	}
+	_, err := client.Get(snapshotV2URL(client, id), &r, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &r.Snapshot, nil
+}
+
+// listSnapshotsV2 retrieves the snapshots matching the given filters,
+// following the pages of the list.
+func listSnapshotsV2(client *golangsdk.ServiceClient, opts snapshotV2ListOpts) ([]snapshotV2, error) {
+	q, err := golangsdk.BuildQueryString(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var allSnapshots []snapshotV2
+	url := client.ServiceURL("snapshots", "detail") + q.String()
+	for url != "" {
+		var r struct {
+			Snapshots []snapshotV2 `json:"snapshots"`
+			Links     []struct {
+				Href string `json:"href"`
+				Rel  string `json:"rel"`
+			} `json:"snapshots_links"`
+		}
+		_, err = client.Get(url, &r, nil)
+		if err != nil {
+			return nil, err
+		}
+		allSnapshots = append(allSnapshots, r.Snapshots...)
+
+		url = ""
+		for _, l := range r.Links {
+			if l.Rel == "next" {
+				url = l.Href
+			}
+		}
+	}
+
+	return allSnapshots, nil
+}
+
+// updateSnapshotV2 updates the name and description of a snapshot.
+func updateSnapshotV2(client *golangsdk.ServiceClient, id string, opts snapshotV2UpdateOpts) error {
+	b, err := opts.ToSnapshotUpdateMap()
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Put(snapshotV2URL(client, id), b, nil, &golangsdk.RequestOpts{
+		OkCodes: []int{200},
+	})
+	return err
+}
+
+// updateSnapshotV2Metadata replaces all the metadata of a snapshot.
+func updateSnapshotV2Metadata(client *golangsdk.ServiceClient, id string, metadata map[string]string) error {
+	b := map[string]interface{}{"metadata": metadata}
+	_, err := client.Put(snapshotV2MetadataURL(client, id), b, nil, &golangsdk.RequestOpts{
+		OkCodes: []int{200},
+	})
+	return err
+}
+
+// deleteSnapshotV2 deletes a snapshot.
+func deleteSnapshotV2(client *golangsdk.ServiceClient, id string) error {
+	_, err := client.Delete(snapshotV2URL(client, id), &golangsdk.RequestOpts{
+		OkCodes: []int{202, 204},
+	})
+	return err
+}
+
+// SnapshotV2StateRefreshFunc returns a resource.StateRefreshFunc that is used
+// to watch a TelefonicaOpenCloud volume snapshot.
+func SnapshotV2StateRefreshFunc(client *golangsdk.ServiceClient, snapshotID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		s, err := getSnapshotV2(client, snapshotID)
+		if err != nil {
+			if _, ok := err.(golangsdk.ErrDefault404); ok {
+				return s, "deleted", nil
+			}
+			return nil, "", err
+		}
+
+		if s.Status == "error" || s.Status == "error_deleting" {
+			return s, s.Status, fmt.Errorf("There was an error with the snapshot %s: its status is %s",
+				snapshotID, s.Status)
+		}
+
+		return s, s.Status, nil
+	}
+}
+
+// blockStorageV2MetadataMatch reports whether the metadata of a volume or a
+// snapshot contains all the given key/value pairs.
+func blockStorageV2MetadataMatch(metadata map[string]string, filter map[string]interface{}) bool {
+	for k, v := range filter {
+		if value, ok := metadata[k]; !ok || value != v.(string) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,73 @@
+package telefonicaopencloud
+
+import (
+	"github.com/huaweicloud/golangsdk"
+)
+
+// volumeV2ExtendOpts contains the new size of a volume to extend.
+type volumeV2ExtendOpts struct {
+	// NewSize is the new size of the volume, in GB. It must be greater than
+	// the current size.
+	NewSize int `json:"new_size" required:"true"`
+}
+
+// ToVolumeExtendMap builds a request body from volumeV2ExtendOpts.
+func (opts volumeV2ExtendOpts) ToVolumeExtendMap() (map[string]interface{}, error) {
+	return golangsdk.BuildRequestBody(opts, "os-extend")
+}
+
+// volumeV2RetypeOpts contains the new type of a volume to retype.
+type volumeV2RetypeOpts struct {
+	// NewType is the name of the new volume type.
+	NewType string `json:"new_type" required:"true"`
+
+	// MigrationPolicy is either "never" or "on-demand", to allow the volume
+	// to be migrated to another backend when the new type requires it.
+	MigrationPolicy string `json:"migration_policy,omitempty"`
+}
+
+// ToVolumeRetypeMap builds a request body from volumeV2RetypeOpts.
+func (opts volumeV2RetypeOpts) ToVolumeRetypeMap() (map[string]interface{}, error) {
+	return golangsdk.BuildRequestBody(opts, "os-retype")
+}
+
+func volumeV2ActionURL(client *golangsdk.ServiceClient, id string) string {
+	return client.ServiceURL("volumes", id, "action")
+}
+
+// cloudVolumeV2ActionURL is the action URL of the EVS extension, which can
+// also extend volumes attached to a server.
+func cloudVolumeV2ActionURL(client *golangsdk.ServiceClient, id string) string {
+	return client.ServiceURL("cloudvolumes", id, "action")
+}
+
+// extendVolumeV2 extends a volume. The EVS extension is used for volumes in
+// use, since the Cinder API only extends available volumes.
+func extendVolumeV2(client *golangsdk.ServiceClient, id string, inUse bool, opts volumeV2ExtendOpts) error {
+	b, err := opts.ToVolumeExtendMap()
+	if err != nil {
+		return err
+	}
+
+	url := volumeV2ActionURL(client, id)
+	if inUse {
+		url = cloudVolumeV2ActionURL(client, id)
+	}
+	_, err = client.Post(url, b, nil, &golangsdk.RequestOpts{
+		OkCodes: []int{200, 202},
+	})
+	return err
+}
+
+// retypeVolumeV2 changes the type of a volume.
+func retypeVolumeV2(client *golangsdk.ServiceClient, id string, opts volumeV2RetypeOpts) error {
+	b, err := opts.ToVolumeRetypeMap()
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Post(volumeV2ActionURL(client, id), b, nil, &golangsdk.RequestOpts{
+		OkCodes: []int{202},
+	})
+	return err
+}
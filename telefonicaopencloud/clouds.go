@@ -0,0 +1,194 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/mitchellh/go-homedir"
+	"gopkg.in/yaml.v2"
+)
+
+// cloudsYAML is the top level structure of a clouds.yaml or secure.yaml file.
+type cloudsYAML struct {
+	Clouds map[string]cloudYAML `yaml:"clouds"`
+}
+
+// cloudYAML is a single cloud entry of a clouds.yaml file. Only the options
+// understood by this provider are decoded.
+type cloudYAML struct {
+	Auth         cloudAuthYAML `yaml:"auth"`
+	RegionName   string        `yaml:"region_name"`
+	Interface    string        `yaml:"interface"`
+	EndpointType string        `yaml:"endpoint_type"`
+	CACertFile   string        `yaml:"cacert"`
+	ClientCert   string        `yaml:"cert"`
+	ClientKey    string        `yaml:"key"`
+	Verify       *bool         `yaml:"verify"`
+}
+
+// cloudAuthYAML is the auth section of a clouds.yaml cloud entry.
+type cloudAuthYAML struct {
+	AuthURL           string `yaml:"auth_url"`
+	Token             string `yaml:"token"`
+	Username          string `yaml:"username"`
+	UserID            string `yaml:"user_id"`
+	Password          string `yaml:"password"`
+	ProjectName       string `yaml:"project_name"`
+	ProjectID         string `yaml:"project_id"`
+	TenantName        string `yaml:"tenant_name"`
+	TenantID          string `yaml:"tenant_id"`
+	DomainName        string `yaml:"domain_name"`
+	DomainID          string `yaml:"domain_id"`
+	UserDomainName    string `yaml:"user_domain_name"`
+	UserDomainID      string `yaml:"user_domain_id"`
+	ProjectDomainName string `yaml:"project_domain_name"`
+	ProjectDomainID   string `yaml:"project_domain_id"`
+}
+
+// cloudsSearchPaths returns the locations searched for the named file, in
+// the same order as the other OpenStack clients: the current directory, the
+// user configuration directory and the system configuration directory.
+func cloudsSearchPaths(name string) []string {
+	paths := []string{}
+
+	if cwd, err := os.Getwd(); err == nil {
+		paths = append(paths, filepath.Join(cwd, name))
+	}
+
+	if home, err := homedir.Dir(); err == nil {
+		paths = append(paths, filepath.Join(home, ".config", "openstack", name))
+	}
+
+	paths = append(paths, filepath.Join("/etc", "openstack", name))
+
+	return paths
+}
+
+// findCloudsFile returns the contents of the first file found. If envVar is
+// set, only the file it points to is used. A missing file is not an error.
+func findCloudsFile(envVar, name string) ([]byte, string, error) {
+	paths := cloudsSearchPaths(name)
+	if v := os.Getenv(envVar); v != "" {
+		paths = []string{v}
+	}
+
+	for _, path := range paths {
+		content, err := ioutil.ReadFile(path)
+		if err == nil {
+			return content, path, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, "", fmt.Errorf("Error reading %s: %s", path, err)
+		}
+	}
+
+	return nil, "", nil
+}
+
+// mergeCloudsMaps copies all values of src into dst, descending into nested
+// maps so that secure.yaml only needs to hold the secret parts of an entry.
+func mergeCloudsMaps(dst, src map[interface{}]interface{}) {
+	for k, v := range src {
+		srcMap, srcOk := v.(map[interface{}]interface{})
+		dstMap, dstOk := dst[k].(map[interface{}]interface{})
+		if srcOk && dstOk {
+			mergeCloudsMaps(dstMap, srcMap)
+			continue
+		}
+		dst[k] = v
+	}
+}
+
+// getCloudFromYAML looks up the named cloud in clouds.yaml, merged with
+// secure.yaml when one is present.
+func getCloudFromYAML(name string) (*cloudYAML, error) {
+	content, path, err := findCloudsFile("OS_CLIENT_CONFIG_FILE", "clouds.yaml")
+	if err != nil {
+		return nil, err
+	}
+	if content == nil {
+		return nil, fmt.Errorf("Unable to find a clouds.yaml file for cloud %q", name)
+	}
+	log.Printf("[DEBUG] Using clouds.yaml file %s", path)
+
+	merged := make(map[interface{}]interface{})
+	if err := yaml.Unmarshal(content, &merged); err != nil {
+		return nil, fmt.Errorf("Error parsing %s: %s", path, err)
+	}
+
+	secure, securePath, err := findCloudsFile("OS_CLIENT_SECURE_FILE", "secure.yaml")
+	if err != nil {
+		return nil, err
+	}
+	if secure != nil {
+		log.Printf("[DEBUG] Using secure.yaml file %s", securePath)
+
+		secureMap := make(map[interface{}]interface{})
+		if err := yaml.Unmarshal(secure, &secureMap); err != nil {
+			return nil, fmt.Errorf("Error parsing %s: %s", securePath, err)
+		}
+		mergeCloudsMaps(merged, secureMap)
+	}
+
+	raw, err := yaml.Marshal(merged)
+	if err != nil {
+		return nil, err
+	}
+
+	var clouds cloudsYAML
+	if err := yaml.Unmarshal(raw, &clouds); err != nil {
+		return nil, fmt.Errorf("Error parsing %s: %s", path, err)
+	}
+
+	cloud, ok := clouds.Clouds[name]
+	if !ok {
+		return nil, fmt.Errorf("Cloud %q was not found in %s", name, path)
+	}
+
+	return &cloud, nil
+}
+
+// firstNonEmpty returns the first of values that is not empty.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// loadCloudConfig fills in every setting of c that was not given explicitly
+// from the clouds.yaml entry named by c.Cloud.
+func (c *Config) loadCloudConfig() error {
+	cloud, err := getCloudFromYAML(c.Cloud)
+	if err != nil {
+		return err
+	}
+
+	auth := cloud.Auth
+	c.IdentityEndpoint = firstNonEmpty(c.IdentityEndpoint, auth.AuthURL)
+	c.Token = firstNonEmpty(c.Token, auth.Token)
+	c.Username = firstNonEmpty(c.Username, auth.Username)
+	c.UserID = firstNonEmpty(c.UserID, auth.UserID)
+	c.Password = firstNonEmpty(c.Password, auth.Password)
+	c.TenantName = firstNonEmpty(c.TenantName, auth.ProjectName, auth.TenantName)
+	c.TenantID = firstNonEmpty(c.TenantID, auth.ProjectID, auth.TenantID)
+	c.DomainName = firstNonEmpty(c.DomainName, auth.UserDomainName, auth.ProjectDomainName, auth.DomainName)
+	c.DomainID = firstNonEmpty(c.DomainID, auth.UserDomainID, auth.ProjectDomainID, auth.DomainID)
+
+	c.Region = firstNonEmpty(c.Region, cloud.RegionName)
+	c.EndpointType = firstNonEmpty(c.EndpointType, cloud.Interface, cloud.EndpointType)
+	c.CACertFile = firstNonEmpty(c.CACertFile, cloud.CACertFile)
+	c.ClientCertFile = firstNonEmpty(c.ClientCertFile, cloud.ClientCert)
+	c.ClientKeyFile = firstNonEmpty(c.ClientKeyFile, cloud.ClientKey)
+
+	if cloud.Verify != nil && !*cloud.Verify {
+		c.Insecure = true
+	}
+
+	return nil
+}
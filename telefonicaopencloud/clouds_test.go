@@ -0,0 +1,106 @@
+package telefonicaopencloud
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+const testCloudsYAML = `
+clouds:
+  mycloud:
+    auth:
+      auth_url: "https://iam.example.com/v3"
+      username: "cloud-user"
+      project_name: "cloud-project"
+      user_domain_name: "cloud-domain"
+    region_name: "eu-west-0"
+    interface: "internal"
+    cacert: "/etc/ssl/cloud.pem"
+    verify: false
+`
+
+const testSecureYAML = `
+clouds:
+  mycloud:
+    auth:
+      password: "secret"
+`
+
+func writeTestCloudsFile(t *testing.T, content string) string {
+	f, err := ioutil.TempFile("", "clouds")
+	if err != nil {
+		t.Fatalf("Error creating temp file: %s", err)
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(content); err != nil {
+		t.Fatalf("Error writing temp file: %s", err)
+	}
+	return f.Name()
+}
+
+func TestConfig_loadCloudConfig(t *testing.T) {
+	cloudsFile := writeTestCloudsFile(t, testCloudsYAML)
+	defer os.Remove(cloudsFile)
+	secureFile := writeTestCloudsFile(t, testSecureYAML)
+	defer os.Remove(secureFile)
+
+	os.Setenv("OS_CLIENT_CONFIG_FILE", cloudsFile)
+	defer os.Unsetenv("OS_CLIENT_CONFIG_FILE")
+	os.Setenv("OS_CLIENT_SECURE_FILE", secureFile)
+	defer os.Unsetenv("OS_CLIENT_SECURE_FILE")
+
+	c := &Config{
+		Cloud:  "mycloud",
+		Region: "eu-de",
+	}
+	if err := c.loadCloudConfig(); err != nil {
+		t.Fatalf("Error loading clouds.yaml: %s", err)
+	}
+
+	expected := map[string]string{
+		"IdentityEndpoint": "https://iam.example.com/v3",
+		"Username":         "cloud-user",
+		"Password":         "secret",
+		"TenantName":       "cloud-project",
+		"DomainName":       "cloud-domain",
+		"Region":           "eu-de",
+		"EndpointType":     "internal",
+		"CACertFile":       "/etc/ssl/cloud.pem",
+	}
+	actual := map[string]string{
+		"IdentityEndpoint": c.IdentityEndpoint,
+		"Username":         c.Username,
+		"Password":         c.Password,
+		"TenantName":       c.TenantName,
+		"DomainName":       c.DomainName,
+		"Region":           c.Region,
+		"EndpointType":     c.EndpointType,
+		"CACertFile":       c.CACertFile,
+	}
+	for k, v := range expected {
+		if actual[k] != v {
+			t.Errorf("Expected %s to be %q, got %q", k, v, actual[k])
+		}
+	}
+
+	if !c.Insecure {
+		t.Errorf("Expected Insecure to be set from verify: false")
+	}
+}
+
+func TestConfig_loadCloudConfigMissingCloud(t *testing.T) {
+	cloudsFile := writeTestCloudsFile(t, testCloudsYAML)
+	defer os.Remove(cloudsFile)
+
+	os.Setenv("OS_CLIENT_CONFIG_FILE", cloudsFile)
+	defer os.Unsetenv("OS_CLIENT_CONFIG_FILE")
+	os.Setenv("OS_CLIENT_SECURE_FILE", cloudsFile+".missing")
+	defer os.Unsetenv("OS_CLIENT_SECURE_FILE")
+
+	c := &Config{Cloud: "othercloud"}
+	if err := c.loadCloudConfig(); err == nil {
+		t.Fatalf("Expected an error for a cloud missing from clouds.yaml")
+	}
+}
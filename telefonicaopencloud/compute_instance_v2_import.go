@@ -0,0 +1,141 @@
+// This set of code rebuilds the arguments of the
+// telefonicaopencloud_compute_instance_v2 and
+// telefonicaopencloud_compute_bms_server_v2 resources when they are imported.
+//
+// Some of them, such as the networks and the boot volume, are only read back
+// by matching the Terraform configuration against the server, so they have
+// to be reconstructed from the server itself when there is no state yet.
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/blockstorage/v2/volumes"
+	"github.com/huaweicloud/golangsdk/openstack/compute/v2/extensions/volumeattach"
+	"github.com/huaweicloud/golangsdk/openstack/compute/v2/servers"
+)
+
+// instanceVolumesAttached is the part of a server listing its volumes. The
+// volumes are listed with the os-volume_attachments API, this is only used
+// for their delete_on_termination flag which that API doesn't return.
+type instanceVolumesAttached struct {
+	Image           interface{} `json:"image"`
+	VolumesAttached []struct {
+		ID string `json:"id"`
+		// DeleteOnTermination is a boolean, or a string such as "True" on
+		// some versions of the API.
+		DeleteOnTermination interface{} `json:"delete_on_termination"`
+	} `json:"os-extended-volumes:volumes_attached"`
+}
+
+// instanceBootVolume is the part of a volume needed to rebuild the block
+// device it was created from.
+type instanceBootVolume struct {
+	ID                  string            `json:"id"`
+	Size                int               `json:"size"`
+	Bootable            string            `json:"bootable"`
+	VolumeImageMetadata map[string]string `json:"volume_image_metadata"`
+}
+
+// importInstanceNetworks builds the network blocks of an imported server.
+// Both the network ID and name are set, so that they are read back as they
+// are instead of being looked up from the configuration.
+func importInstanceNetworks(networkingClient *golangsdk.ServiceClient, server *servers.Server) []map[string]interface{} {
+	networks := getInstanceNetworksAndAddresses(networkingClient, server)
+	for _, network := range networks {
+		// Floating IPs are managed by their own resources.
+		delete(network, "floating_ip")
+		network["access_network"] = false
+	}
+
+	log.Printf("[DEBUG] importInstanceNetworks: %#v", networks)
+	return networks
+}
+
+// importInstanceBlockDevices builds the block_device of an imported server
+// booted from a volume. Nothing is returned for servers booted from an
+// image. Other volumes attached to the server are left out, they can be
+// imported as telefonicaopencloud_compute_volume_attach_v2 resources.
+func importInstanceBlockDevices(computeClient, blockStorageClient *golangsdk.ServiceClient,
+	serverID string) ([]map[string]interface{}, error) {
+
+	var server instanceVolumesAttached
+	if err := servers.Get(computeClient, serverID).ExtractInto(&server); err != nil {
+		return nil, fmt.Errorf("Error retrieving TelefonicaOpenCloud server %s: %s", serverID, err)
+	}
+
+	if image, ok := server.Image.(map[string]interface{}); ok && image["id"] != nil && image["id"] != "" {
+		return nil, nil
+	}
+
+	allPages, err := volumeattach.List(computeClient, serverID).AllPages()
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving the volumes of TelefonicaOpenCloud server %s: %s", serverID, err)
+	}
+	attachments, err := volumeattach.ExtractVolumeAttachments(allPages)
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving the volumes of TelefonicaOpenCloud server %s: %s", serverID, err)
+	}
+
+	var bootVolume *instanceBootVolume
+	for _, attachment := range attachments {
+		var volume instanceBootVolume
+		if err := volumes.Get(blockStorageClient, attachment.VolumeID).ExtractInto(&volume); err != nil {
+			return nil, fmt.Errorf("Error retrieving TelefonicaOpenCloud volume %s: %s", attachment.VolumeID, err)
+		}
+		if volume.Bootable != "true" {
+			continue
+		}
+
+		// The boot volume is the first disk of the server, e.g. /dev/vda.
+		isRoot := strings.HasSuffix(attachment.Device, "da")
+		if bootVolume == nil || isRoot {
+			bootVolume = &volume
+		}
+		if isRoot {
+			break
+		}
+	}
+
+	if bootVolume == nil {
+		return nil, fmt.Errorf("Unable to find the boot volume of TelefonicaOpenCloud server %s", serverID)
+	}
+
+	deleteOnTermination := false
+	for _, attached := range server.VolumesAttached {
+		if attached.ID == bootVolume.ID {
+			deleteOnTermination = parseInstanceDeleteOnTermination(attached.DeleteOnTermination)
+		}
+	}
+
+	blockDevice := map[string]interface{}{
+		"source_type":           "volume",
+		"uuid":                  bootVolume.ID,
+		"destination_type":      "volume",
+		"boot_index":            0,
+		"delete_on_termination": deleteOnTermination,
+	}
+	if imageID := bootVolume.VolumeImageMetadata["image_id"]; imageID != "" {
+		blockDevice["source_type"] = "image"
+		blockDevice["uuid"] = imageID
+		blockDevice["volume_size"] = bootVolume.Size
+	}
+
+	log.Printf("[DEBUG] importInstanceBlockDevices: %#v", blockDevice)
+	return []map[string]interface{}{blockDevice}, nil
+}
+
+func parseInstanceDeleteOnTermination(v interface{}) bool {
+	switch v := v.(type) {
+	case bool:
+		return v
+	case string:
+		b, _ := strconv.ParseBool(v)
+		return b
+	}
+	return false
+}
@@ -13,6 +13,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"sort"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/huaweicloud/golangsdk"
@@ -25,9 +26,10 @@ import (
 // InstanceNIC is a structured representation of a Gophercloud servers.Server
 // virtual NIC.
 type InstanceNIC struct {
-	FixedIPv4 string
-	FixedIPv6 string
-	MAC       string
+	FixedIPv4    string
+	FixedIPv6    string
+	FloatingIPv4 string
+	MAC          string
 }
 
 // InstanceAddresses is a collection of InstanceNICs, grouped by the
@@ -333,6 +335,10 @@ func getInstanceAddresses(addresses map[string]interface{}) []InstanceAddresses
 				}
 			}
 
+			if v["OS-EXT-IPS:type"] == "floating" {
+				instanceNIC.FloatingIPv4 = v["addr"].(string)
+			}
+
 			// To associate IPv4 and IPv6 on the right NIC,
 			// key on the mac address and fill in the blanks.
 			for i, v := range instanceAddresses.InstanceNICs {
@@ -344,6 +350,9 @@ func getInstanceAddresses(addresses map[string]interface{}) []InstanceAddresses
 					if instanceNIC.FixedIPv4 != "" {
 						instanceAddresses.InstanceNICs[i].FixedIPv4 = instanceNIC.FixedIPv4
 					}
+					if instanceNIC.FloatingIPv4 != "" {
+						instanceAddresses.InstanceNICs[i].FloatingIPv4 = instanceNIC.FloatingIPv4
+					}
 				}
 			}
 
@@ -423,29 +432,54 @@ func flattenInstanceNetworks(
 		return networks, nil
 	}
 
+	// NICs may be attached and detached outside of the network blocks, either
+	// by hand or by telefonicaopencloud_compute_interface_attach_v2 resources.
+	// The ports of the NICs are retrieved so that each network block can be
+	// matched to its own NIC, and a network block whose NIC is gone is left
+	// out so that it's attached again.
+	portMACs := map[string]string{}
+	macPorts := map[string]string{}
+	interfaces, err := listComputeInterfacesV2(computeClient, d.Id())
+	if err != nil {
+		log.Printf("[WARN] Unable to retrieve the interfaces of instance %s: %s", d.Id(), err)
+	}
+	for _, i := range interfaces {
+		portMACs[i.PortID] = i.MACAddr
+		macPorts[i.MACAddr] = i.PortID
+	}
+
 	// Loop through all networks and addresses, merge relevant address details.
+	usedMACs := map[string]bool{}
 	for _, instanceNetwork := range allInstanceNetworks {
 		for _, instanceAddresses := range allInstanceAddresses {
-			if instanceNetwork.Name == instanceAddresses.NetworkName {
-				// It is possible that the address will be hidden and not be returned
-				if len(instanceAddresses.InstanceNICs) == 0 {
-					continue
-				}
-				// Only use one NIC since it's possible the user defined another NIC
-				// on this same network in another Terraform network block.
-				instanceNIC := instanceAddresses.InstanceNICs[0]
-				copy(instanceAddresses.InstanceNICs, instanceAddresses.InstanceNICs[1:])
-				v := map[string]interface{}{
-					"name":           instanceAddresses.NetworkName,
-					"fixed_ip_v4":    instanceNIC.FixedIPv4,
-					"fixed_ip_v6":    instanceNIC.FixedIPv6,
-					"mac":            instanceNIC.MAC,
-					"uuid":           instanceNetwork.UUID,
-					"port":           instanceNetwork.Port,
-					"access_network": instanceNetwork.AccessNetwork,
-				}
-				networks = append(networks, v)
+			if instanceNetwork.Name != instanceAddresses.NetworkName {
+				continue
+			}
+
+			// It is possible that the address will be hidden and not be returned
+			instanceNIC, ok := matchInstanceNIC(
+				instanceNetwork, portMACs[instanceNetwork.Port], instanceAddresses.InstanceNICs, usedMACs)
+			if !ok {
+				continue
+			}
+			usedMACs[instanceNIC.MAC] = true
+
+			port := instanceNetwork.Port
+			if v, ok := macPorts[instanceNIC.MAC]; ok {
+				port = v
+			}
+
+			v := map[string]interface{}{
+				"name":           instanceAddresses.NetworkName,
+				"fixed_ip_v4":    instanceNIC.FixedIPv4,
+				"fixed_ip_v6":    instanceNIC.FixedIPv6,
+				"mac":            instanceNIC.MAC,
+				"uuid":           instanceNetwork.UUID,
+				"port":           port,
+				"access_network": instanceNetwork.AccessNetwork,
 			}
+			networks = append(networks, v)
+			break
 		}
 	}
 
@@ -453,6 +487,300 @@ func flattenInstanceNetworks(
 	return networks, nil
 }
 
+// matchInstanceNIC picks the NIC of a network block among the NICs of its
+// network which aren't used by another network block yet. The NIC of the
+// port of the network block is picked first, then the NIC with its fixed IP,
+// then the first NIC left.
+func matchInstanceNIC(instanceNetwork InstanceNetwork, portMAC string,
+	instanceNICs []InstanceNIC, usedMACs map[string]bool) (InstanceNIC, bool) {
+
+	var available []InstanceNIC
+	for _, instanceNIC := range instanceNICs {
+		if !usedMACs[instanceNIC.MAC] {
+			available = append(available, instanceNIC)
+		}
+	}
+
+	for _, instanceNIC := range available {
+		if portMAC != "" && instanceNIC.MAC == portMAC {
+			return instanceNIC, true
+		}
+	}
+	for _, instanceNIC := range available {
+		if instanceNetwork.FixedIP != "" && instanceNIC.FixedIPv4 == instanceNetwork.FixedIP {
+			return instanceNIC, true
+		}
+	}
+	if len(available) > 0 {
+		return available[0], true
+	}
+
+	return InstanceNIC{}, false
+}
+
+// updateInstanceNetworks attaches and detaches the NICs of an instance so that
+// they match its network blocks.
+//
+// Since the arguments which are not set in the configuration keep the value
+// of the previous network block at the same position, the values a network
+// block shares with the previous block at its position are ignored when it
+// is for another network. The network blocks are then matched with the
+// previous ones by port, by network and fixed IP, or by MAC address, so that
+// removing or inserting a network block leaves the other NICs in place.
+func updateInstanceNetworks(d *schema.ResourceData, meta interface{}, computeClient *golangsdk.ServiceClient) error {
+	oldRaw, newRaw := d.GetChange("network")
+	oldNetworks := make([]map[string]interface{}, len(oldRaw.([]interface{})))
+	for i, v := range oldRaw.([]interface{}) {
+		oldNetworks[i] = v.(map[string]interface{})
+	}
+
+	networks := make([]map[string]interface{}, len(newRaw.([]interface{})))
+	for i, v := range newRaw.([]interface{}) {
+		network := copyInstanceNetwork(v.(map[string]interface{}))
+		networks[i] = network
+		if i >= len(oldNetworks) || sameInstanceNetwork(oldNetworks[i], network) {
+			continue
+		}
+
+		oldNetwork := oldNetworks[i]
+		if network["uuid"] != oldNetwork["uuid"] {
+			if network["name"] == oldNetwork["name"] {
+				network["name"] = ""
+			}
+		} else if network["name"] != oldNetwork["name"] {
+			network["uuid"] = ""
+		}
+		if network["uuid"] != oldNetwork["uuid"] || network["name"] != oldNetwork["name"] {
+			for _, key := range []string{"port", "fixed_ip_v4", "fixed_ip_v6", "mac"} {
+				if network[key] == oldNetwork[key] {
+					network[key] = ""
+				}
+			}
+		} else if network["port"] == oldNetwork["port"] {
+			// Only the port or the fixed IP changed.
+			network["port"] = ""
+			network["fixed_ip_v6"] = ""
+			network["mac"] = ""
+		}
+	}
+
+	matched := make([]bool, len(oldNetworks))
+	var attach []int
+	for i, network := range networks {
+		j := matchInstanceNetwork(oldNetworks, matched, network, i)
+		if j < 0 {
+			attach = append(attach, i)
+			continue
+		}
+
+		matched[j] = true
+		accessNetwork := network["access_network"]
+		networks[i] = copyInstanceNetwork(oldNetworks[j])
+		networks[i]["access_network"] = accessNetwork
+	}
+
+	var detach []map[string]interface{}
+	for j, oldNetwork := range oldNetworks {
+		if !matched[j] {
+			detach = append(detach, oldNetwork)
+		}
+	}
+
+	// NICs are detached first, so that their fixed IPs can be reused.
+	if len(detach) > 0 {
+		interfaces, err := listComputeInterfacesV2(computeClient, d.Id())
+		if err != nil {
+			return fmt.Errorf("Error retrieving the interfaces of TelefonicaOpenCloud instance %s: %s", d.Id(), err)
+		}
+
+		for _, network := range detach {
+			portID := findInstanceNetworkPort(interfaces, network)
+			if portID == "" {
+				log.Printf("[DEBUG] Unable to find the interface of network %#v, it's already detached", network)
+				continue
+			}
+			err := detachComputeInterfaceV2(computeClient, d.Id(), portID, d.Timeout(schema.TimeoutUpdate))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, i := range attach {
+		network := networks[i]
+		attachOpts := computeInterfaceV2CreateOpts{
+			PortID: network["port"].(string),
+		}
+		if attachOpts.PortID == "" {
+			attachOpts.NetID = network["uuid"].(string)
+			if attachOpts.NetID == "" {
+				name := network["name"].(string)
+				if name == "" {
+					return fmt.Errorf(
+						"At least one of network.uuid, network.name, or network.port must be set.")
+				}
+				networkInfo, err := getInstanceNetworkInfo(d, meta, "name", name)
+				if err != nil {
+					return err
+				}
+				attachOpts.NetID = networkInfo["uuid"].(string)
+			}
+			if fixedIP := network["fixed_ip_v4"].(string); fixedIP != "" {
+				attachOpts.FixedIPs = []computeInterfaceV2FixedIP{{IPAddress: fixedIP}}
+			}
+		}
+
+		attachment, err := attachComputeInterfaceV2(computeClient, d.Id(), attachOpts, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return err
+		}
+
+		// The name is looked up again from the port when the instance is read.
+		network["port"] = attachment.PortID
+		network["uuid"] = attachment.NetID
+		network["name"] = ""
+		network["mac"] = attachment.MACAddr
+		if len(attachment.FixedIPs) > 0 {
+			network["fixed_ip_v4"] = attachment.FixedIPs[0].IPAddress
+		}
+	}
+
+	return d.Set("network", networks)
+}
+
+// copyInstanceNetwork copies the arguments of a network block.
+func copyInstanceNetwork(network map[string]interface{}) map[string]interface{} {
+	v := map[string]interface{}{}
+	for _, key := range []string{"uuid", "name", "port", "fixed_ip_v4", "fixed_ip_v6", "mac", "access_network"} {
+		v[key] = network[key]
+	}
+	return v
+}
+
+// matchInstanceNetwork returns the index of the previous network block not
+// matched yet which has the NIC of a network block, or -1 if there is none.
+// The previous block at the same position is preferred when it's the same.
+func matchInstanceNetwork(oldNetworks []map[string]interface{}, matched []bool,
+	network map[string]interface{}, position int) int {
+
+	if position < len(oldNetworks) && !matched[position] && sameInstanceNetwork(oldNetworks[position], network) {
+		return position
+	}
+
+	port, _ := network["port"].(string)
+	if port != "" {
+		for j, oldNetwork := range oldNetworks {
+			if !matched[j] && oldNetwork["port"] == port {
+				return j
+			}
+		}
+		// The port is not attached yet.
+		return -1
+	}
+
+	fixedIP, _ := network["fixed_ip_v4"].(string)
+	for j, oldNetwork := range oldNetworks {
+		if matched[j] {
+			continue
+		}
+		if uuid, _ := network["uuid"].(string); uuid != "" && oldNetwork["uuid"] != uuid {
+			continue
+		}
+		if name, _ := network["name"].(string); name != "" && oldNetwork["name"] != name {
+			continue
+		}
+		if network["uuid"] == "" && network["name"] == "" {
+			continue
+		}
+		if fixedIP != "" && oldNetwork["fixed_ip_v4"] != fixedIP {
+			continue
+		}
+		return j
+	}
+
+	if mac, _ := network["mac"].(string); mac != "" {
+		for j, oldNetwork := range oldNetworks {
+			if !matched[j] && oldNetwork["mac"] == mac {
+				return j
+			}
+		}
+	}
+
+	return -1
+}
+
+// sameInstanceNetwork tells whether two network blocks have the same NIC.
+func sameInstanceNetwork(a, b map[string]interface{}) bool {
+	for _, key := range []string{"uuid", "name", "port", "fixed_ip_v4"} {
+		if a[key] != b[key] {
+			return false
+		}
+	}
+	return true
+}
+
+// findInstanceNetworkPort returns the port of the interface of a network
+// block, found by port, MAC address or fixed IP.
+func findInstanceNetworkPort(interfaces []computeInterfaceV2, network map[string]interface{}) string {
+	for _, i := range interfaces {
+		if port, _ := network["port"].(string); port != "" && i.PortID == port {
+			return i.PortID
+		}
+	}
+	for _, i := range interfaces {
+		if mac, _ := network["mac"].(string); mac != "" && i.MACAddr == mac {
+			return i.PortID
+		}
+	}
+	for _, i := range interfaces {
+		for _, ip := range i.FixedIPs {
+			if fixedIP, _ := network["fixed_ip_v4"].(string); fixedIP != "" && ip.IPAddress == fixedIP {
+				return i.PortID
+			}
+		}
+	}
+	return ""
+}
+
+// getInstanceNetworksAndAddresses builds the network information of a server
+// without a Terraform configuration to correlate it with, as data sources do.
+// The network IDs are looked up by name in the Network API; a network which
+// can't be found there is returned without its ID.
+func getInstanceNetworksAndAddresses(
+	networkingClient *golangsdk.ServiceClient, server *servers.Server) []map[string]interface{} {
+
+	allInstanceAddresses := getInstanceAddresses(server.Addresses)
+	sort.Slice(allInstanceAddresses, func(i, j int) bool {
+		return allInstanceAddresses[i].NetworkName < allInstanceAddresses[j].NetworkName
+	})
+
+	networks := []map[string]interface{}{}
+	for _, instanceAddresses := range allInstanceAddresses {
+		var networkID string
+		networkInfo, err := getInstanceNetworkInfoNeutron(networkingClient, "name", instanceAddresses.NetworkName)
+		if err != nil {
+			log.Printf("[WARN] Unable to determine the ID of network %s: %s", instanceAddresses.NetworkName, err)
+		} else {
+			networkID = networkInfo["uuid"].(string)
+		}
+
+		for _, instanceNIC := range instanceAddresses.InstanceNICs {
+			v := map[string]interface{}{
+				"uuid":        networkID,
+				"name":        instanceAddresses.NetworkName,
+				"fixed_ip_v4": instanceNIC.FixedIPv4,
+				"fixed_ip_v6": instanceNIC.FixedIPv6,
+				"floating_ip": instanceNIC.FloatingIPv4,
+				"mac":         instanceNIC.MAC,
+			}
+			networks = append(networks, v)
+		}
+	}
+
+	log.Printf("[DEBUG] getInstanceNetworksAndAddresses: %#v", networks)
+	return networks
+}
+
 // getInstanceAccessAddresses determines the best IP address to communicate
 // with the instance. It does this by looping through all networks and looking
 // for a valid IP address. Priority is given to a network that was flagged as
@@ -0,0 +1,127 @@
+// This set of code handles the power state of the
+// telefonicaopencloud_compute_instance_v2 and
+// telefonicaopencloud_compute_bms_server_v2 resources.
+//
+// The power_state argument is either active or shutoff. It can also be set to
+// reboot or hard_reboot: changing it to one of them reboots the server, which
+// then stays active. The reboot value is kept in the state while the server is
+// active, so it only triggers a single reboot. It's Computed rather than
+// defaulted so that configurations which don't set it are left unchanged.
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/compute/v2/extensions/startstop"
+	"github.com/huaweicloud/golangsdk/openstack/compute/v2/servers"
+)
+
+const (
+	instancePowerStateActive     = "active"
+	instancePowerStateShutoff    = "shutoff"
+	instancePowerStateReboot     = "reboot"
+	instancePowerStateHardReboot = "hard_reboot"
+)
+
+func instancePowerStateSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Computed: true,
+		ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+			return ValidateStringList(v, k, []string{
+				instancePowerStateActive, instancePowerStateShutoff,
+				instancePowerStateReboot, instancePowerStateHardReboot,
+			})
+		},
+	}
+}
+
+// flattenInstancePowerState returns the power state of a server from its
+// status. A configured reboot is kept as long as the server is active, and
+// the current power state is kept for transitional statuses.
+func flattenInstancePowerState(d *schema.ResourceData, status string) string {
+	current := d.Get("power_state").(string)
+
+	switch status {
+	case "ACTIVE":
+		if current == instancePowerStateReboot || current == instancePowerStateHardReboot {
+			return current
+		}
+		return instancePowerStateActive
+	case "SHUTOFF":
+		return instancePowerStateShutoff
+	}
+
+	if current == "" {
+		return instancePowerStateActive
+	}
+	return current
+}
+
+// setInstancePowerState starts, stops or reboots a server so that it matches
+// the power_state argument, and waits for it to reach the matching status.
+func setInstancePowerState(client *golangsdk.ServiceClient, d *schema.ResourceData,
+	refresh resource.StateRefreshFunc, timeout time.Duration) error {
+
+	_, status, err := refresh()
+	if err != nil {
+		return fmt.Errorf("Error retrieving TelefonicaOpenCloud server %s: %s", d.Id(), err)
+	}
+
+	powerState := d.Get("power_state").(string)
+	var pending []string
+	target := "ACTIVE"
+
+	switch {
+	case powerState == instancePowerStateShutoff:
+		if status == "SHUTOFF" {
+			return nil
+		}
+		log.Printf("[DEBUG] Stopping TelefonicaOpenCloud instance %s", d.Id())
+		err = startstop.Stop(client, d.Id()).ExtractErr()
+		pending = []string{"ACTIVE"}
+		target = "SHUTOFF"
+	case status == "SHUTOFF":
+		// A stopped server is started whether it's asked to be active or
+		// to be rebooted.
+		log.Printf("[DEBUG] Starting TelefonicaOpenCloud instance %s", d.Id())
+		err = startstop.Start(client, d.Id()).ExtractErr()
+		pending = []string{"SHUTOFF"}
+	case powerState == instancePowerStateReboot:
+		log.Printf("[DEBUG] Rebooting TelefonicaOpenCloud instance %s", d.Id())
+		err = servers.Reboot(client, d.Id(), servers.RebootOpts{Type: servers.SoftReboot}).ExtractErr()
+		pending = []string{"REBOOT"}
+	case powerState == instancePowerStateHardReboot:
+		log.Printf("[DEBUG] Hard rebooting TelefonicaOpenCloud instance %s", d.Id())
+		err = servers.Reboot(client, d.Id(), servers.RebootOpts{Type: servers.HardReboot}).ExtractErr()
+		pending = []string{"HARD_REBOOT"}
+	default:
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error changing the power state of TelefonicaOpenCloud instance %s to %s: %s",
+			d.Id(), powerState, err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    pending,
+		Target:     []string{target},
+		Refresh:    refresh,
+		Timeout:    timeout,
+		Delay:      10 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	log.Printf("[DEBUG] Waiting for instance (%s) to become %s", d.Id(), target)
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for instance (%s) to become %s: %s", d.Id(), target, err)
+	}
+
+	return nil
+}
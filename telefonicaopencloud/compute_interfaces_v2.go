@@ -0,0 +1,183 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/huaweicloud/golangsdk"
+)
+
+// computeInterfaceV2 is a network interface of a server, as returned by the
+// Nova os-interface API.
+type computeInterfaceV2 struct {
+	PortState string `json:"port_state"`
+	FixedIPs  []struct {
+		SubnetID  string `json:"subnet_id"`
+		IPAddress string `json:"ip_address"`
+	} `json:"fixed_ips"`
+	PortID  string `json:"port_id"`
+	NetID   string `json:"net_id"`
+	MACAddr string `json:"mac_addr"`
+}
+
+// computeInterfaceV2FixedIP is a fixed IP requested for a new interface.
+type computeInterfaceV2FixedIP struct {
+	IPAddress string `json:"ip_address"`
+}
+
+// computeInterfaceV2CreateOpts contains the values needed to attach a network
+// interface to a server. Either a port or a network must be given.
+type computeInterfaceV2CreateOpts struct {
+	// PortID is an existing port to attach to the server.
+	PortID string `json:"port_id,omitempty"`
+
+	// NetID is the network a new port is created on.
+	NetID string `json:"net_id,omitempty"`
+
+	// FixedIPs are the IP addresses of the new port. Only one is supported.
+	FixedIPs []computeInterfaceV2FixedIP `json:"fixed_ips,omitempty"`
+}
+
+// ToInterfaceAttachMap builds a request body from computeInterfaceV2CreateOpts.
+func (opts computeInterfaceV2CreateOpts) ToInterfaceAttachMap() (map[string]interface{}, error) {
+	return golangsdk.BuildRequestBody(opts, "interfaceAttachment")
+}
+
+func computeInterfacesV2URL(client *golangsdk.ServiceClient, serverID string) string {
+	return client.ServiceURL("servers", serverID, "os-interface")
+}
+
+func computeInterfaceV2URL(client *golangsdk.ServiceClient, serverID, portID string) string {
+	return client.ServiceURL("servers", serverID, "os-interface", portID)
+}
+
+// createComputeInterfaceV2 attaches a network interface to a server.
+func createComputeInterfaceV2(client *golangsdk.ServiceClient, serverID string,
+	opts computeInterfaceV2CreateOpts) (*computeInterfaceV2, error) {
+
+	b, err := opts.ToInterfaceAttachMap()
+	if err != nil {
+		return nil, err
+	}
+
+	var r struct {
+		InterfaceAttachment computeInterfaceV2 `json:"interfaceAttachment"`
+	}
+	_, err = client.Post(computeInterfacesV2URL(client, serverID), b, &r, &golangsdk.RequestOpts{
+		OkCodes: []int{200, 202},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &r.InterfaceAttachment, nil
+}
+
+// getComputeInterfaceV2 retrieves the interface of a server with the given
+// port ID.
+func getComputeInterfaceV2(client *golangsdk.ServiceClient, serverID, portID string) (*computeInterfaceV2, error) {
+	var r struct {
+		InterfaceAttachment computeInterfaceV2 `json:"interfaceAttachment"`
+	}
+	_, err := client.Get(computeInterfaceV2URL(client, serverID, portID), &r, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &r.InterfaceAttachment, nil
+}
+
+// listComputeInterfacesV2 retrieves all the interfaces of a server.
+func listComputeInterfacesV2(client *golangsdk.ServiceClient, serverID string) ([]computeInterfaceV2, error) {
+	var r struct {
+		InterfaceAttachments []computeInterfaceV2 `json:"interfaceAttachments"`
+	}
+	_, err := client.Get(computeInterfacesV2URL(client, serverID), &r, nil)
+	if err != nil {
+		return nil, err
+	}
+	return r.InterfaceAttachments, nil
+}
+
+// deleteComputeInterfaceV2 detaches the interface with the given port ID from
+// a server.
+func deleteComputeInterfaceV2(client *golangsdk.ServiceClient, serverID, portID string) error {
+	_, err := client.Delete(computeInterfaceV2URL(client, serverID, portID), &golangsdk.RequestOpts{
+		OkCodes: []int{202, 204},
+	})
+	return err
+}
+
+// attachComputeInterfaceV2 attaches a network interface to a server and
+// waits for it to become active.
+func attachComputeInterfaceV2(client *golangsdk.ServiceClient, serverID string,
+	opts computeInterfaceV2CreateOpts, timeout time.Duration) (*computeInterfaceV2, error) {
+
+	log.Printf("[DEBUG] Attaching interface to TelefonicaOpenCloud instance %s: %#v", serverID, opts)
+	attachment, err := createComputeInterfaceV2(client, serverID, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Error attaching interface to TelefonicaOpenCloud instance %s: %s", serverID, err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"BUILD", "DOWN"},
+		Target:     []string{"ACTIVE"},
+		Refresh:    computeInterfaceV2StateRefreshFunc(client, serverID, attachment.PortID),
+		Timeout:    timeout,
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	log.Printf("[DEBUG] Waiting for interface %s of instance %s to become active", attachment.PortID, serverID)
+	v, err := stateConf.WaitForState()
+	if err != nil {
+		return nil, fmt.Errorf("Error waiting for interface %s of instance %s to become active: %s",
+			attachment.PortID, serverID, err)
+	}
+
+	return v.(*computeInterfaceV2), nil
+}
+
+// detachComputeInterfaceV2 detaches a network interface from a server and
+// waits for it to be gone.
+func detachComputeInterfaceV2(client *golangsdk.ServiceClient, serverID, portID string, timeout time.Duration) error {
+	log.Printf("[DEBUG] Detaching interface %s from TelefonicaOpenCloud instance %s", portID, serverID)
+	if err := deleteComputeInterfaceV2(client, serverID, portID); err != nil {
+		if _, ok := err.(golangsdk.ErrDefault404); ok {
+			return nil
+		}
+		return fmt.Errorf("Error detaching interface %s from TelefonicaOpenCloud instance %s: %s", portID, serverID, err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"ACTIVE", "BUILD", "DOWN"},
+		Target:     []string{"DETACHED"},
+		Refresh:    computeInterfaceV2StateRefreshFunc(client, serverID, portID),
+		Timeout:    timeout,
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	log.Printf("[DEBUG] Waiting for interface %s of instance %s to be detached", portID, serverID)
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for interface %s of instance %s to be detached: %s", portID, serverID, err)
+	}
+
+	return nil
+}
+
+// computeInterfaceV2StateRefreshFunc returns a resource.StateRefreshFunc that
+// is used to watch an interface of a server.
+func computeInterfaceV2StateRefreshFunc(client *golangsdk.ServiceClient, serverID, portID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		attachment, err := getComputeInterfaceV2(client, serverID, portID)
+		if err != nil {
+			if _, ok := err.(golangsdk.ErrDefault404); ok {
+				return attachment, "DETACHED", nil
+			}
+			return nil, "", err
+		}
+
+		return attachment, attachment.PortState, nil
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
@@ -29,12 +30,16 @@ type Config struct {
 	ClientCertFile   string
 	ClientKeyFile    string
 	Cloud            string
+	DefaultTags      map[string]string
 	DomainID         string
 	DomainName       string
 	EndpointType     string
 	IdentityEndpoint string
 	Insecure         bool
+	MaxBackoff       time.Duration
+	MaxRetries       int
 	Password         string
+	RateLimits       map[string]RateLimit
 	Region           string
 	Swauth           bool
 	TenantID         string
@@ -44,11 +49,19 @@ type Config struct {
 	UserID           string
 	useOctavia       bool
 
-	HwClient *golangsdk.ProviderClient
-	s3sess   *session.Session
+	HwClient    *golangsdk.ProviderClient
+	s3sess      *session.Session
+	rateLimiter *RateLimitRoundTripper
 }
 
 func (c *Config) LoadAndValidate() error {
+	// Settings from clouds.yaml only fill in what was not set explicitly.
+	if c.Cloud != "" {
+		if err := c.loadCloudConfig(); err != nil {
+			return err
+		}
+	}
+
 	validEndpoint := false
 	validEndpoints := []string{
 		"internal", "internalURL",
@@ -149,10 +162,23 @@ func newhwClient(c *Config) error {
 	}
 
 	transport := &http.Transport{Proxy: http.ProxyFromEnvironment, TLSClientConfig: config}
+	var rt http.RoundTripper = &LogRoundTripper{
+		Rt:      transport,
+		OsDebug: osDebug,
+	}
+
+	// The rate limit applies to every attempt of a request, so it sits
+	// below the retries.
+	if len(c.RateLimits) > 0 {
+		c.rateLimiter = NewRateLimitRoundTripper(rt, c.RateLimits)
+		rt = c.rateLimiter
+	}
+
 	client.HTTPClient = http.Client{
-		Transport: &LogRoundTripper{
-			Rt:      transport,
-			OsDebug: osDebug,
+		Transport: &RetryRoundTripper{
+			Rt:         rt,
+			MaxRetries: c.MaxRetries,
+			MaxBackoff: c.MaxBackoff,
 		},
 	}
 
@@ -190,6 +216,7 @@ func newhwClient(c *Config) error {
 			Credentials: creds,
 			Region:      aws.String(c.Region),
 			HTTPClient:  cleanhttp.DefaultClient(),
+			MaxRetries:  aws.Int(c.MaxRetries),
 		}
 
 		if osDebug {
@@ -237,6 +264,19 @@ func (c *Config) determineRegion(region string) string {
 	return region
 }
 
+// limitedClient registers the endpoint of a service client with the rate
+// limit of its service, if one was configured.
+func (c *Config) limitedClient(service string, client *golangsdk.ServiceClient, err error) (*golangsdk.ServiceClient, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	if c.rateLimiter != nil {
+		c.rateLimiter.register(service, client.Endpoint)
+	}
+	return client, nil
+}
+
 func (c *Config) computeS3conn(region string) (*s3.S3, error) {
 	if c.s3sess == nil {
 		return nil, fmt.Errorf("Missing credentials for Swift S3 Provider, need access_key and secret_key values for provider.")
@@ -256,67 +296,99 @@ func (c *Config) computeS3conn(region string) (*s3.S3, error) {
 }
 
 func (c *Config) blockStorageV1Client(region string) (*golangsdk.ServiceClient, error) {
-	return huaweisdk.NewBlockStorageV1(c.HwClient, golangsdk.EndpointOpts{
+	client, err := huaweisdk.NewBlockStorageV1(c.HwClient, golangsdk.EndpointOpts{
 		Region:       c.determineRegion(region),
 		Availability: c.getHwEndpointType(),
 	})
+	return c.limitedClient("blockstorage", client, err)
 }
 
 func (c *Config) blockStorageV2Client(region string) (*golangsdk.ServiceClient, error) {
-	return huaweisdk.NewBlockStorageV2(c.HwClient, golangsdk.EndpointOpts{
+	client, err := huaweisdk.NewBlockStorageV2(c.HwClient, golangsdk.EndpointOpts{
 		Region:       c.determineRegion(region),
 		Availability: c.getHwEndpointType(),
 	})
+	return c.limitedClient("blockstorage", client, err)
 }
 
 func (c *Config) computeV2Client(region string) (*golangsdk.ServiceClient, error) {
-	return huaweisdk.NewComputeV2(c.HwClient, golangsdk.EndpointOpts{
+	client, err := huaweisdk.NewComputeV2(c.HwClient, golangsdk.EndpointOpts{
+		Region:       c.determineRegion(region),
+		Availability: c.getHwEndpointType(),
+	})
+	return c.limitedClient("compute", client, err)
+}
+
+// computeV1Client is used to access the ECS v1 API, i.e. the tags of a server.
+func (c *Config) computeV1Client(region string) (*golangsdk.ServiceClient, error) {
+	client, err := huaweisdk.NewComputeV1(c.HwClient, golangsdk.EndpointOpts{
 		Region:       c.determineRegion(region),
 		Availability: c.getHwEndpointType(),
 	})
+	return c.limitedClient("compute", client, err)
 }
 
 func (c *Config) dnsV2Client(region string) (*golangsdk.ServiceClient, error) {
-	return huaweisdk.NewDNSV2(c.HwClient, golangsdk.EndpointOpts{
+	client, err := huaweisdk.NewDNSV2(c.HwClient, golangsdk.EndpointOpts{
 		Region:       c.determineRegion(region),
 		Availability: c.getHwEndpointType(),
 	})
+	return c.limitedClient("dns", client, err)
 }
 
 func (c *Config) identityV3Client(region string) (*golangsdk.ServiceClient, error) {
-	return huaweisdk.NewIdentityV3(c.HwClient, golangsdk.EndpointOpts{
+	client, err := huaweisdk.NewIdentityV3(c.HwClient, golangsdk.EndpointOpts{
 		Region:       c.determineRegion(region),
 		Availability: c.getHwEndpointType(),
 	})
+	return c.limitedClient("identity", client, err)
 }
 
 func (c *Config) imageV2Client(region string) (*golangsdk.ServiceClient, error) {
-	return huaweisdk.NewImageServiceV2(c.HwClient, golangsdk.EndpointOpts{
+	client, err := huaweisdk.NewImageServiceV2(c.HwClient, golangsdk.EndpointOpts{
 		Region:       c.determineRegion(region),
 		Availability: c.getHwEndpointType(),
 	})
+	return c.limitedClient("image", client, err)
 }
 
 func (c *Config) networkingV1Client(region string) (*golangsdk.ServiceClient, error) {
-	return huaweisdk.NewNetworkV1(c.HwClient, golangsdk.EndpointOpts{
+	client, err := huaweisdk.NewNetworkV1(c.HwClient, golangsdk.EndpointOpts{
 		Region:       c.determineRegion(region),
 		Name:         "neutron",
 		Availability: c.getHwEndpointType(),
 	})
+	return c.limitedClient("network", client, err)
 }
 
 func (c *Config) networkingV2Client(region string) (*golangsdk.ServiceClient, error) {
-	return huaweisdk.NewNetworkV2(c.HwClient, golangsdk.EndpointOpts{
+	client, err := huaweisdk.NewNetworkV2(c.HwClient, golangsdk.EndpointOpts{
+		Region:       c.determineRegion(region),
+		Availability: c.getHwEndpointType(),
+	})
+	return c.limitedClient("network", client, err)
+}
+
+// networkingV2TagsClient is used to access the project scoped VPC v2.0 API,
+// i.e. the tags of a VPC or an EIP.
+func (c *Config) networkingV2TagsClient(region string) (*golangsdk.ServiceClient, error) {
+	sc, err := huaweisdk.NewNetworkV2(c.HwClient, golangsdk.EndpointOpts{
 		Region:       c.determineRegion(region),
 		Availability: c.getHwEndpointType(),
 	})
+	if err != nil {
+		return nil, err
+	}
+	sc.ResourceBase = sc.ResourceBase + c.HwClient.ProjectID + "/"
+	return c.limitedClient("network", sc, nil)
 }
 
 func (c *Config) hwNetworkV2Client(region string) (*golangsdk.ServiceClient, error) {
-	return huaweisdk.NewNetworkV2(c.HwClient, golangsdk.EndpointOpts{
+	client, err := huaweisdk.NewNetworkV2(c.HwClient, golangsdk.EndpointOpts{
 		Region:       c.determineRegion(region),
 		Availability: c.getHwEndpointType(),
 	})
+	return c.limitedClient("network", client, err)
 }
 
 func (c *Config) objectStorageV1Client(region string) (*golangsdk.ServiceClient, error) {
@@ -328,59 +400,79 @@ func (c *Config) objectStorageV1Client(region string) (*golangsdk.ServiceClient,
 		})
 	}
 
-	return huaweisdk.NewObjectStorageV1(c.HwClient, golangsdk.EndpointOpts{
+	client, err := huaweisdk.NewObjectStorageV1(c.HwClient, golangsdk.EndpointOpts{
 		Region:       c.determineRegion(region),
 		Availability: c.getHwEndpointType(),
 	})
+	return c.limitedClient("objectstorage", client, err)
 }
 
 func (c *Config) loadBalancerV2Client(region string) (*golangsdk.ServiceClient, error) {
-	return huaweisdk.NewLoadBalancerV2(c.HwClient, golangsdk.EndpointOpts{
+	client, err := huaweisdk.NewLoadBalancerV2(c.HwClient, golangsdk.EndpointOpts{
 		Region:       c.determineRegion(region),
 		Availability: c.getHwEndpointType(),
 	})
+	return c.limitedClient("network", client, err)
 }
 
 func (c *Config) databaseV1Client(region string) (*golangsdk.ServiceClient, error) {
-	return huaweisdk.NewDBV1(c.HwClient, golangsdk.EndpointOpts{
+	client, err := huaweisdk.NewDBV1(c.HwClient, golangsdk.EndpointOpts{
 		Region:       c.determineRegion(region),
 		Availability: c.getHwEndpointType(),
 	})
+	return c.limitedClient("rds", client, err)
 }
 
 func (c *Config) loadElasticLoadBalancerClient(region string) (*golangsdk.ServiceClient, error) {
-	return huaweisdk.NewElasticLoadBalancer(c.HwClient, golangsdk.EndpointOpts{
+	client, err := huaweisdk.NewElasticLoadBalancer(c.HwClient, golangsdk.EndpointOpts{
 		Region:       c.determineRegion(region),
 		Availability: c.getHwEndpointType(),
 	})
+	return c.limitedClient("elb", client, err)
 }
 
 func (c *Config) autoscalingV1Client(region string) (*golangsdk.ServiceClient, error) {
-	return huaweisdk.NewAutoScalingService(c.HwClient, golangsdk.EndpointOpts{
+	client, err := huaweisdk.NewAutoScalingService(c.HwClient, golangsdk.EndpointOpts{
 		Region:       c.determineRegion(region),
 		Availability: c.getHwEndpointType(),
 	})
+	return c.limitedClient("autoscaling", client, err)
 }
 
 func (c *Config) SmnV2Client(region string) (*golangsdk.ServiceClient, error) {
-	return huaweisdk.NewSmnServiceV2(c.HwClient, golangsdk.EndpointOpts{
+	client, err := huaweisdk.NewSmnServiceV2(c.HwClient, golangsdk.EndpointOpts{
 		Region:       c.determineRegion(region),
 		Availability: c.getHwEndpointType(),
 	})
+	return c.limitedClient("smn", client, err)
 }
 
 func (c *Config) RdsV1Client(region string) (*golangsdk.ServiceClient, error) {
-	return huaweisdk.NewRdsServiceV1(c.HwClient, golangsdk.EndpointOpts{
+	client, err := huaweisdk.NewRdsServiceV1(c.HwClient, golangsdk.EndpointOpts{
 		Region:       c.determineRegion(region),
 		Availability: c.getHwEndpointType(),
 	})
+	return c.limitedClient("rds", client, err)
+}
+
+// RdsTagsV1Client is used to access the tags of an RDS instance, which are
+// not served below the /rds/v1/ path used by the other RDS APIs.
+func (c *Config) RdsTagsV1Client(region string) (*golangsdk.ServiceClient, error) {
+	sc, err := c.RdsV1Client(region)
+	if err != nil {
+		return nil, err
+	}
+	sc.Endpoint = strings.Replace(sc.Endpoint, "/rds/v1/", "/v1/", 1)
+	sc.ResourceBase = sc.Endpoint
+	return c.limitedClient("rds", sc, nil)
 }
 
 func (c *Config) loadCESClient(region string) (*golangsdk.ServiceClient, error) {
-	return huaweisdk.NewCESClient(c.HwClient, golangsdk.EndpointOpts{
+	client, err := huaweisdk.NewCESClient(c.HwClient, golangsdk.EndpointOpts{
 		Region:       c.determineRegion(region),
 		Availability: c.getHwEndpointType(),
 	})
+	return c.limitedClient("ces", client, err)
 }
 
 func (c *Config) getHwEndpointType() golangsdk.Availability {
@@ -394,94 +486,107 @@ func (c *Config) getHwEndpointType() golangsdk.Availability {
 }
 
 func (c *Config) orchestrationV1Client(region string) (*golangsdk.ServiceClient, error) {
-	return huaweisdk.NewOrchestrationV1(c.HwClient, golangsdk.EndpointOpts{
+	client, err := huaweisdk.NewOrchestrationV1(c.HwClient, golangsdk.EndpointOpts{
 		Region:       c.determineRegion(region),
 		Availability: c.getHwEndpointType(),
 	})
+	return c.limitedClient("orchestration", client, err)
 }
 
 func (c *Config) sfsV2Client(region string) (*golangsdk.ServiceClient, error) {
-	return huaweisdk.NewSharedFileSystemV2(c.HwClient, golangsdk.EndpointOpts{
+	client, err := huaweisdk.NewSharedFileSystemV2(c.HwClient, golangsdk.EndpointOpts{
 		Region:       c.determineRegion(region),
 		Name:         "manilav2",
 		Availability: c.getHwEndpointType(),
 	})
+	return c.limitedClient("sfs", client, err)
 }
 
 func (c *Config) loadEVSV2Client(region string) (*golangsdk.ServiceClient, error) {
-	return huaweisdk.NewBlockStorageV2(c.HwClient, golangsdk.EndpointOpts{
+	client, err := huaweisdk.NewBlockStorageV2(c.HwClient, golangsdk.EndpointOpts{
 		Region:       c.determineRegion(region),
 		Availability: c.getHwEndpointType(),
 	})
+	return c.limitedClient("blockstorage", client, err)
 }
 
 func (c *Config) computeV2HWClient(region string) (*golangsdk.ServiceClient, error) {
-	return huaweisdk.NewComputeV2(c.HwClient, golangsdk.EndpointOpts{
+	client, err := huaweisdk.NewComputeV2(c.HwClient, golangsdk.EndpointOpts{
 		Region:       c.determineRegion(region),
 		Availability: c.getHwEndpointType(),
 	})
+	return c.limitedClient("compute", client, err)
 }
 
 //bmsClient used to access the v2.1 bms Services i.e. servers, tags.
 func (c *Config) bmsClient(region string) (*golangsdk.ServiceClient, error) {
-	return huaweisdk.NewBMSV2(c.HwClient, golangsdk.EndpointOpts{
+	client, err := huaweisdk.NewBMSV2(c.HwClient, golangsdk.EndpointOpts{
 		Region:       c.determineRegion(region),
 		Availability: c.getHwEndpointType(),
 	})
+	return c.limitedClient("compute", client, err)
 }
 
 func (c *Config) csbsV1Client(region string) (*golangsdk.ServiceClient, error) {
-	return huaweisdk.NewCSBSService(c.HwClient, golangsdk.EndpointOpts{
+	client, err := huaweisdk.NewCSBSService(c.HwClient, golangsdk.EndpointOpts{
 		Region:       c.determineRegion(region),
 		Availability: c.getHwEndpointType(),
 	})
+	return c.limitedClient("csbs", client, err)
 }
 
 func (c *Config) dmsV1Client(region string) (*golangsdk.ServiceClient, error) {
-	return huaweisdk.NewDMSServiceV1(c.HwClient, golangsdk.EndpointOpts{
+	client, err := huaweisdk.NewDMSServiceV1(c.HwClient, golangsdk.EndpointOpts{
 		Region:       c.determineRegion(region),
 		Availability: c.getHwEndpointType(),
 	})
+	return c.limitedClient("dms", client, err)
 }
 
 func (c *Config) vbsV2Client(region string) (*golangsdk.ServiceClient, error) {
-	return huaweisdk.NewVBSV2(c.HwClient, golangsdk.EndpointOpts{
+	client, err := huaweisdk.NewVBSV2(c.HwClient, golangsdk.EndpointOpts{
 		Region:       c.determineRegion(region),
 		Availability: c.getHwEndpointType(),
 	})
+	return c.limitedClient("vbs", client, err)
 }
 
 func (c *Config) ctsV1Client(region string) (*golangsdk.ServiceClient, error) {
-	return huaweisdk.NewCTSService(c.HwClient, golangsdk.EndpointOpts{
+	client, err := huaweisdk.NewCTSService(c.HwClient, golangsdk.EndpointOpts{
 		Region:       c.determineRegion(region),
 		Availability: c.getHwEndpointType(),
 	})
+	return c.limitedClient("cts", client, err)
 }
 
 func (c *Config) dcsV1Client(region string) (*golangsdk.ServiceClient, error) {
-	return huaweisdk.NewDCSServiceV1(c.HwClient, golangsdk.EndpointOpts{
+	client, err := huaweisdk.NewDCSServiceV1(c.HwClient, golangsdk.EndpointOpts{
 		Region:       c.determineRegion(region),
 		Availability: c.getHwEndpointType(),
 	})
+	return c.limitedClient("dcs", client, err)
 }
 
 func (c *Config) MrsV1Client(region string) (*golangsdk.ServiceClient, error) {
-	return huaweisdk.NewMapReduceV1(c.HwClient, golangsdk.EndpointOpts{
+	client, err := huaweisdk.NewMapReduceV1(c.HwClient, golangsdk.EndpointOpts{
 		Region:       c.determineRegion(region),
 		Availability: c.getHwEndpointType(),
 	})
+	return c.limitedClient("mrs", client, err)
 }
 
 func (c *Config) maasV1Client(region string) (*golangsdk.ServiceClient, error) {
-	return huaweisdk.NewMAASV1(c.HwClient, golangsdk.EndpointOpts{
+	client, err := huaweisdk.NewMAASV1(c.HwClient, golangsdk.EndpointOpts{
 		Region:       c.determineRegion(region),
 		Availability: c.getHwEndpointType(),
 	})
+	return c.limitedClient("maas", client, err)
 }
 
 func (c *Config) antiddosV1Client(region string) (*golangsdk.ServiceClient, error) {
-	return huaweisdk.NewAntiDDoSV1(c.HwClient, golangsdk.EndpointOpts{
+	client, err := huaweisdk.NewAntiDDoSV1(c.HwClient, golangsdk.EndpointOpts{
 		Region:       c.determineRegion(region),
 		Availability: c.getHwEndpointType(),
 	})
+	return c.limitedClient("antiddos", client, err)
 }
@@ -0,0 +1,124 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceBlockStorageSnapshotV2() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceBlockStorageSnapshotV2Read,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"volume_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Computed: true,
+			},
+			"most_recent": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"size": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"created_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceBlockStorageSnapshotV2Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	blockStorageClient, err := config.blockStorageV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud block storage client: %s", err)
+	}
+
+	listOpts := snapshotV2ListOpts{
+		Name:     d.Get("name").(string),
+		Status:   d.Get("status").(string),
+		VolumeID: d.Get("volume_id").(string),
+	}
+
+	log.Printf("[DEBUG] List Options: %#v", listOpts)
+
+	allSnapshots, err := listSnapshotsV2(blockStorageClient, listOpts)
+	if err != nil {
+		return fmt.Errorf("Unable to retrieve snapshots: %s", err)
+	}
+
+	// The metadata are filtered here rather than by the API, which may
+	// add system keys to them.
+	metadata := d.Get("metadata").(map[string]interface{})
+
+	var refinedSnapshots []snapshotV2
+	for _, s := range allSnapshots {
+		if blockStorageV2MetadataMatch(s.Metadata, metadata) {
+			refinedSnapshots = append(refinedSnapshots, s)
+		}
+	}
+
+	if len(refinedSnapshots) < 1 {
+		return fmt.Errorf("Your query returned no results. " +
+			"Please change your search criteria and try again.")
+	}
+
+	if len(refinedSnapshots) > 1 {
+		if !d.Get("most_recent").(bool) {
+			return fmt.Errorf("Your query returned more than one result." +
+				" Please try a more specific search criteria, or set `most_recent` to true")
+		}
+		// The creation dates share the same format, so they sort as strings.
+		sort.Slice(refinedSnapshots, func(i, j int) bool {
+			return refinedSnapshots[i].CreatedAt > refinedSnapshots[j].CreatedAt
+		})
+	}
+
+	s := refinedSnapshots[0]
+
+	log.Printf("[DEBUG] Retrieved Snapshot %s: %+v", s.ID, s)
+	d.SetId(s.ID)
+
+	d.Set("name", s.Name)
+	d.Set("status", s.Status)
+	d.Set("volume_id", s.VolumeID)
+	d.Set("metadata", s.Metadata)
+	d.Set("description", s.Description)
+	d.Set("size", s.Size)
+	d.Set("created_at", s.CreatedAt)
+	d.Set("region", GetRegion(d, config))
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccBlockStorageV2SnapshotDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBlockStorageV2SnapshotDataSource_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBlockStorageV2SnapshotDataSourceID("data.telefonicaopencloud_blockstorage_snapshot_v2.snapshot_1"),
+					resource.TestCheckResourceAttrPair(
+						"data.telefonicaopencloud_blockstorage_snapshot_v2.snapshot_1", "id",
+						"telefonicaopencloud_blockstorage_snapshot_v2.snapshot_1", "id"),
+					resource.TestCheckResourceAttrPair(
+						"data.telefonicaopencloud_blockstorage_snapshot_v2.snapshot_1", "volume_id",
+						"telefonicaopencloud_blockstorage_volume_v2.volume_1", "id"),
+					resource.TestCheckResourceAttr(
+						"data.telefonicaopencloud_blockstorage_snapshot_v2.snapshot_1", "name", "snapshot_ds_1"),
+					resource.TestCheckResourceAttr(
+						"data.telefonicaopencloud_blockstorage_snapshot_v2.snapshot_1", "status", "available"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBlockStorageV2SnapshotDataSource_mostRecent(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBlockStorageV2SnapshotDataSource_mostRecent,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBlockStorageV2SnapshotDataSourceID("data.telefonicaopencloud_blockstorage_snapshot_v2.snapshot_1"),
+					resource.TestCheckResourceAttr(
+						"data.telefonicaopencloud_blockstorage_snapshot_v2.snapshot_1", "metadata.foo", "bar"),
+					resource.TestCheckResourceAttrPair(
+						"data.telefonicaopencloud_blockstorage_snapshot_v2.snapshot_1", "volume_id",
+						"telefonicaopencloud_blockstorage_volume_v2.volume_1", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckBlockStorageV2SnapshotDataSourceID(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Can't find snapshot data source: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("Snapshot data source ID not set")
+		}
+
+		return nil
+	}
+}
+
+const testAccBlockStorageV2SnapshotDataSource_basic = `
+resource "telefonicaopencloud_blockstorage_volume_v2" "volume_1" {
+  name = "volume_1"
+  size = 1
+}
+
+resource "telefonicaopencloud_blockstorage_snapshot_v2" "snapshot_1" {
+  volume_id = "${telefonicaopencloud_blockstorage_volume_v2.volume_1.id}"
+  name = "snapshot_ds_1"
+}
+
+data "telefonicaopencloud_blockstorage_snapshot_v2" "snapshot_1" {
+  name = "${telefonicaopencloud_blockstorage_snapshot_v2.snapshot_1.name}"
+  status = "available"
+}
+`
+
+const testAccBlockStorageV2SnapshotDataSource_mostRecent = `
+resource "telefonicaopencloud_blockstorage_volume_v2" "volume_1" {
+  name = "volume_1"
+  size = 1
+}
+
+resource "telefonicaopencloud_blockstorage_snapshot_v2" "snapshot_1" {
+  volume_id = "${telefonicaopencloud_blockstorage_volume_v2.volume_1.id}"
+  name = "snapshot_ds_1"
+  metadata {
+    foo = "bar"
+  }
+}
+
+resource "telefonicaopencloud_blockstorage_snapshot_v2" "snapshot_2" {
+  volume_id = "${telefonicaopencloud_blockstorage_volume_v2.volume_1.id}"
+  name = "snapshot_ds_1"
+  metadata {
+    foo = "bar"
+  }
+}
+
+data "telefonicaopencloud_blockstorage_snapshot_v2" "snapshot_1" {
+  volume_id = "${telefonicaopencloud_blockstorage_snapshot_v2.snapshot_2.volume_id}"
+  metadata {
+    foo = "bar"
+  }
+  most_recent = true
+}
+`
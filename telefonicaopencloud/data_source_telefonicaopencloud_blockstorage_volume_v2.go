@@ -0,0 +1,131 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/huaweicloud/golangsdk/openstack/blockstorage/v2/volumes"
+)
+
+func dataSourceBlockStorageVolumeV2() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceBlockStorageVolumeV2Read,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Computed: true,
+			},
+			"size": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"availability_zone": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"volume_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"bootable": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"snapshot_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"source_vol_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceBlockStorageVolumeV2Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	blockStorageClient, err := config.blockStorageV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud block storage client: %s", err)
+	}
+
+	listOpts := volumes.ListOpts{
+		Name:   d.Get("name").(string),
+		Status: d.Get("status").(string),
+	}
+
+	log.Printf("[DEBUG] List Options: %#v", listOpts)
+
+	allPages, err := volumes.List(blockStorageClient, listOpts).AllPages()
+	if err != nil {
+		return fmt.Errorf("Unable to retrieve volumes: %s", err)
+	}
+
+	allVolumes, err := volumes.ExtractVolumes(allPages)
+	if err != nil {
+		return fmt.Errorf("Unable to extract volumes: %s", err)
+	}
+
+	// The metadata are filtered here rather than by the API, which may
+	// add system keys to them.
+	metadata := d.Get("metadata").(map[string]interface{})
+
+	var refinedVolumes []volumes.Volume
+	for _, v := range allVolumes {
+		if blockStorageV2MetadataMatch(v.Metadata, metadata) {
+			refinedVolumes = append(refinedVolumes, v)
+		}
+	}
+
+	if len(refinedVolumes) < 1 {
+		return fmt.Errorf("Your query returned no results. " +
+			"Please change your search criteria and try again.")
+	}
+
+	if len(refinedVolumes) > 1 {
+		return fmt.Errorf("Your query returned more than one result." +
+			" Please try a more specific search criteria")
+	}
+
+	v := refinedVolumes[0]
+
+	log.Printf("[DEBUG] Retrieved Volume %s: %+v", v.ID, v)
+	d.SetId(v.ID)
+
+	d.Set("name", v.Name)
+	d.Set("status", v.Status)
+	d.Set("metadata", v.Metadata)
+	d.Set("size", v.Size)
+	d.Set("description", v.Description)
+	d.Set("availability_zone", v.AvailabilityZone)
+	d.Set("volume_type", v.VolumeType)
+	d.Set("bootable", v.Bootable)
+	d.Set("snapshot_id", v.SnapshotID)
+	d.Set("source_vol_id", v.SourceVolID)
+	d.Set("region", GetRegion(d, config))
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccBlockStorageV2VolumeDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBlockStorageV2VolumeDataSource_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBlockStorageV2VolumeDataSourceID("data.telefonicaopencloud_blockstorage_volume_v2.volume_1"),
+					resource.TestCheckResourceAttrPair(
+						"data.telefonicaopencloud_blockstorage_volume_v2.volume_1", "id",
+						"telefonicaopencloud_blockstorage_volume_v2.volume_1", "id"),
+					resource.TestCheckResourceAttr(
+						"data.telefonicaopencloud_blockstorage_volume_v2.volume_1", "name", "volume_ds_1"),
+					resource.TestCheckResourceAttr(
+						"data.telefonicaopencloud_blockstorage_volume_v2.volume_1", "size", "1"),
+					resource.TestCheckResourceAttr(
+						"data.telefonicaopencloud_blockstorage_volume_v2.volume_1", "status", "available"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBlockStorageV2VolumeDataSource_metadata(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBlockStorageV2VolumeDataSource_metadata,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBlockStorageV2VolumeDataSourceID("data.telefonicaopencloud_blockstorage_volume_v2.volume_1"),
+					resource.TestCheckResourceAttrPair(
+						"data.telefonicaopencloud_blockstorage_volume_v2.volume_1", "id",
+						"telefonicaopencloud_blockstorage_volume_v2.volume_2", "id"),
+					resource.TestCheckResourceAttr(
+						"data.telefonicaopencloud_blockstorage_volume_v2.volume_1", "metadata.foo", "baz"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckBlockStorageV2VolumeDataSourceID(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Can't find volume data source: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("Volume data source ID not set")
+		}
+
+		return nil
+	}
+}
+
+const testAccBlockStorageV2VolumeDataSource_basic = `
+resource "telefonicaopencloud_blockstorage_volume_v2" "volume_1" {
+  name = "volume_ds_1"
+  size = 1
+}
+
+data "telefonicaopencloud_blockstorage_volume_v2" "volume_1" {
+  name = "${telefonicaopencloud_blockstorage_volume_v2.volume_1.name}"
+  status = "available"
+}
+`
+
+const testAccBlockStorageV2VolumeDataSource_metadata = `
+resource "telefonicaopencloud_blockstorage_volume_v2" "volume_1" {
+  name = "volume_ds_1"
+  size = 1
+  metadata {
+    foo = "bar"
+  }
+}
+
+resource "telefonicaopencloud_blockstorage_volume_v2" "volume_2" {
+  name = "volume_ds_1"
+  size = 1
+  metadata {
+    foo = "baz"
+  }
+}
+
+data "telefonicaopencloud_blockstorage_volume_v2" "volume_1" {
+  name = "${telefonicaopencloud_blockstorage_volume_v2.volume_2.name}"
+  metadata {
+    foo = "${telefonicaopencloud_blockstorage_volume_v2.volume_2.metadata.foo}"
+  }
+}
+`
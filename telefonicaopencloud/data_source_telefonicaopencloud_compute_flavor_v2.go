@@ -0,0 +1,164 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/huaweicloud/golangsdk/openstack/compute/v2/flavors"
+)
+
+// computeFlavorV2PerformanceType is the extra spec holding the performance
+// type of a flavor, e.g. "normal", "computingv1" or "highmem".
+const computeFlavorV2PerformanceType = "ecs:performancetype"
+
+func dataSourceComputeFlavorV2() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceComputeFlavorV2Read,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"vcpus": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"ram": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"disk": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"performance_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"swap": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"rx_tx_factor": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			"is_public": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"extra_specs": {
+				Type:     schema.TypeMap,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceComputeFlavorV2Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	computeClient, err := config.computeV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud compute client: %s", err)
+	}
+
+	listOpts := flavors.ListOpts{
+		MinDisk:    d.Get("disk").(int),
+		MinRAM:     d.Get("ram").(int),
+		AccessType: flavors.PublicAccess,
+	}
+
+	log.Printf("[DEBUG] List Options: %#v", listOpts)
+
+	allPages, err := flavors.ListDetail(computeClient, listOpts).AllPages()
+	if err != nil {
+		return fmt.Errorf("Unable to retrieve flavors: %s", err)
+	}
+
+	allFlavors, err := flavors.ExtractFlavors(allPages)
+	if err != nil {
+		return fmt.Errorf("Unable to retrieve flavors: %s", err)
+	}
+
+	// The API only has lower bounds on the RAM and the disk, the exact values
+	// and the performance type are matched here.
+	performanceType := d.Get("performance_type").(string)
+	extraSpecs := make(map[string]map[string]string)
+
+	var refinedFlavors []flavors.Flavor
+	for _, flavor := range allFlavors {
+		if v, ok := d.GetOk("name"); ok && flavor.Name != v.(string) {
+			continue
+		}
+		if v, ok := d.GetOk("vcpus"); ok && flavor.VCPUs != v.(int) {
+			continue
+		}
+		if v, ok := d.GetOk("ram"); ok && flavor.RAM != v.(int) {
+			continue
+		}
+		if v, ok := d.GetOk("disk"); ok && flavor.Disk != v.(int) {
+			continue
+		}
+		if performanceType != "" {
+			es, err := flavors.ListExtraSpecs(computeClient, flavor.ID).Extract()
+			if err != nil {
+				return fmt.Errorf("Unable to retrieve the extra specs of flavor %s: %s", flavor.ID, err)
+			}
+			if es[computeFlavorV2PerformanceType] != performanceType {
+				continue
+			}
+			extraSpecs[flavor.ID] = es
+		}
+		refinedFlavors = append(refinedFlavors, flavor)
+	}
+
+	if len(refinedFlavors) < 1 {
+		return fmt.Errorf("Your query returned no results. " +
+			"Please change your search criteria and try again.")
+	}
+
+	if len(refinedFlavors) > 1 {
+		return fmt.Errorf("Your query returned more than one result." +
+			" Please try a more specific search criteria")
+	}
+
+	flavor := refinedFlavors[0]
+
+	log.Printf("[DEBUG] Retrieved Flavor %s: %+v", flavor.ID, flavor)
+	d.SetId(flavor.ID)
+
+	es, ok := extraSpecs[flavor.ID]
+	if !ok {
+		es, err = flavors.ListExtraSpecs(computeClient, flavor.ID).Extract()
+		if err != nil {
+			return fmt.Errorf("Unable to retrieve the extra specs of flavor %s: %s", flavor.ID, err)
+		}
+	}
+
+	d.Set("name", flavor.Name)
+	d.Set("vcpus", flavor.VCPUs)
+	d.Set("ram", flavor.RAM)
+	d.Set("disk", flavor.Disk)
+	d.Set("swap", flavor.Swap)
+	d.Set("rx_tx_factor", flavor.RxTxFactor)
+	d.Set("is_public", flavor.IsPublic)
+	d.Set("performance_type", es[computeFlavorV2PerformanceType])
+	if err := d.Set("extra_specs", es); err != nil {
+		log.Printf("[DEBUG] Error saving extra_specs to state for TelefonicaOpenCloud flavor (%s): %s", d.Id(), err)
+	}
+	d.Set("region", GetRegion(d, config))
+
+	return nil
+}
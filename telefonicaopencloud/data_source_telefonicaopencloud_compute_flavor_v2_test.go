@@ -0,0 +1,81 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccComputeV2FlavorDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccComputeV2FlavorDataSource_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2FlavorDataSourceID("data.telefonicaopencloud_compute_flavor_v2.flavor_1"),
+					resource.TestCheckResourceAttr(
+						"data.telefonicaopencloud_compute_flavor_v2.flavor_1", "name", OS_FLAVOR_NAME),
+					resource.TestCheckResourceAttrSet(
+						"data.telefonicaopencloud_compute_flavor_v2.flavor_1", "performance_type"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccComputeV2FlavorDataSource_performanceType(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccComputeV2FlavorDataSource_performanceType,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2FlavorDataSourceID("data.telefonicaopencloud_compute_flavor_v2.flavor_1"),
+					resource.TestCheckResourceAttr(
+						"data.telefonicaopencloud_compute_flavor_v2.flavor_1", "vcpus", "2"),
+					resource.TestCheckResourceAttr(
+						"data.telefonicaopencloud_compute_flavor_v2.flavor_1", "ram", "8192"),
+					resource.TestCheckResourceAttr(
+						"data.telefonicaopencloud_compute_flavor_v2.flavor_1", "performance_type", "computingv1"),
+					resource.TestCheckResourceAttr(
+						"data.telefonicaopencloud_compute_flavor_v2.flavor_1", "extra_specs.ecs:performancetype", "computingv1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckComputeV2FlavorDataSourceID(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Can't find flavor data source: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("Flavor data source ID not set")
+		}
+
+		return nil
+	}
+}
+
+var testAccComputeV2FlavorDataSource_basic = fmt.Sprintf(`
+data "telefonicaopencloud_compute_flavor_v2" "flavor_1" {
+  name = "%s"
+}
+`, OS_FLAVOR_NAME)
+
+const testAccComputeV2FlavorDataSource_performanceType = `
+data "telefonicaopencloud_compute_flavor_v2" "flavor_1" {
+  vcpus = 2
+  ram = 8192
+  disk = 40
+  performance_type = "computingv1"
+}
+`
@@ -0,0 +1,280 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/compute/v2/extensions/availabilityzones"
+	"github.com/huaweicloud/golangsdk/openstack/compute/v2/flavors"
+	"github.com/huaweicloud/golangsdk/openstack/compute/v2/images"
+	"github.com/huaweicloud/golangsdk/openstack/compute/v2/servers"
+)
+
+// computeInstanceV2WithAZ is a server along with its availability zone.
+type computeInstanceV2WithAZ struct {
+	servers.Server
+	availabilityzones.ServerAvailabilityZoneExt
+}
+
+func dataSourceComputeInstanceV2() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceComputeInstanceV2Read,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"image_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"image_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"flavor_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"flavor_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"key_pair": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"security_groups": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"availability_zone": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Computed: true,
+			},
+			"access_ip_v4": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"access_ip_v6": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"network": dataSourceComputeInstanceV2NetworkSchema(),
+		},
+	}
+}
+
+func dataSourceComputeInstanceV2NetworkSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"uuid": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"name": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"fixed_ip_v4": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"fixed_ip_v6": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"floating_ip": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"mac": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceComputeInstanceV2Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	computeClient, err := config.computeV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud compute client: %s", err)
+	}
+	networkingClient, err := config.networkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud networking client: %s", err)
+	}
+
+	var allServers []computeInstanceV2WithAZ
+	if id := d.Get("id").(string); id != "" {
+		var server computeInstanceV2WithAZ
+		err := servers.Get(computeClient, id).ExtractInto(&server)
+		if err != nil {
+			if _, ok := err.(golangsdk.ErrDefault404); !ok {
+				return fmt.Errorf("Unable to retrieve server %s: %s", id, err)
+			}
+		} else {
+			allServers = append(allServers, server)
+		}
+	} else {
+		allServers, err = listComputeInstancesV2(computeClient, servers.ListOpts{
+			Name: d.Get("name").(string),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// The name is matched as a regular expression by the API.
+	var refinedServers []computeInstanceV2WithAZ
+	for _, server := range allServers {
+		if name := d.Get("name").(string); name != "" && server.Name != name {
+			continue
+		}
+		refinedServers = append(refinedServers, server)
+	}
+
+	if len(refinedServers) < 1 {
+		return fmt.Errorf("Your query returned no results. " +
+			"Please change your search criteria and try again.")
+	}
+
+	if len(refinedServers) > 1 {
+		return fmt.Errorf("Your query returned more than one result." +
+			" Please try a more specific search criteria")
+	}
+
+	server := refinedServers[0]
+
+	log.Printf("[DEBUG] Retrieved Server %s: %+v", server.ID, server)
+	d.SetId(server.ID)
+
+	instance, err := flattenComputeInstanceV2(computeClient, networkingClient, &server)
+	if err != nil {
+		return err
+	}
+	for k, v := range instance {
+		if err := d.Set(k, v); err != nil {
+			log.Printf("[DEBUG] Error saving %s to state for TelefonicaOpenCloud server (%s): %s", k, d.Id(), err)
+		}
+	}
+	d.Set("region", GetRegion(d, config))
+
+	return nil
+}
+
+// listComputeInstancesV2 lists the servers matching the given options, along
+// with their availability zones.
+func listComputeInstancesV2(client *golangsdk.ServiceClient, listOpts servers.ListOpts) ([]computeInstanceV2WithAZ, error) {
+	allPages, err := servers.List(client, listOpts).AllPages()
+	if err != nil {
+		return nil, fmt.Errorf("Unable to retrieve servers: %s", err)
+	}
+
+	// ExtractServersInto can't handle the body of an empty list.
+	if empty, err := allPages.IsEmpty(); err != nil || empty {
+		return nil, err
+	}
+
+	var allServers []computeInstanceV2WithAZ
+	if err := servers.ExtractServersInto(allPages, &allServers); err != nil {
+		return nil, fmt.Errorf("Unable to retrieve servers: %s", err)
+	}
+
+	return allServers, nil
+}
+
+// flattenComputeInstanceV2 returns the attributes of a server exposed by the
+// compute instance data sources.
+func flattenComputeInstanceV2(computeClient, networkingClient *golangsdk.ServiceClient,
+	server *computeInstanceV2WithAZ) (map[string]interface{}, error) {
+
+	networks := getInstanceNetworksAndAddresses(networkingClient, &server.Server)
+
+	// There is no access network without a configuration, so the first
+	// addresses found are used.
+	hostv4, hostv6 := getInstanceAccessAddresses(nil, networks)
+	if server.AccessIPv4 != "" && hostv4 == "" {
+		hostv4 = server.AccessIPv4
+	}
+	if server.AccessIPv6 != "" && hostv6 == "" {
+		hostv6 = server.AccessIPv6
+	}
+
+	secGroups := []string{}
+	for _, sg := range server.SecurityGroups {
+		if name, ok := sg["name"].(string); ok {
+			secGroups = append(secGroups, name)
+		}
+	}
+
+	instance := map[string]interface{}{
+		"id":                server.ID,
+		"name":              server.Name,
+		"status":            server.Status,
+		"key_pair":          server.KeyName,
+		"security_groups":   secGroups,
+		"availability_zone": server.AvailabilityZone,
+		"metadata":          server.Metadata,
+		"access_ip_v4":      hostv4,
+		"access_ip_v6":      hostv6,
+		"network":           networks,
+	}
+
+	flavorID, ok := server.Flavor["id"].(string)
+	if !ok {
+		return nil, fmt.Errorf("Error setting TelefonicaOpenCloud server's flavor: %v", server.Flavor)
+	}
+	instance["flavor_id"] = flavorID
+
+	flavor, err := flavors.Get(computeClient, flavorID).Extract()
+	if err != nil {
+		return nil, err
+	}
+	instance["flavor_name"] = flavor.Name
+
+	// Servers booted from a volume have no image.
+	if imageID, ok := server.Image["id"].(string); ok && imageID != "" {
+		instance["image_id"] = imageID
+		image, err := images.Get(computeClient, imageID).Extract()
+		if err != nil {
+			if _, ok := err.(golangsdk.ErrDefault404); !ok {
+				return nil, err
+			}
+			instance["image_name"] = "Image not found"
+		} else {
+			instance["image_name"] = image.Name
+		}
+	}
+
+	return instance, nil
+}
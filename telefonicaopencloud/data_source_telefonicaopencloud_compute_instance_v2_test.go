@@ -0,0 +1,85 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccComputeV2InstanceDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeV2InstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccComputeV2InstanceDataSource_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"data.telefonicaopencloud_compute_instance_v2.by_id", "id",
+						"telefonicaopencloud_compute_instance_v2.instance_1", "id"),
+					resource.TestCheckResourceAttr(
+						"data.telefonicaopencloud_compute_instance_v2.by_id", "name", "instance_1"),
+					resource.TestCheckResourceAttr(
+						"data.telefonicaopencloud_compute_instance_v2.by_id", "status", "ACTIVE"),
+					resource.TestCheckResourceAttr(
+						"data.telefonicaopencloud_compute_instance_v2.by_id", "availability_zone", OS_AVAILABILITY_ZONE),
+					resource.TestCheckResourceAttr(
+						"data.telefonicaopencloud_compute_instance_v2.by_id", "metadata.foo", "bar"),
+					resource.TestCheckResourceAttr(
+						"data.telefonicaopencloud_compute_instance_v2.by_id", "security_groups.0", "default"),
+					resource.TestCheckResourceAttr(
+						"data.telefonicaopencloud_compute_instance_v2.by_id", "network.#", "1"),
+					resource.TestCheckResourceAttr(
+						"data.telefonicaopencloud_compute_instance_v2.by_id", "network.0.uuid", OS_NETWORK_ID),
+					resource.TestCheckResourceAttrPair(
+						"data.telefonicaopencloud_compute_instance_v2.by_id", "network.0.fixed_ip_v4",
+						"telefonicaopencloud_compute_instance_v2.instance_1", "network.0.fixed_ip_v4"),
+					resource.TestCheckResourceAttrPair(
+						"data.telefonicaopencloud_compute_instance_v2.by_id", "network.0.floating_ip",
+						"telefonicaopencloud_networking_floatingip_v2.fip_1", "address"),
+					resource.TestCheckResourceAttrPair(
+						"data.telefonicaopencloud_compute_instance_v2.by_id", "flavor_id",
+						"telefonicaopencloud_compute_instance_v2.instance_1", "flavor_id"),
+					resource.TestCheckResourceAttrPair(
+						"data.telefonicaopencloud_compute_instance_v2.by_id", "image_name",
+						"telefonicaopencloud_compute_instance_v2.instance_1", "image_name"),
+					resource.TestCheckResourceAttrPair(
+						"data.telefonicaopencloud_compute_instance_v2.by_name", "id",
+						"telefonicaopencloud_compute_instance_v2.instance_1", "id"),
+				),
+			},
+		},
+	})
+}
+
+var testAccComputeV2InstanceDataSource_basic = fmt.Sprintf(`
+resource "telefonicaopencloud_compute_instance_v2" "instance_1" {
+  name = "instance_1"
+  security_groups = ["default"]
+  availability_zone = "%s"
+  metadata {
+    foo = "bar"
+  }
+  network {
+    uuid = "%s"
+  }
+}
+
+resource "telefonicaopencloud_networking_floatingip_v2" "fip_1" {
+}
+
+resource "telefonicaopencloud_compute_floatingip_associate_v2" "fip_1" {
+  floating_ip = "${telefonicaopencloud_networking_floatingip_v2.fip_1.address}"
+  instance_id = "${telefonicaopencloud_compute_instance_v2.instance_1.id}"
+}
+
+data "telefonicaopencloud_compute_instance_v2" "by_id" {
+  id = "${telefonicaopencloud_compute_floatingip_associate_v2.fip_1.instance_id}"
+}
+
+data "telefonicaopencloud_compute_instance_v2" "by_name" {
+  name = "${telefonicaopencloud_compute_instance_v2.instance_1.name}"
+}
+`, OS_AVAILABILITY_ZONE, OS_NETWORK_ID)
@@ -0,0 +1,195 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/huaweicloud/golangsdk/openstack/compute/v2/servers"
+)
+
+func dataSourceComputeInstancesV2() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceComputeInstancesV2Read,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"name_regex": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					if _, err := regexp.Compile(v.(string)); err != nil {
+						errors = append(errors, fmt.Errorf("%q is not a valid regular expression: %s", k, err))
+					}
+					return
+				},
+			},
+			"flavor_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+			},
+			"availability_zone": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"instances": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"image_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"image_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"flavor_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"flavor_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"key_pair": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"security_groups": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"availability_zone": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"metadata": {
+							Type:     schema.TypeMap,
+							Computed: true,
+						},
+						"access_ip_v4": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"access_ip_v6": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"network": dataSourceComputeInstanceV2NetworkSchema(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceComputeInstancesV2Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	computeClient, err := config.computeV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud compute client: %s", err)
+	}
+	networkingClient, err := config.networkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud networking client: %s", err)
+	}
+
+	listOpts := servers.ListOpts{
+		Flavor: d.Get("flavor_id").(string),
+		Status: d.Get("status").(string),
+	}
+
+	allServers, err := listComputeInstancesV2(computeClient, listOpts)
+	if err != nil {
+		return err
+	}
+
+	// The name regex and the metadata are matched here, since the API uses
+	// the regular expression syntax of its database and has no metadata
+	// filter.
+	var nameRegex *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(v.(string))
+	}
+	metadata := d.Get("metadata").(map[string]interface{})
+	az := d.Get("availability_zone").(string)
+
+	var ids []string
+	var instances []map[string]interface{}
+	for i := range allServers {
+		server := &allServers[i]
+		if nameRegex != nil && !nameRegex.MatchString(server.Name) {
+			continue
+		}
+		if az != "" && server.AvailabilityZone != az {
+			continue
+		}
+		if !computeInstanceV2MetadataMatch(server.Metadata, metadata) {
+			continue
+		}
+
+		instance, err := flattenComputeInstanceV2(computeClient, networkingClient, server)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, server.ID)
+		instances = append(instances, instance)
+	}
+
+	log.Printf("[DEBUG] Retrieved %d servers using the given filters", len(ids))
+
+	d.SetId(fmt.Sprintf("%d", hashcode.String(strings.Join(ids, ","))))
+	d.Set("ids", ids)
+	if err := d.Set("instances", instances); err != nil {
+		return fmt.Errorf("Error saving instances to state for TelefonicaOpenCloud servers (%s): %s", d.Id(), err)
+	}
+	d.Set("region", GetRegion(d, config))
+
+	return nil
+}
+
+// computeInstanceV2MetadataMatch reports whether a server has all the given
+// metadata.
+func computeInstanceV2MetadataMatch(metadata map[string]string, filter map[string]interface{}) bool {
+	for k, v := range filter {
+		if value, ok := metadata[k]; !ok || value != v.(string) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,90 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccComputeV2InstancesDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeV2InstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccComputeV2InstancesDataSource_instances,
+			},
+			{
+				Config: testAccComputeV2InstancesDataSource_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.telefonicaopencloud_compute_instances_v2.by_name", "ids.#", "2"),
+					resource.TestCheckResourceAttr(
+						"data.telefonicaopencloud_compute_instances_v2.by_metadata", "ids.#", "1"),
+					resource.TestCheckResourceAttrPair(
+						"data.telefonicaopencloud_compute_instances_v2.by_metadata", "ids.0",
+						"telefonicaopencloud_compute_instance_v2.instance_2", "id"),
+					resource.TestCheckResourceAttr(
+						"data.telefonicaopencloud_compute_instances_v2.by_metadata", "instances.0.name", "instances_ds_2"),
+					resource.TestCheckResourceAttr(
+						"data.telefonicaopencloud_compute_instances_v2.by_metadata", "instances.0.metadata.role", "db"),
+					resource.TestCheckResourceAttrPair(
+						"data.telefonicaopencloud_compute_instances_v2.by_metadata", "instances.0.network.0.fixed_ip_v4",
+						"telefonicaopencloud_compute_instance_v2.instance_2", "network.0.fixed_ip_v4"),
+					resource.TestCheckResourceAttr(
+						"data.telefonicaopencloud_compute_instances_v2.none", "ids.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+var testAccComputeV2InstancesDataSource_instances = fmt.Sprintf(`
+resource "telefonicaopencloud_compute_instance_v2" "instance_1" {
+  name = "instances_ds_1"
+  security_groups = ["default"]
+  availability_zone = "%s"
+  metadata {
+    role = "web"
+  }
+  network {
+    uuid = "%s"
+  }
+}
+
+resource "telefonicaopencloud_compute_instance_v2" "instance_2" {
+  name = "instances_ds_2"
+  security_groups = ["default"]
+  availability_zone = "%s"
+  metadata {
+    role = "db"
+  }
+  network {
+    uuid = "%s"
+  }
+}
+`, OS_AVAILABILITY_ZONE, OS_NETWORK_ID, OS_AVAILABILITY_ZONE, OS_NETWORK_ID)
+
+var testAccComputeV2InstancesDataSource_basic = fmt.Sprintf(`
+%s
+
+data "telefonicaopencloud_compute_instances_v2" "by_name" {
+  name_regex = "^instances_ds_"
+  status = "ACTIVE"
+  availability_zone = "%s"
+}
+
+data "telefonicaopencloud_compute_instances_v2" "by_metadata" {
+  name_regex = "^instances_ds_"
+  metadata {
+    role = "db"
+  }
+}
+
+data "telefonicaopencloud_compute_instances_v2" "none" {
+  name_regex = "^instances_ds_"
+  flavor_id = "no-such-flavor"
+}
+`, testAccComputeV2InstancesDataSource_instances, OS_AVAILABILITY_ZONE)
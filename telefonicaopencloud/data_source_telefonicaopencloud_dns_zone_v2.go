@@ -104,6 +104,29 @@ func dataSourceDNSZoneV2() *schema.Resource {
 				Computed: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
+
+			"zone_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"router": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"router_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"router_region": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -141,16 +164,34 @@ func dataSourceDNSZoneV2Read(d *schema.ResourceData, meta interface{}) error {
 		listOpts.Type = v.(string)
 	}
 
+	// The type query selects between public and private zones, so the type
+	// of the zone is filtered afterwards when both are given.
+	zoneType := d.Get("zone_type").(string)
+	if zoneType != "" {
+		listOpts.Type = zoneType
+	}
+
 	pages, err := zones.List(dnsClient, listOpts).AllPages()
 	if err != nil {
 		return fmt.Errorf("Unable to retrieve zones: %s", err)
 	}
 
-	allZones, err := zones.ExtractZones(pages)
+	refinedZones, err := zones.ExtractZones(pages)
 	if err != nil {
 		return fmt.Errorf("Unable to extract zones: %s", err)
 	}
 
+	var allZones []zones.Zone
+	for _, zone := range refinedZones {
+		if zoneType != "" && zone.ZoneType != zoneType {
+			continue
+		}
+		if v, ok := d.GetOk("type"); ok && zoneType != "" && zone.Type != v.(string) {
+			continue
+		}
+		allZones = append(allZones, zone)
+	}
+
 	if len(allZones) < 1 {
 		return fmt.Errorf("Your query returned no results. " +
 			"Please change your search criteria and try again.")
@@ -174,6 +215,7 @@ func dataSourceDNSZoneV2Read(d *schema.ResourceData, meta interface{}) error {
 	d.Set("description", zone.Description)
 	d.Set("status", zone.Status)
 	d.Set("type", zone.Type)
+	d.Set("zone_type", zone.ZoneType)
 	d.Set("region", GetRegion(d, config))
 
 	// ints
@@ -200,5 +242,18 @@ func dataSourceDNSZoneV2Read(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
+	var routers []dnsZoneV2Router
+	if zone.ZoneType == "private" {
+		routers, err = getDNSZoneV2Routers(dnsClient, zone.ID)
+		if err != nil {
+			return fmt.Errorf("Unable to retrieve routers of zone %s: %s", zone.ID, err)
+		}
+	}
+	err = d.Set("router", flattenDNSZoneV2Routers(routers))
+	if err != nil {
+		log.Printf("[DEBUG] Unable to set router: %s", err)
+		return err
+	}
+
 	return nil
 }
@@ -35,6 +35,27 @@ func TestAccTelefonicaOpenCloudDNSZoneV2DataSource_basic(t *testing.T) {
 	})
 }
 
+func TestAccTelefonicaOpenCloudDNSZoneV2DataSource_private(t *testing.T) {
+	var privateZoneName = fmt.Sprintf("ACPTTEST%s.com.", acctest.RandString(5))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheckDNS(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTelefonicaOpenCloudDNSZoneV2DataSource_private(privateZoneName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDNSZoneV2DataSourceID("data.telefonicaopencloud_dns_zone_v2.z1"),
+					resource.TestCheckResourceAttr(
+						"data.telefonicaopencloud_dns_zone_v2.z1", "zone_type", "private"),
+					resource.TestCheckResourceAttr(
+						"data.telefonicaopencloud_dns_zone_v2.z1", "router.#", "2"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckDNSZoneV2DataSourceID(n string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -64,3 +85,36 @@ data "telefonicaopencloud_dns_zone_v2" "z1" {
 	name = "${telefonicaopencloud_dns_zone_v2.z1.name}"
 }
 `, testAccTelefonicaOpenCloudDNSZoneV2DataSource_zone)
+
+func testAccTelefonicaOpenCloudDNSZoneV2DataSource_private(zoneName string) string {
+	return fmt.Sprintf(`
+resource "telefonicaopencloud_vpc_v1" "vpc_1" {
+  name = "vpc_test"
+  cidr = "192.168.0.0/16"
+}
+
+resource "telefonicaopencloud_vpc_v1" "vpc_2" {
+  name = "vpc_test1"
+  cidr = "172.16.0.0/16"
+}
+
+resource "telefonicaopencloud_dns_zone_v2" "z1" {
+  name = "%s"
+  email = "terraform-dns-zone-v2-test-name@example.com"
+  zone_type = "private"
+
+  router {
+    router_id = "${telefonicaopencloud_vpc_v1.vpc_1.id}"
+  }
+
+  router {
+    router_id = "${telefonicaopencloud_vpc_v1.vpc_2.id}"
+  }
+}
+
+data "telefonicaopencloud_dns_zone_v2" "z1" {
+  name = "${telefonicaopencloud_dns_zone_v2.z1.name}"
+  zone_type = "private"
+}
+`, zoneName)
+}
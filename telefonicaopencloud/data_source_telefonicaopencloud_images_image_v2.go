@@ -0,0 +1,219 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceImagesImageV2() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceImagesImageV2Read,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"name_regex"},
+			},
+			"name_regex": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"name"},
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					if _, err := regexp.Compile(v.(string)); err != nil {
+						errors = append(errors, fmt.Errorf("%q is not a valid regular expression: %s", k, err))
+					}
+					return
+				},
+			},
+			"visibility": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					return ValidateStringList(v, k, []string{"public", "private", "shared", "community"})
+				},
+			},
+			"owner": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"tag": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"properties": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Computed: true,
+			},
+			"most_recent": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"tags": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"container_format": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"disk_format": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"min_disk_gb": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"min_ram_mb": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"protected": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"checksum": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"size_bytes": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"file": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"schema": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"created_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"updated_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceImagesImageV2Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	imageClient, err := config.imageV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud image client: %s", err)
+	}
+
+	listOpts := imageV2ListOpts{
+		Name:       d.Get("name").(string),
+		Visibility: d.Get("visibility").(string),
+		Owner:      d.Get("owner").(string),
+		Status:     "active",
+	}
+	if tag := d.Get("tag").(string); tag != "" {
+		listOpts.Tags = []string{tag}
+	}
+
+	log.Printf("[DEBUG] List Options: %#v", listOpts)
+
+	allImages, err := listImagesV2(imageClient, listOpts)
+	if err != nil {
+		return fmt.Errorf("Unable to retrieve images: %s", err)
+	}
+
+	// The API has no filter on the name pattern nor on the properties.
+	var nameRegex *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(v.(string))
+	}
+	properties := d.Get("properties").(map[string]interface{})
+
+	var refinedImages []imageV2
+	for _, image := range allImages {
+		if nameRegex != nil && !nameRegex.MatchString(image.Name) {
+			continue
+		}
+		if !imageV2PropertiesMatch(image.Properties, properties) {
+			continue
+		}
+		refinedImages = append(refinedImages, image)
+	}
+
+	if len(refinedImages) < 1 {
+		return fmt.Errorf("Your query returned no results. " +
+			"Please change your search criteria and try again.")
+	}
+
+	if len(refinedImages) > 1 {
+		if !d.Get("most_recent").(bool) {
+			return fmt.Errorf("Your query returned more than one result." +
+				" Please try a more specific search criteria, or set `most_recent` to true")
+		}
+		sort.Slice(refinedImages, func(i, j int) bool {
+			return refinedImages[i].CreatedAt.After(refinedImages[j].CreatedAt)
+		})
+	}
+
+	image := refinedImages[0]
+
+	log.Printf("[DEBUG] Retrieved Image %s: %+v", image.ID, image)
+	d.SetId(image.ID)
+
+	d.Set("name", image.Name)
+	d.Set("visibility", image.Visibility)
+	d.Set("owner", image.Owner)
+	d.Set("status", image.Status)
+	d.Set("tags", image.Tags)
+	d.Set("container_format", image.ContainerFormat)
+	d.Set("disk_format", image.DiskFormat)
+	d.Set("min_disk_gb", image.MinDisk)
+	d.Set("min_ram_mb", image.MinRAM)
+	d.Set("protected", image.Protected)
+	d.Set("checksum", image.Checksum)
+	d.Set("size_bytes", image.SizeBytes)
+	d.Set("file", image.File)
+	d.Set("schema", image.Schema)
+	d.Set("created_at", image.CreatedAt.Format(time.RFC3339))
+	d.Set("updated_at", image.UpdatedAt.Format(time.RFC3339))
+	if err := d.Set("properties", image.Properties); err != nil {
+		log.Printf("[DEBUG] Error saving properties to state for TelefonicaOpenCloud image (%s): %s", d.Id(), err)
+	}
+	d.Set("region", GetRegion(d, config))
+
+	return nil
+}
+
+// imageV2PropertiesMatch reports whether an image has all the given
+// properties.
+func imageV2PropertiesMatch(properties map[string]string, filter map[string]interface{}) bool {
+	for k, v := range filter {
+		if value, ok := properties[k]; !ok || value != v.(string) {
+			return false
+		}
+	}
+	return true
+}
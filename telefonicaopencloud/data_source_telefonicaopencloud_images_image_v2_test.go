@@ -0,0 +1,117 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccImagesImageV2DataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccImagesImageV2DataSource_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckImagesImageV2DataSourceID("data.telefonicaopencloud_images_image_v2.image_1"),
+					resource.TestCheckResourceAttr(
+						"data.telefonicaopencloud_images_image_v2.image_1", "name", OS_IMAGE_NAME),
+					resource.TestCheckResourceAttr(
+						"data.telefonicaopencloud_images_image_v2.image_1", "status", "active"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccImagesImageV2DataSource_mostRecent(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccImagesImageV2DataSource_mostRecent,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckImagesImageV2DataSourceID("data.telefonicaopencloud_images_image_v2.image_1"),
+					resource.TestCheckResourceAttr(
+						"data.telefonicaopencloud_images_image_v2.image_1", "properties.__os_type", "Linux"),
+					testAccCheckImagesImageV2DataSourceMostRecent("data.telefonicaopencloud_images_image_v2.image_1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckImagesImageV2DataSourceID(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Can't find image data source: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("Image data source ID not set")
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckImagesImageV2DataSourceMostRecent checks that no active image
+// with the same properties was created after the one found.
+func testAccCheckImagesImageV2DataSourceMostRecent(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Can't find image data source: %s", n)
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		imageClient, err := config.imageV2Client(OS_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating TelefonicaOpenCloud image client: %s", err)
+		}
+
+		allImages, err := listImagesV2(imageClient, imageV2ListOpts{Status: "active"})
+		if err != nil {
+			return err
+		}
+
+		var found *imageV2
+		for i := range allImages {
+			if allImages[i].ID == rs.Primary.ID {
+				found = &allImages[i]
+			}
+		}
+		if found == nil {
+			return fmt.Errorf("Image %s not found", rs.Primary.ID)
+		}
+
+		for _, image := range allImages {
+			if image.Properties["__os_type"] == "Linux" && image.CreatedAt.After(found.CreatedAt) {
+				return fmt.Errorf("Image %s was created after image %s", image.ID, found.ID)
+			}
+		}
+
+		return nil
+	}
+}
+
+var testAccImagesImageV2DataSource_basic = fmt.Sprintf(`
+data "telefonicaopencloud_images_image_v2" "image_1" {
+  name = "%s"
+}
+`, OS_IMAGE_NAME)
+
+var testAccImagesImageV2DataSource_mostRecent = `
+data "telefonicaopencloud_images_image_v2" "image_1" {
+  name_regex = ".+"
+  most_recent = true
+  properties {
+    __os_type = "Linux"
+  }
+}
+`
@@ -0,0 +1,98 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceVPCRouteV2() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceVPCRouteV2Read,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"vpc_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"destination": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"nexthop": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceVPCRouteV2Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	routeClient, err := config.hwNetworkV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud Vpc Route Client: %s", err)
+	}
+
+	listOpts := vpcRouteV2ListOpts{
+		ID:          d.Get("id").(string),
+		Type:        d.Get("type").(string),
+		VpcID:       d.Get("vpc_id").(string),
+		Destination: d.Get("destination").(string),
+		TenantID:    d.Get("tenant_id").(string),
+	}
+
+	refinedRoutes, err := listVpcRoutesV2(routeClient, listOpts)
+	if err != nil {
+		return fmt.Errorf("Unable to retrieve vpc routes: %s", err)
+	}
+
+	if len(refinedRoutes) < 1 {
+		return fmt.Errorf("Your query returned no results. " +
+			"Please change your search criteria and try again.")
+	}
+
+	if len(refinedRoutes) > 1 {
+		return fmt.Errorf("Your query returned more than one result." +
+			" Please try a more specific search criteria")
+	}
+
+	Route := refinedRoutes[0]
+
+	log.Printf("[INFO] Retrieved Vpc Route using given filter %s: %+v", Route.ID, Route)
+	d.SetId(Route.ID)
+
+	d.Set("id", Route.ID)
+	d.Set("type", Route.Type)
+	d.Set("nexthop", Route.NextHop)
+	d.Set("destination", Route.Destination)
+	d.Set("vpc_id", Route.VpcID)
+	d.Set("tenant_id", Route.TenantID)
+	d.Set("region", GetRegion(d, config))
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccVpcRouteV2DataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVpcRouteV2Config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccDataSourceVpcRouteV2Check("data.telefonicaopencloud_vpc_route_v2.by_id"),
+					testAccDataSourceVpcRouteV2Check("data.telefonicaopencloud_vpc_route_v2.by_vpc_id"),
+					resource.TestCheckResourceAttr(
+						"data.telefonicaopencloud_vpc_route_v2.by_vpc_id", "type", "peering"),
+					resource.TestCheckResourceAttr(
+						"data.telefonicaopencloud_vpc_route_v2.by_vpc_id", "destination", "172.16.0.0/24"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceVpcRouteV2Check(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("root module has no resource called %s", n)
+		}
+
+		routeRs, ok := s.RootModule().Resources["telefonicaopencloud_vpc_route_v2.route_1"]
+		if !ok {
+			return fmt.Errorf("can't find telefonicaopencloud_vpc_route_v2.route_1 in state")
+		}
+
+		attr := rs.Primary.Attributes
+
+		if attr["id"] != routeRs.Primary.Attributes["id"] {
+			return fmt.Errorf(
+				"id is %s; want %s",
+				attr["id"],
+				routeRs.Primary.Attributes["id"],
+			)
+		}
+
+		if attr["nexthop"] != routeRs.Primary.Attributes["nexthop"] {
+			return fmt.Errorf(
+				"nexthop is %s; want %s",
+				attr["nexthop"],
+				routeRs.Primary.Attributes["nexthop"],
+			)
+		}
+
+		return nil
+	}
+}
+
+var testAccDataSourceVpcRouteV2Config = fmt.Sprintf(`
+%s
+
+data "telefonicaopencloud_vpc_route_v2" "by_id" {
+  id = "${telefonicaopencloud_vpc_route_v2.route_1.id}"
+}
+
+data "telefonicaopencloud_vpc_route_v2" "by_vpc_id" {
+  vpc_id = "${telefonicaopencloud_vpc_route_v2.route_1.vpc_id}"
+}
+`, testAccVpcRouteV2_basic)
@@ -31,3 +31,23 @@ func suppressComputedFixedWhenFloatingIp(k, old, new string, d *schema.ResourceD
 	}
 	return false
 }
+
+// Suppress changes of the bandwidth of an EIP which was added to a shared
+// bandwidth. Its own bandwidth settings only apply again once it is removed.
+func suppressSharedBandwidthDiffs(k, old, new string, d *schema.ResourceData) bool {
+	shareType, _ := d.GetChange("bandwidth.0.share_type")
+	return shareType.(string) == "WHOLE"
+}
+
+// Suppress changes of a zone file which hold the same record sets, as the
+// zone file in the state is rendered from the records read from the API.
+func suppressEquivalentDNSZoneFiles(k, old, new string, d *schema.ResourceData) bool {
+	return dnsZoneFileEqual(old, new)
+}
+
+// Suppress changes of an argument which isn't returned by the API and can't
+// be updated, as long as it's empty in the state of an existing resource,
+// i.e. after the resource was imported.
+func suppressUnreadAfterImport(k, old, new string, d *schema.ResourceData) bool {
+	return old == "" && d.Id() != ""
+}
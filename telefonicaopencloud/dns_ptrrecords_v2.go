@@ -0,0 +1,78 @@
+package telefonicaopencloud
+
+import (
+	"github.com/huaweicloud/golangsdk"
+)
+
+// dnsPtrRecordV2 is the reverse DNS record of a floating IP, as returned by
+// the DNS v2 API.
+type dnsPtrRecordV2 struct {
+	// ID is made of the region and the ID of the floating IP, separated by a
+	// colon.
+	ID          string `json:"id"`
+	PtrDName    string `json:"ptrdname"`
+	Description string `json:"description"`
+	TTL         int    `json:"ttl"`
+	Address     string `json:"address"`
+	Status      string `json:"status"`
+}
+
+// dnsPtrRecordV2SetOpts contains the values needed to set the reverse DNS
+// record of a floating IP.
+type dnsPtrRecordV2SetOpts struct {
+	// PtrDName is the domain name the address resolves to, with the trailing
+	// dot.
+	PtrDName string `json:"ptrdname" required:"true"`
+
+	Description string `json:"description,omitempty"`
+
+	TTL int `json:"ttl,omitempty"`
+}
+
+// ToPtrRecordSetMap builds a request body from dnsPtrRecordV2SetOpts.
+func (opts dnsPtrRecordV2SetOpts) ToPtrRecordSetMap() (map[string]interface{}, error) {
+	return golangsdk.BuildRequestBody(opts, "")
+}
+
+func dnsPtrRecordV2URL(client *golangsdk.ServiceClient, id string) string {
+	return client.ServiceURL("reverse", "floatingips", id)
+}
+
+// getDNSPtrRecordV2 retrieves the reverse DNS record of a floating IP. The ID
+// is <region>:<floatingip_id>.
+func getDNSPtrRecordV2(client *golangsdk.ServiceClient, id string) (*dnsPtrRecordV2, error) {
+	var r dnsPtrRecordV2
+	_, err := client.Get(dnsPtrRecordV2URL(client, id), &r, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// setDNSPtrRecordV2 creates or updates the reverse DNS record of a floating
+// IP.
+func setDNSPtrRecordV2(client *golangsdk.ServiceClient, id string, opts dnsPtrRecordV2SetOpts) (*dnsPtrRecordV2, error) {
+	b, err := opts.ToPtrRecordSetMap()
+	if err != nil {
+		return nil, err
+	}
+
+	var r dnsPtrRecordV2
+	_, err = client.Patch(dnsPtrRecordV2URL(client, id), b, &r, &golangsdk.RequestOpts{
+		OkCodes: []int{200, 202},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// unsetDNSPtrRecordV2 removes the reverse DNS record of a floating IP, which
+// falls back to the default one of the service.
+func unsetDNSPtrRecordV2(client *golangsdk.ServiceClient, id string) error {
+	b := map[string]interface{}{"ptrdname": nil}
+	_, err := client.Patch(dnsPtrRecordV2URL(client, id), b, nil, &golangsdk.RequestOpts{
+		OkCodes: []int{200, 202},
+	})
+	return err
+}
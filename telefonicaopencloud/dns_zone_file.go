@@ -0,0 +1,325 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// dnsZoneRecordV2 is one record set owned by a dns_zone_records_v2 resource,
+// either read from its record blocks or from its BIND zone file.
+type dnsZoneRecordV2 struct {
+	Name        string
+	Type        string
+	TTL         int
+	Description string
+	Records     []string
+}
+
+// dnsZoneFileDefaultTTL is the TTL of records which don't set one, neither
+// directly nor through $TTL.
+const dnsZoneFileDefaultTTL = 300
+
+// dnsZoneFileEntry is a logical line of a zone file, with the parentheses
+// joined and the comments stripped.
+type dnsZoneFileEntry struct {
+	line         int
+	tokens       []string
+	ownerOmitted bool
+}
+
+// splitDNSZoneFile splits the content of a zone file into its entries.
+// Quoted strings are kept as a single token, with their quotes.
+func splitDNSZoneFile(content string) ([]dnsZoneFileEntry, error) {
+	var entries []dnsZoneFileEntry
+	var entry dnsZoneFileEntry
+	var token []rune
+	line, depth := 1, 0
+	inQuote, escaped, inComment, atLineStart := false, false, false, true
+
+	flushToken := func() {
+		if len(token) > 0 {
+			entry.tokens = append(entry.tokens, string(token))
+			token = nil
+		}
+	}
+	flushEntry := func() {
+		flushToken()
+		if len(entry.tokens) > 0 {
+			entries = append(entries, entry)
+		}
+		entry = dnsZoneFileEntry{}
+	}
+
+	for _, c := range content {
+		switch {
+		case c == '\n':
+			if inQuote {
+				return nil, fmt.Errorf("line %d: unterminated quoted string", line)
+			}
+			inComment = false
+			if depth == 0 {
+				flushEntry()
+				atLineStart = true
+			} else {
+				flushToken()
+			}
+			line++
+			continue
+		case inComment:
+			continue
+		case inQuote:
+			token = append(token, c)
+			if escaped {
+				escaped = false
+			} else if c == '\\' {
+				escaped = true
+			} else if c == '"' {
+				inQuote = false
+			}
+		case c == ';':
+			flushToken()
+			inComment = true
+		case c == '"':
+			token = append(token, c)
+			inQuote = true
+		case c == '(':
+			flushToken()
+			depth++
+		case c == ')':
+			if depth == 0 {
+				return nil, fmt.Errorf("line %d: unbalanced parenthesis", line)
+			}
+			flushToken()
+			depth--
+		case unicode.IsSpace(c):
+			if atLineStart && len(entry.tokens) == 0 && len(token) == 0 {
+				entry.ownerOmitted = true
+			}
+			flushToken()
+		default:
+			if len(entry.tokens) == 0 && len(token) == 0 {
+				entry.line = line
+			}
+			token = append(token, c)
+		}
+		atLineStart = false
+	}
+
+	if inQuote {
+		return nil, fmt.Errorf("line %d: unterminated quoted string", line)
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("line %d: unbalanced parenthesis", line)
+	}
+	flushEntry()
+
+	return entries, nil
+}
+
+// parseDNSZoneFileTTL parses a TTL given either in seconds or with BIND
+// units, like 1h30m.
+func parseDNSZoneFileTTL(s string) (int, error) {
+	if ttl, err := strconv.Atoi(s); err == nil {
+		return ttl, nil
+	}
+
+	units := map[rune]int{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}
+	ttl, n, digits := 0, 0, false
+	for _, c := range strings.ToLower(s) {
+		if c >= '0' && c <= '9' {
+			n = n*10 + int(c-'0')
+			digits = true
+			continue
+		}
+		unit, ok := units[c]
+		if !ok || !digits {
+			return 0, fmt.Errorf("invalid TTL %q", s)
+		}
+		ttl += n * unit
+		n, digits = 0, false
+	}
+	if digits {
+		return 0, fmt.Errorf("invalid TTL %q", s)
+	}
+	return ttl, nil
+}
+
+// qualifyDNSZoneFileName turns a name of a zone file into a fully qualified
+// one.
+func qualifyDNSZoneFileName(name, origin string) (string, error) {
+	if name == "@" {
+		name = origin
+	} else if !strings.HasSuffix(name, ".") {
+		if origin == "" {
+			return "", fmt.Errorf("relative name %q needs an $ORIGIN", name)
+		}
+		name = name + "." + origin
+	}
+	if name == "" {
+		return "", fmt.Errorf("@ needs an $ORIGIN")
+	}
+	return name, nil
+}
+
+// dnsZoneFileNameFields are the fields of the record data which hold a domain
+// name, and so can be relative to the origin, by record type.
+var dnsZoneFileNameFields = map[string]int{
+	"CNAME": 0,
+	"NS":    0,
+	"PTR":   0,
+	"MX":    1,
+	"SRV":   3,
+}
+
+// parseDNSZoneFile parses a BIND zone file into record sets. Records of the
+// same name and type are grouped in a single record set, which takes the TTL
+// of its first record. The SOA record and the NS records of the origin are
+// managed by the DNS service, so they are left out.
+func parseDNSZoneFile(content string) ([]dnsZoneRecordV2, error) {
+	entries, err := splitDNSZoneFile(content)
+	if err != nil {
+		return nil, err
+	}
+
+	var rrsets []dnsZoneRecordV2
+	index := make(map[string]int)
+	origin, owner := "", ""
+	defaultTTL := dnsZoneFileDefaultTTL
+
+	for _, e := range entries {
+		tokens := e.tokens
+
+		if strings.HasPrefix(tokens[0], "$") {
+			directive := strings.ToUpper(tokens[0])
+			switch directive {
+			case "$ORIGIN":
+				if len(tokens) != 2 {
+					return nil, fmt.Errorf("line %d: $ORIGIN takes exactly one name", e.line)
+				}
+				origin, err = qualifyDNSZoneFileName(tokens[1], origin)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %s", e.line, err)
+				}
+			case "$TTL":
+				if len(tokens) != 2 {
+					return nil, fmt.Errorf("line %d: $TTL takes exactly one value", e.line)
+				}
+				defaultTTL, err = parseDNSZoneFileTTL(tokens[1])
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %s", e.line, err)
+				}
+			default:
+				return nil, fmt.Errorf("line %d: unsupported directive %s", e.line, tokens[0])
+			}
+			continue
+		}
+
+		if !e.ownerOmitted {
+			owner, err = qualifyDNSZoneFileName(tokens[0], origin)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %s", e.line, err)
+			}
+			tokens = tokens[1:]
+		}
+		if owner == "" {
+			return nil, fmt.Errorf("line %d: record without a name", e.line)
+		}
+
+		ttl := defaultTTL
+		for i := 0; i < 2 && len(tokens) > 0; i++ {
+			if class := strings.ToUpper(tokens[0]); class == "IN" || class == "CH" || class == "HS" {
+				tokens = tokens[1:]
+			} else if t, err := parseDNSZoneFileTTL(tokens[0]); err == nil {
+				ttl = t
+				tokens = tokens[1:]
+			}
+		}
+
+		if len(tokens) < 2 {
+			return nil, fmt.Errorf("line %d: record without type or data", e.line)
+		}
+		rrType := strings.ToUpper(tokens[0])
+		data := tokens[1:]
+
+		if rrType == "SOA" || (rrType == "NS" && strings.EqualFold(owner, origin)) {
+			continue
+		}
+
+		if i, ok := dnsZoneFileNameFields[rrType]; ok && i < len(data) {
+			data[i], err = qualifyDNSZoneFileName(data[i], origin)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %s", e.line, err)
+			}
+		}
+
+		key := strings.ToLower(owner) + " " + rrType
+		if i, ok := index[key]; ok {
+			rrsets[i].Records = append(rrsets[i].Records, strings.Join(data, " "))
+			continue
+		}
+		index[key] = len(rrsets)
+		rrsets = append(rrsets, dnsZoneRecordV2{
+			Name:    owner,
+			Type:    rrType,
+			TTL:     ttl,
+			Records: []string{strings.Join(data, " ")},
+		})
+	}
+
+	return rrsets, nil
+}
+
+// renderDNSZoneFile writes record sets as a zone file, with fully qualified
+// names and one line per record.
+func renderDNSZoneFile(rrsets []dnsZoneRecordV2) string {
+	sorted := make([]dnsZoneRecordV2, len(rrsets))
+	copy(sorted, rrsets)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Name != sorted[j].Name {
+			return sorted[i].Name < sorted[j].Name
+		}
+		return sorted[i].Type < sorted[j].Type
+	})
+
+	var b strings.Builder
+	for _, rrset := range sorted {
+		records := make([]string, len(rrset.Records))
+		copy(records, rrset.Records)
+		sort.Strings(records)
+		for _, record := range records {
+			fmt.Fprintf(&b, "%s %d IN %s %s\n", rrset.Name, rrset.TTL, rrset.Type, record)
+		}
+	}
+	return b.String()
+}
+
+// dnsZoneFileEqual reports whether two zone files hold the same record sets.
+func dnsZoneFileEqual(a, b string) bool {
+	rrsetsA, err := parseDNSZoneFile(a)
+	if err != nil {
+		return false
+	}
+	rrsetsB, err := parseDNSZoneFile(b)
+	if err != nil {
+		return false
+	}
+
+	normalize := func(rrsets []dnsZoneRecordV2) map[string]dnsZoneRecordV2 {
+		m := make(map[string]dnsZoneRecordV2, len(rrsets))
+		for _, rrset := range rrsets {
+			records := make([]string, len(rrset.Records))
+			copy(records, rrset.Records)
+			sort.Strings(records)
+			rrset.Records = records
+			rrset.Name = strings.ToLower(rrset.Name)
+			m[rrset.Name+" "+rrset.Type] = rrset
+		}
+		return m
+	}
+
+	return reflect.DeepEqual(normalize(rrsetsA), normalize(rrsetsB))
+}
@@ -0,0 +1,83 @@
+package telefonicaopencloud
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDNSZoneFile(t *testing.T) {
+	zoneFile := `
+$ORIGIN example.com.
+$TTL 1h
+@	IN	SOA	ns1.example.com. admin.example.com. (
+		2018010101 ; serial
+		7200       ; refresh
+		3600       ; retry
+		1209600    ; expire
+		300 )      ; minimum
+	IN	NS	ns1.example.com.
+	IN	MX	10 mail
+www	600	IN	A	192.0.2.1
+	IN	A	192.0.2.2 ; second address
+ftp	CNAME	www
+txt.example.com. IN 300 TXT "v=spf1 -all; really" "second"
+`
+
+	rrsets, err := parseDNSZoneFile(zoneFile)
+	if err != nil {
+		t.Fatalf("Error parsing zone file: %s", err)
+	}
+
+	expected := []dnsZoneRecordV2{
+		{Name: "example.com.", Type: "MX", TTL: 3600, Records: []string{"10 mail.example.com."}},
+		{Name: "www.example.com.", Type: "A", TTL: 600, Records: []string{"192.0.2.1", "192.0.2.2"}},
+		{Name: "ftp.example.com.", Type: "CNAME", TTL: 3600, Records: []string{"www.example.com."}},
+		{Name: "txt.example.com.", Type: "TXT", TTL: 300, Records: []string{`"v=spf1 -all; really" "second"`}},
+	}
+	if !reflect.DeepEqual(rrsets, expected) {
+		t.Fatalf("Expected record sets %#v, got %#v", expected, rrsets)
+	}
+}
+
+func TestParseDNSZoneFile_errors(t *testing.T) {
+	zoneFiles := map[string]string{
+		"relative name":  "www IN A 192.0.2.1\n",
+		"no type":        "www.example.com. 300 IN\n",
+		"unterminated":   "www.example.com. IN TXT \"foo\n",
+		"unbalanced":     "www.example.com. IN A ( 192.0.2.1\n",
+		"directive":      "$INCLUDE other.zone\n",
+		"invalid ttl":    "$TTL 1x\n",
+		"missing origin": "@ IN A 192.0.2.1\n",
+	}
+
+	for name, zoneFile := range zoneFiles {
+		if _, err := parseDNSZoneFile(zoneFile); err == nil {
+			t.Errorf("Expected an error parsing zone file %q", name)
+		}
+	}
+}
+
+func TestDNSZoneFileEqual(t *testing.T) {
+	zoneFile := `
+$ORIGIN example.com.
+www 600 IN A 192.0.2.1
+    600 IN A 192.0.2.2
+`
+	rrsets, err := parseDNSZoneFile(zoneFile)
+	if err != nil {
+		t.Fatalf("Error parsing zone file: %s", err)
+	}
+
+	rendered := renderDNSZoneFile(rrsets)
+	expected := "www.example.com. 600 IN A 192.0.2.1\nwww.example.com. 600 IN A 192.0.2.2\n"
+	if rendered != expected {
+		t.Fatalf("Expected rendered zone file %q, got %q", expected, rendered)
+	}
+
+	if !dnsZoneFileEqual(zoneFile, "www.example.com. 600 IN A 192.0.2.2\nWWW.example.com. 600 IN A 192.0.2.1\n") {
+		t.Fatalf("Expected zone files to be equal")
+	}
+	if dnsZoneFileEqual(zoneFile, "www.example.com. 300 IN A 192.0.2.1\nwww.example.com. 300 IN A 192.0.2.2\n") {
+		t.Fatalf("Expected zone files with different TTLs to differ")
+	}
+}
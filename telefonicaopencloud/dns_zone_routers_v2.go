@@ -0,0 +1,66 @@
+package telefonicaopencloud
+
+import (
+	"github.com/huaweicloud/golangsdk"
+)
+
+// dnsZoneV2Router is a router (VPC) associated with a private DNS zone.
+type dnsZoneV2Router struct {
+	RouterID     string `json:"router_id" required:"true"`
+	RouterRegion string `json:"router_region,omitempty"`
+	Status       string `json:"status,omitempty"`
+}
+
+// dnsZoneV2RouterOpts contains the router to associate with or disassociate
+// from a private DNS zone.
+type dnsZoneV2RouterOpts struct {
+	Router dnsZoneV2Router `json:"router" required:"true"`
+}
+
+// ToZoneRouterMap builds a request body from dnsZoneV2RouterOpts.
+func (opts dnsZoneV2RouterOpts) ToZoneRouterMap() (map[string]interface{}, error) {
+	return golangsdk.BuildRequestBody(opts, "")
+}
+
+func dnsZoneV2URL(client *golangsdk.ServiceClient, zoneID string) string {
+	return client.ServiceURL("zones", zoneID)
+}
+
+func dnsZoneV2ActionURL(client *golangsdk.ServiceClient, zoneID, action string) string {
+	return client.ServiceURL("zones", zoneID, action)
+}
+
+// getDNSZoneV2Routers retrieves the routers associated with a private DNS
+// zone. Public zones have none.
+func getDNSZoneV2Routers(client *golangsdk.ServiceClient, zoneID string) ([]dnsZoneV2Router, error) {
+	var r struct {
+		Routers []dnsZoneV2Router `json:"routers"`
+	}
+	_, err := client.Get(dnsZoneV2URL(client, zoneID), &r, nil)
+	if err != nil {
+		return nil, err
+	}
+	return r.Routers, nil
+}
+
+// associateDNSZoneV2Router associates a router with a private DNS zone.
+func associateDNSZoneV2Router(client *golangsdk.ServiceClient, zoneID string, router dnsZoneV2Router) error {
+	return dnsZoneV2RouterAction(client, zoneID, "associaterouter", router)
+}
+
+// disassociateDNSZoneV2Router disassociates a router from a private DNS zone.
+// The last router of a zone can't be disassociated.
+func disassociateDNSZoneV2Router(client *golangsdk.ServiceClient, zoneID string, router dnsZoneV2Router) error {
+	return dnsZoneV2RouterAction(client, zoneID, "disassociaterouter", router)
+}
+
+func dnsZoneV2RouterAction(client *golangsdk.ServiceClient, zoneID, action string, router dnsZoneV2Router) error {
+	b, err := dnsZoneV2RouterOpts{Router: router}.ToZoneRouterMap()
+	if err != nil {
+		return err
+	}
+	_, err = client.Post(dnsZoneV2ActionURL(client, zoneID, action), b, nil, &golangsdk.RequestOpts{
+		OkCodes: []int{200, 202},
+	})
+	return err
+}
@@ -0,0 +1,273 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// registerBlockStorage adds the EVS v2 API of volumes, their snapshots and
+// their tags.
+func (api *fakeAPI) registerBlockStorage() {
+	base := "/evs/v2/{project}/"
+
+	volumes := api.coll("volumes", "id")
+	snapshots := api.coll("snapshots", "id")
+
+	api.handle("GET", base+"volumes/detail", func(r *fakeRequest) (int, interface{}) {
+		return http.StatusOK, fakeObject{"volumes": volumes.list(r.filter())}
+	})
+	api.handle("POST", base+"volumes", func(r *fakeRequest) (int, interface{}) {
+		opts := r.object("volume")
+		if fakeInt(opts["size"]) <= 0 {
+			return fakeBadRequest("the size of the volume must be positive")
+		}
+		return http.StatusAccepted, fakeObject{"volume": api.createVolume(opts)}
+	})
+	api.handle("GET", base+"volumes/{id}", func(r *fakeRequest) (int, interface{}) {
+		volume, ok := volumes.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		return http.StatusOK, fakeObject{"volume": volume}
+	})
+	api.handle("PUT", base+"volumes/{id}", func(r *fakeRequest) (int, interface{}) {
+		volume, ok := volumes.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		opts := r.object("volume")
+		for _, k := range []string{"name", "description", "metadata"} {
+			if v, ok := opts[k]; ok {
+				volume[k] = v
+			}
+		}
+		volume["updated_at"] = fakeTime()
+		return http.StatusOK, fakeObject{"volume": volume}
+	})
+	api.handle("DELETE", base+"volumes/{id}", func(r *fakeRequest) (int, interface{}) {
+		volume, ok := volumes.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		if volume.str("status") != "available" {
+			return fakeBadRequest(fmt.Sprintf("volume %s is %s", volume["id"], volume["status"]))
+		}
+		volumeSnapshots := snapshots.list(func(s fakeObject) bool { return s["volume_id"] == volume["id"] })
+		if len(volumeSnapshots) > 0 && r.URL.Query().Get("cascade") != "true" {
+			return fakeBadRequest(fmt.Sprintf("volume %s has snapshots", volume["id"]))
+		}
+		for _, snapshot := range volumeSnapshots {
+			snapshots.remove(snapshot.str("id"))
+		}
+		volumes.remove(r.vars["id"])
+		return http.StatusAccepted, nil
+	})
+
+	api.handle("POST", base+"volumes/{id}/action", func(r *fakeRequest) (int, interface{}) {
+		return api.volumeAction(r, false)
+	})
+	api.handle("POST", base+"cloudvolumes/{id}/action", func(r *fakeRequest) (int, interface{}) {
+		return api.volumeAction(r, true)
+	})
+
+	api.handle("GET", base+"snapshots/detail", func(r *fakeRequest) (int, interface{}) {
+		return http.StatusOK, fakeObject{"snapshots": snapshots.list(r.filter())}
+	})
+	api.handle("POST", base+"snapshots", func(r *fakeRequest) (int, interface{}) {
+		opts := r.object("snapshot")
+		volume, ok := volumes.get(opts.str("volume_id"))
+		if !ok {
+			return fakeNotFound()
+		}
+		if volume.str("status") != "available" && !(volume.str("status") == "in-use" && opts["force"] == true) {
+			return fakeBadRequest(fmt.Sprintf("volume %s is %s", volume["id"], volume["status"]))
+		}
+		snapshot := snapshots.add(fakeObject{
+			"id":          api.newID(),
+			"name":        opts.str("name"),
+			"description": opts.str("description"),
+			"volume_id":   volume["id"],
+			"status":      "available",
+			"size":        volume["size"],
+			"metadata":    fakeMerge(fakeObject{}, fakeObject(toFakeMap(opts["metadata"]))),
+			"created_at":  fakeTime(),
+			"updated_at":  nil,
+		})
+		return http.StatusAccepted, fakeObject{"snapshot": snapshot}
+	})
+	api.handle("GET", base+"snapshots/{id}", func(r *fakeRequest) (int, interface{}) {
+		snapshot, ok := snapshots.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		return http.StatusOK, fakeObject{"snapshot": snapshot}
+	})
+	api.handle("PUT", base+"snapshots/{id}", func(r *fakeRequest) (int, interface{}) {
+		snapshot, ok := snapshots.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		opts := r.object("snapshot")
+		for _, k := range []string{"name", "description"} {
+			if v, ok := opts[k]; ok {
+				snapshot[k] = v
+			}
+		}
+		snapshot["updated_at"] = fakeTime()
+		return http.StatusOK, fakeObject{"snapshot": snapshot}
+	})
+	api.handle("PUT", base+"snapshots/{id}/metadata", func(r *fakeRequest) (int, interface{}) {
+		snapshot, ok := snapshots.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		snapshot["metadata"] = fakeMerge(fakeObject{}, fakeObject(toFakeMap(r.body["metadata"])))
+		return http.StatusOK, fakeObject{"metadata": snapshot["metadata"]}
+	})
+	api.handle("DELETE", base+"snapshots/{id}", func(r *fakeRequest) (int, interface{}) {
+		if _, ok := snapshots.get(r.vars["id"]); !ok {
+			return fakeNotFound()
+		}
+		snapshots.remove(r.vars["id"])
+		return http.StatusAccepted, nil
+	})
+
+	api.registerTags(base, "cloudvolumes")
+}
+
+// volumeAction extends or retypes a volume. Like EVS, volumes in use can only
+// be extended through the cloudvolumes API.
+func (api *fakeAPI) volumeAction(r *fakeRequest, cloudVolumes bool) (int, interface{}) {
+	volume, ok := api.coll("volumes", "id").get(r.vars["id"])
+	if !ok {
+		return fakeNotFound()
+	}
+
+	for action, v := range r.body {
+		opts := fakeObject(toFakeMap(v))
+		switch action {
+		case "os-extend":
+			if volume.str("status") != "available" && !(cloudVolumes && volume.str("status") == "in-use") {
+				return fakeBadRequest(fmt.Sprintf("volume %s is %s", volume["id"], volume["status"]))
+			}
+			if fakeInt(opts["new_size"]) <= fakeInt(volume["size"]) {
+				return fakeBadRequest("the new size of the volume must be greater than its size")
+			}
+			volume["size"] = fakeInt(opts["new_size"])
+		case "os-retype":
+			if cloudVolumes {
+				return fakeBadRequest("unknown volume action " + action)
+			}
+			volume["volume_type"] = opts.str("new_type")
+		default:
+			return fakeBadRequest("unknown volume action " + action)
+		}
+	}
+	volume["updated_at"] = fakeTime()
+	return http.StatusAccepted, nil
+}
+
+func (api *fakeAPI) createVolume(opts fakeObject) fakeObject {
+	bootable := "false"
+	if opts.str("imageRef") != "" {
+		bootable = "true"
+	}
+	volume := fakeObject{
+		"id":                api.newID(),
+		"status":            "available",
+		"size":              fakeInt(opts["size"]),
+		"name":              opts.str("name"),
+		"description":       opts.str("description"),
+		"availability_zone": fakeAPIAZ,
+		"volume_type":       "SATA",
+		"snapshot_id":       opts.str("snapshot_id"),
+		"source_volid":      opts.str("source_volid"),
+		"metadata":          fakeMerge(fakeObject{}, fakeObject(toFakeMap(opts["metadata"]))),
+		"bootable":          bootable,
+		"encrypted":         false,
+		"multiattach":       false,
+		"attachments":       []fakeObject{},
+		"user_id":           "fake-user",
+		"created_at":        fakeTime(),
+		"updated_at":        fakeTime(),
+	}
+	if az := opts.str("availability_zone"); az != "" {
+		volume["availability_zone"] = az
+	}
+	if t := opts.str("volume_type"); t != "" {
+		volume["volume_type"] = t
+	}
+	if imageID := opts.str("imageRef"); imageID != "" {
+		volume["volume_image_metadata"] = fakeObject{"image_id": imageID}
+	}
+	return api.coll("volumes", "id").add(volume)
+}
+
+// attachVolume attaches a volume to a server and returns the attachment in
+// the form of the Nova API. The first free device is used if none is given.
+func (api *fakeAPI) attachVolume(server fakeObject, volumeID, device string) (fakeObject, error) {
+	volume, ok := api.coll("volumes", "id").get(volumeID)
+	if !ok {
+		return nil, fmt.Errorf("volume %s not found", volumeID)
+	}
+	if volume.str("status") != "available" {
+		return nil, fmt.Errorf("volume %s is %s", volumeID, volume["status"])
+	}
+
+	used := map[string]bool{}
+	for _, v := range api.coll("volumes", "id").list(nil) {
+		for _, a := range v["attachments"].([]fakeObject) {
+			if a["server_id"] == server["id"] {
+				used[a.str("device")] = true
+			}
+		}
+	}
+	if device == "" {
+		for c := 'a'; c <= 'z'; c++ {
+			if d := fmt.Sprintf("/dev/vd%c", c); !used[d] {
+				device = d
+				break
+			}
+		}
+	}
+
+	volume["status"] = "in-use"
+	volume["attachments"] = []fakeObject{{
+		"id":            volumeID,
+		"attachment_id": api.newID(),
+		"volume_id":     volumeID,
+		"server_id":     server["id"],
+		"device":        device,
+		"host_name":     nil,
+		"attached_at":   fakeTime(),
+	}}
+	attachment, _ := api.volumeAttachment(server.str("id"), volumeID)
+	return attachment, nil
+}
+
+// volumeAttachment returns the attachment of a volume to a server in the
+// form of the Nova API.
+func (api *fakeAPI) volumeAttachment(serverID, volumeID string) (fakeObject, bool) {
+	volume, ok := api.coll("volumes", "id").get(volumeID)
+	if !ok {
+		return nil, false
+	}
+	for _, a := range volume["attachments"].([]fakeObject) {
+		if a.str("server_id") == serverID {
+			return fakeObject{
+				"id":       volumeID,
+				"volumeId": volumeID,
+				"serverId": serverID,
+				"device":   a["device"],
+			}, true
+		}
+	}
+	return nil, false
+}
+
+func (api *fakeAPI) detachVolume(volumeID string) {
+	if volume, ok := api.coll("volumes", "id").get(volumeID); ok {
+		volume["status"] = "available"
+		volume["attachments"] = []fakeObject{}
+	}
+}
@@ -0,0 +1,860 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+)
+
+// registerCompute adds the Nova v2 API, i.e. servers, flavors, images,
+// keypairs, server groups, volume attachments and the Nova proxies of the
+// Neutron security groups and floating IPs, and the tags of ECS and BMS
+// servers.
+func (api *fakeAPI) registerCompute() {
+	base := "/ecs/v2/{project}/"
+
+	servers := api.coll("servers", "id")
+	flavors := api.coll("flavors", "id")
+	images := api.coll("images", "id")
+	keypairs := api.coll("keypairs", "name")
+	servergroups := api.coll("servergroups", "id")
+
+	for _, flavor := range []fakeObject{
+		{"id": fakeAPIFlavorID, "name": fakeAPIFlavorID, "vcpus": 1, "ram": 4096, "disk": 40},
+		{"id": "s1.large", "name": "s1.large", "vcpus": 2, "ram": 8192, "disk": 40},
+		{"id": fakeAPIBmsFlavor, "name": fakeAPIBmsFlavor, "vcpus": 32, "ram": 131072, "disk": 40,
+			"extra_specs": fakeObject{"baremetal:__support_evs": "true"}},
+		{"id": "c1.large", "name": "c1.large", "vcpus": 2, "ram": 8192, "disk": 40,
+			"extra_specs": fakeObject{"ecs:performancetype": "computingv1"}},
+	} {
+		flavors.add(fakeDefaults(flavor, fakeObject{
+			"swap":                       "",
+			"rxtx_factor":                1.0,
+			"os-flavor-access:is_public": true,
+			"OS-FLV-EXT-DATA:ephemeral":  0,
+			"extra_specs":                fakeObject{"ecs:performancetype": "normal"},
+		}))
+	}
+	images.add(fakeObject{
+		"id":       fakeAPIImageID,
+		"name":     fakeAPIImageName,
+		"status":   "ACTIVE",
+		"progress": 100,
+		"minDisk":  0,
+		"minRam":   0,
+		"created":  fakeTime(),
+		"updated":  fakeTime(),
+		"metadata": fakeObject{},
+	})
+
+	api.handle("GET", base+"flavors/detail", func(r *fakeRequest) (int, interface{}) {
+		minRAM := fakeInt(r.URL.Query().Get("minRam"))
+		minDisk := fakeInt(r.URL.Query().Get("minDisk"))
+		return http.StatusOK, fakeObject{"flavors": flavors.list(func(flavor fakeObject) bool {
+			return fakeInt(flavor["ram"]) >= minRAM && fakeInt(flavor["disk"]) >= minDisk
+		})}
+	})
+	api.handle("GET", base+"flavors/{id}", func(r *fakeRequest) (int, interface{}) {
+		flavor, ok := flavors.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		return http.StatusOK, fakeObject{"flavor": flavor}
+	})
+	api.handle("GET", base+"flavors/{id}/os-extra_specs", func(r *fakeRequest) (int, interface{}) {
+		flavor, ok := flavors.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		return http.StatusOK, fakeObject{"extra_specs": flavor["extra_specs"]}
+	})
+	api.handle("GET", base+"images/detail", func(r *fakeRequest) (int, interface{}) {
+		return http.StatusOK, fakeObject{"images": images.list(nil)}
+	})
+	api.handle("GET", base+"images/{id}", func(r *fakeRequest) (int, interface{}) {
+		image, ok := images.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		return http.StatusOK, fakeObject{"image": image}
+	})
+
+	api.handle("POST", base+"servers", api.createServer)
+	api.handle("POST", base+"os-volumes_boot", api.createServer)
+	api.handle("GET", base+"servers/detail", func(r *fakeRequest) (int, interface{}) {
+		// Like Nova, the name is matched as a regular expression.
+		query := r.URL.Query()
+		name, err := regexp.Compile(query.Get("name"))
+		if err != nil {
+			return fakeBadRequest(err.Error())
+		}
+		list := []fakeObject{}
+		for _, server := range servers.list(nil) {
+			flavor, _ := server["flavor"].(fakeObject)
+			if !name.MatchString(server.str("name")) ||
+				(query.Get("flavor") != "" && flavor.str("id") != query.Get("flavor")) ||
+				(query.Get("status") != "" && server.str("status") != query.Get("status")) {
+				continue
+			}
+			list = append(list, api.serverView(server))
+		}
+		return http.StatusOK, fakeObject{"servers": list}
+	})
+	api.handle("GET", base+"servers/{id}", func(r *fakeRequest) (int, interface{}) {
+		server, ok := servers.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		return http.StatusOK, fakeObject{"server": api.serverView(server)}
+	})
+	api.handle("PUT", base+"servers/{id}", func(r *fakeRequest) (int, interface{}) {
+		server, ok := servers.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		if name := r.object("server").str("name"); name != "" {
+			server["name"] = name
+		}
+		return http.StatusOK, fakeObject{"server": api.serverView(server)}
+	})
+	// The BMS tags are set with the v2.1 API.
+	api.handle("PUT", "/ecs/v2.1/{project}/servers/{id}/tags", func(r *fakeRequest) (int, interface{}) {
+		server, ok := servers.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		tags := []string{}
+		list, _ := r.body["tags"].([]interface{})
+		for _, t := range list {
+			tags = append(tags, fmt.Sprint(t))
+		}
+		server["tags"] = tags
+		return http.StatusOK, fakeObject{"tags": tags}
+	})
+	api.handle("DELETE", base+"servers/{id}", func(r *fakeRequest) (int, interface{}) {
+		server, ok := servers.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		api.deleteServer(server)
+		return http.StatusNoContent, nil
+	})
+	api.handle("POST", base+"servers/{id}/action", api.serverAction)
+
+	api.handle("POST", base+"servers/{id}/metadata", func(r *fakeRequest) (int, interface{}) {
+		server, ok := servers.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		metadata := server["metadata"].(fakeObject)
+		fakeMerge(metadata, r.object("metadata"))
+		return http.StatusOK, fakeObject{"metadata": metadata}
+	})
+	api.handle("DELETE", base+"servers/{id}/metadata/{key}", func(r *fakeRequest) (int, interface{}) {
+		server, ok := servers.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		metadata := server["metadata"].(fakeObject)
+		if _, ok := metadata[r.vars["key"]]; !ok {
+			return fakeNotFound()
+		}
+		delete(metadata, r.vars["key"])
+		return http.StatusNoContent, nil
+	})
+
+	api.handle("GET", base+"servers/{id}/os-volume_attachments", func(r *fakeRequest) (int, interface{}) {
+		if _, ok := servers.get(r.vars["id"]); !ok {
+			return fakeNotFound()
+		}
+		list := []fakeObject{}
+		for _, volume := range api.coll("volumes", "id").list(nil) {
+			if attachment, ok := api.volumeAttachment(r.vars["id"], volume.str("id")); ok {
+				list = append(list, attachment)
+			}
+		}
+		return http.StatusOK, fakeObject{"volumeAttachments": list}
+	})
+	api.handle("POST", base+"servers/{id}/os-volume_attachments", func(r *fakeRequest) (int, interface{}) {
+		server, ok := servers.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		opts := r.object("volumeAttachment")
+		attachment, err := api.attachVolume(server, opts.str("volumeId"), opts.str("device"))
+		if err != nil {
+			return fakeBadRequest(err.Error())
+		}
+		return http.StatusOK, fakeObject{"volumeAttachment": attachment}
+	})
+	api.handle("GET", base+"servers/{id}/os-volume_attachments/{volume}", func(r *fakeRequest) (int, interface{}) {
+		attachment, ok := api.volumeAttachment(r.vars["id"], r.vars["volume"])
+		if !ok {
+			return fakeNotFound()
+		}
+		return http.StatusOK, fakeObject{"volumeAttachment": attachment}
+	})
+	api.handle("DELETE", base+"servers/{id}/os-volume_attachments/{volume}", func(r *fakeRequest) (int, interface{}) {
+		if _, ok := api.volumeAttachment(r.vars["id"], r.vars["volume"]); !ok {
+			return fakeNotFound()
+		}
+		api.detachVolume(r.vars["volume"])
+		return http.StatusAccepted, nil
+	})
+
+	api.handle("GET", base+"servers/{id}/os-interface", func(r *fakeRequest) (int, interface{}) {
+		if _, ok := servers.get(r.vars["id"]); !ok {
+			return fakeNotFound()
+		}
+		list := []fakeObject{}
+		for _, port := range api.serverPorts(r.vars["id"]) {
+			list = append(list, fakeInterfaceAttachment(port))
+		}
+		return http.StatusOK, fakeObject{"interfaceAttachments": list}
+	})
+	api.handle("POST", base+"servers/{id}/os-interface", api.attachInterface)
+	api.handle("GET", base+"servers/{id}/os-interface/{port}", func(r *fakeRequest) (int, interface{}) {
+		port, ok := api.coll("ports", "id").get(r.vars["port"])
+		if !ok || port.str("device_id") != r.vars["id"] {
+			return fakeNotFound()
+		}
+		return http.StatusOK, fakeObject{"interfaceAttachment": fakeInterfaceAttachment(port)}
+	})
+	api.handle("DELETE", base+"servers/{id}/os-interface/{port}", func(r *fakeRequest) (int, interface{}) {
+		server, ok := servers.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		port, ok := api.coll("ports", "id").get(r.vars["port"])
+		if !ok || port.str("device_id") != r.vars["id"] {
+			return fakeNotFound()
+		}
+		api.detachInterface(server, port)
+		return http.StatusAccepted, nil
+	})
+
+	api.handle("POST", base+"os-keypairs", func(r *fakeRequest) (int, interface{}) {
+		opts := r.object("keypair")
+		if _, ok := keypairs.get(opts.str("name")); ok {
+			return http.StatusConflict, fakeError(http.StatusConflict, "the keypair already exists")
+		}
+		keypair := keypairs.add(fakeObject{
+			"name":        opts["name"],
+			"public_key":  opts["public_key"],
+			"fingerprint": fmt.Sprintf("%x", api.nextID),
+			"user_id":     "fake-user",
+		})
+		return http.StatusOK, fakeObject{"keypair": keypair}
+	})
+	api.handle("GET", base+"os-keypairs/{name}", func(r *fakeRequest) (int, interface{}) {
+		keypair, ok := keypairs.get(r.vars["name"])
+		if !ok {
+			return fakeNotFound()
+		}
+		return http.StatusOK, fakeObject{"keypair": keypair}
+	})
+	api.handle("DELETE", base+"os-keypairs/{name}", func(r *fakeRequest) (int, interface{}) {
+		if !keypairs.remove(r.vars["name"]) {
+			return fakeNotFound()
+		}
+		return http.StatusAccepted, nil
+	})
+
+	api.handle("POST", base+"os-server-groups", func(r *fakeRequest) (int, interface{}) {
+		opts := r.object("server_group")
+		group := servergroups.add(fakeObject{
+			"id":       api.newID(),
+			"name":     opts["name"],
+			"policies": fakeStrings(opts["policies"]),
+			"members":  []string{},
+			"metadata": fakeObject{},
+		})
+		return http.StatusOK, fakeObject{"server_group": group}
+	})
+	api.handle("GET", base+"os-server-groups/{id}", func(r *fakeRequest) (int, interface{}) {
+		group, ok := servergroups.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		return http.StatusOK, fakeObject{"server_group": group}
+	})
+	api.handle("DELETE", base+"os-server-groups/{id}", func(r *fakeRequest) (int, interface{}) {
+		if !servergroups.remove(r.vars["id"]) {
+			return fakeNotFound()
+		}
+		return http.StatusNoContent, nil
+	})
+
+	api.registerNovaSecGroups(base)
+	api.registerNovaFloatingIPs(base)
+
+	api.registerTags("/ecs/v1/{project}/", "cloudservers")
+}
+
+func (api *fakeAPI) createServer(r *fakeRequest) (int, interface{}) {
+	opts := r.object("server")
+	if _, ok := api.coll("flavors", "id").get(opts.str("flavorRef")); !ok {
+		return fakeBadRequest(fmt.Sprintf("flavor %s not found", opts["flavorRef"]))
+	}
+
+	id := api.newID()
+	imageID := opts.str("imageRef")
+	var image interface{} = ""
+	if imageID != "" {
+		image = fakeObject{"id": imageID}
+	}
+
+	securityGroups := []string{}
+	if groups, ok := opts["security_groups"].([]interface{}); ok {
+		for _, g := range groups {
+			securityGroups = append(securityGroups, fakeObject(g.(map[string]interface{})).str("name"))
+		}
+	}
+	if len(securityGroups) == 0 {
+		securityGroups = append(securityGroups, "default")
+	}
+	securityGroupIDs, err := api.secGroupIDs(securityGroups)
+	if err != nil {
+		return fakeBadRequest(err.Error())
+	}
+
+	networks, _ := opts["networks"].([]interface{})
+	if len(networks) == 0 {
+		networks = []interface{}{map[string]interface{}{"uuid": fakeAPINetworkID}}
+	}
+	var created []string
+	rollback := func() {
+		for _, portID := range created {
+			api.coll("ports", "id").remove(portID)
+		}
+	}
+	for _, n := range networks {
+		network := fakeObject(n.(map[string]interface{}))
+		if portID := network.str("port"); portID != "" {
+			port, ok := api.coll("ports", "id").get(portID)
+			if !ok || port.str("device_id") != "" {
+				rollback()
+				return fakeBadRequest(fmt.Sprintf("port %s not found or in use", portID))
+			}
+			port["device_id"] = id
+			port["device_owner"] = "compute:" + fakeAPIAZ
+			port["security_groups"] = securityGroupIDs
+			continue
+		}
+
+		port := fakeObject{
+			"network_id":      network["uuid"],
+			"device_id":       id,
+			"device_owner":    "compute:" + fakeAPIAZ,
+			"security_groups": securityGroupIDs,
+		}
+		if ip := network.str("fixed_ip"); ip != "" {
+			netw, ok := api.coll("networks", "id").get(network.str("uuid"))
+			if !ok || len(fakeStrings(netw["subnets"])) == 0 {
+				rollback()
+				return fakeBadRequest(fmt.Sprintf("network %s not found", network["uuid"]))
+			}
+			port["fixed_ips"] = []interface{}{map[string]interface{}{
+				"subnet_id":  fakeStrings(netw["subnets"])[0],
+				"ip_address": ip,
+			}}
+		}
+		port, err := api.createPort(port)
+		if err != nil {
+			rollback()
+			return fakeBadRequest(err.Error())
+		}
+		created = append(created, port.str("id"))
+	}
+
+	server := fakeObject{
+		"id":                          id,
+		"name":                        opts["name"],
+		"status":                      "ACTIVE",
+		"tenant_id":                   fakeAPIProjectID,
+		"user_id":                     "fake-user",
+		"hostId":                      api.newID(),
+		"created":                     time.Now().UTC().Format(time.RFC3339),
+		"updated":                     time.Now().UTC().Format(time.RFC3339),
+		"image":                       image,
+		"flavor":                      fakeObject{"id": opts["flavorRef"]},
+		"metadata":                    fakeMerge(fakeObject{}, fakeObject(toFakeMap(opts["metadata"]))),
+		"key_name":                    opts["key_name"],
+		"security_groups":             securityGroups,
+		"OS-EXT-AZ:availability_zone": fakeAPIAZ,
+		"OS-EXT-STS:vm_state":         "active",
+		"created_ports":               created,
+	}
+	if az := opts.str("availability_zone"); az != "" {
+		server["OS-EXT-AZ:availability_zone"] = az
+	}
+	api.coll("servers", "id").add(server)
+
+	devices, _ := opts["block_device_mapping_v2"].([]interface{})
+	for _, d := range devices {
+		device := fakeObject(d.(map[string]interface{}))
+		if device.str("destination_type") != "volume" {
+			continue
+		}
+		volumeID := device.str("uuid")
+		if device.str("source_type") != "volume" {
+			volume := api.createVolume(fakeObject{
+				"size":     device["volume_size"],
+				"imageRef": device["uuid"],
+			})
+			volumeID = volume.str("id")
+		}
+		if _, err := api.attachVolume(server, volumeID, ""); err != nil {
+			api.deleteServer(server)
+			return fakeBadRequest(err.Error())
+		}
+		if deleteOnTermination, _ := device["delete_on_termination"].(bool); deleteOnTermination {
+			volume, _ := api.coll("volumes", "id").get(volumeID)
+			volume["delete_on_termination"] = true
+		}
+	}
+
+	if hints, ok := r.body["os:scheduler_hints"].(map[string]interface{}); ok {
+		if group, ok := api.coll("servergroups", "id").get(fakeObject(hints).str("group")); ok {
+			group["members"] = append(group["members"].([]string), id)
+		}
+	}
+
+	return http.StatusAccepted, fakeObject{"server": api.serverView(server)}
+}
+
+// deleteServer removes a server, its ports and its volume attachments.
+func (api *fakeAPI) deleteServer(server fakeObject) {
+	id := server.str("id")
+	for _, port := range api.serverPorts(id) {
+		port["device_id"] = ""
+		port["device_owner"] = ""
+	}
+	for _, portID := range server["created_ports"].([]string) {
+		if port, ok := api.coll("ports", "id").get(portID); ok {
+			api.deleteNeutron("port", port)
+			api.coll("ports", "id").remove(portID)
+		}
+	}
+	for _, volume := range api.coll("volumes", "id").list(nil) {
+		if attachments, _ := volume["attachments"].([]fakeObject); len(attachments) > 0 && attachments[0].str("server_id") == id {
+			api.detachVolume(volume.str("id"))
+			if deleteOnTermination, _ := volume["delete_on_termination"].(bool); deleteOnTermination {
+				api.coll("volumes", "id").remove(volume.str("id"))
+			}
+		}
+	}
+	for _, group := range api.coll("servergroups", "id").list(nil) {
+		members := []string{}
+		for _, m := range group["members"].([]string) {
+			if m != id {
+				members = append(members, m)
+			}
+		}
+		group["members"] = members
+	}
+	api.coll("servers", "id").remove(id)
+}
+
+func (api *fakeAPI) serverAction(r *fakeRequest) (int, interface{}) {
+	server, ok := api.coll("servers", "id").get(r.vars["id"])
+	if !ok {
+		return fakeNotFound()
+	}
+
+	for action, v := range r.body {
+		opts := fakeObject(toFakeMap(v))
+		switch action {
+		case "os-stop":
+			server["status"] = "SHUTOFF"
+		case "os-start", "reboot":
+			server["status"] = "ACTIVE"
+		case "resize":
+			if _, ok := api.coll("flavors", "id").get(opts.str("flavorRef")); !ok {
+				return fakeBadRequest(fmt.Sprintf("flavor %s not found", opts["flavorRef"]))
+			}
+			server["flavor"] = fakeObject{"id": opts["flavorRef"]}
+			server["status"] = "VERIFY_RESIZE"
+		case "confirmResize":
+			server["status"] = "ACTIVE"
+		case "changePassword":
+		case "addSecurityGroup", "removeSecurityGroup":
+			name := opts.str("name")
+			groups := []string{}
+			for _, g := range server["security_groups"].([]string) {
+				if g != name {
+					groups = append(groups, g)
+				}
+			}
+			if action == "addSecurityGroup" {
+				groups = append(groups, name)
+			}
+			ids, err := api.secGroupIDs(groups)
+			if err != nil {
+				return fakeBadRequest(err.Error())
+			}
+			server["security_groups"] = groups
+			for _, port := range api.serverPorts(server.str("id")) {
+				port["security_groups"] = ids
+			}
+		case "addFloatingIp", "removeFloatingIp":
+			var fip fakeObject
+			for _, f := range api.coll("floatingips", "id").list(nil) {
+				if f["floating_ip_address"] == opts["address"] {
+					fip = f
+				}
+			}
+			if fip == nil {
+				return fakeNotFound()
+			}
+			fip["port_id"] = ""
+			fip["fixed_ip_address"] = ""
+			if action == "addFloatingIp" {
+				for _, port := range api.serverPorts(server.str("id")) {
+					for _, ip := range fakeFixedIPs(port) {
+						if fip.str("port_id") == "" && (opts.str("fixed_address") == "" || ip["ip_address"] == opts["fixed_address"]) {
+							fip["port_id"] = port["id"]
+							fip["fixed_ip_address"] = ip["ip_address"]
+						}
+					}
+				}
+				if fip.str("port_id") == "" {
+					return fakeBadRequest("no port found for the floating IP")
+				}
+			}
+			api.bindFloatingIP(fip)
+		default:
+			return fakeBadRequest("unknown server action " + action)
+		}
+	}
+	return http.StatusAccepted, nil
+}
+
+// attachInterface attaches an existing port or a new port of a network to a
+// server, like the Nova os-interface API.
+func (api *fakeAPI) attachInterface(r *fakeRequest) (int, interface{}) {
+	server, ok := api.coll("servers", "id").get(r.vars["id"])
+	if !ok {
+		return fakeNotFound()
+	}
+	securityGroupIDs, err := api.secGroupIDs(server["security_groups"].([]string))
+	if err != nil {
+		return fakeBadRequest(err.Error())
+	}
+
+	opts := r.object("interfaceAttachment")
+	if portID := opts.str("port_id"); portID != "" {
+		port, ok := api.coll("ports", "id").get(portID)
+		if !ok || port.str("device_id") != "" {
+			return fakeBadRequest(fmt.Sprintf("port %s not found or in use", portID))
+		}
+		port["device_id"] = server["id"]
+		port["device_owner"] = "compute:" + fakeAPIAZ
+		return http.StatusOK, fakeObject{"interfaceAttachment": fakeInterfaceAttachment(port)}
+	}
+
+	port := fakeObject{
+		"network_id":      opts["net_id"],
+		"device_id":       server["id"],
+		"device_owner":    "compute:" + fakeAPIAZ,
+		"security_groups": securityGroupIDs,
+	}
+	if ips, ok := opts["fixed_ips"].([]interface{}); ok && len(ips) > 0 {
+		netw, ok := api.coll("networks", "id").get(opts.str("net_id"))
+		if !ok || len(fakeStrings(netw["subnets"])) == 0 {
+			return fakeBadRequest(fmt.Sprintf("network %s not found", opts["net_id"]))
+		}
+		port["fixed_ips"] = []interface{}{map[string]interface{}{
+			"subnet_id":  fakeStrings(netw["subnets"])[0],
+			"ip_address": fakeObject(ips[0].(map[string]interface{})).str("ip_address"),
+		}}
+	}
+	port, err = api.createPort(port)
+	if err != nil {
+		return fakeBadRequest(err.Error())
+	}
+	server["created_ports"] = append(server["created_ports"].([]string), port.str("id"))
+	return http.StatusOK, fakeObject{"interfaceAttachment": fakeInterfaceAttachment(port)}
+}
+
+// detachInterface detaches a port from a server. The port is deleted when it
+// was created by the server.
+func (api *fakeAPI) detachInterface(server, port fakeObject) {
+	port["device_id"] = ""
+	port["device_owner"] = ""
+
+	created := []string{}
+	for _, portID := range server["created_ports"].([]string) {
+		if portID != port.str("id") {
+			created = append(created, portID)
+			continue
+		}
+		api.deleteNeutron("port", port)
+		api.coll("ports", "id").remove(portID)
+	}
+	server["created_ports"] = created
+}
+
+// fakeInterfaceAttachment returns a port in the form of the Nova os-interface
+// API.
+func fakeInterfaceAttachment(port fakeObject) fakeObject {
+	return fakeObject{
+		"port_state": port["status"],
+		"fixed_ips":  fakeFixedIPs(port),
+		"port_id":    port["id"],
+		"net_id":     port["network_id"],
+		"mac_addr":   port["mac_address"],
+	}
+}
+
+// serverView returns a server with its addresses, security groups and
+// volumes in the form of the Nova API.
+func (api *fakeAPI) serverView(server fakeObject) fakeObject {
+	addresses := fakeObject{}
+	for _, port := range api.serverPorts(server.str("id")) {
+		network, _ := api.coll("networks", "id").get(port.str("network_id"))
+		name := network.str("name")
+		list, _ := addresses[name].([]fakeObject)
+		for _, ip := range fakeFixedIPs(port) {
+			list = append(list, fakeObject{
+				"addr":                    ip["ip_address"],
+				"version":                 4,
+				"OS-EXT-IPS:type":         "fixed",
+				"OS-EXT-IPS-MAC:mac_addr": port["mac_address"],
+			})
+		}
+		for _, fip := range api.coll("floatingips", "id").list(nil) {
+			if fip["port_id"] == port["id"] {
+				list = append(list, fakeObject{
+					"addr":                    fip["floating_ip_address"],
+					"version":                 4,
+					"OS-EXT-IPS:type":         "floating",
+					"OS-EXT-IPS-MAC:mac_addr": port["mac_address"],
+				})
+			}
+		}
+		addresses[name] = list
+	}
+
+	groups := []fakeObject{}
+	for _, name := range server["security_groups"].([]string) {
+		groups = append(groups, fakeObject{"name": name})
+	}
+
+	volumesAttached := []fakeObject{}
+	for _, volume := range api.coll("volumes", "id").list(nil) {
+		if _, ok := api.volumeAttachment(server.str("id"), volume.str("id")); ok {
+			deleteOnTermination, _ := volume["delete_on_termination"].(bool)
+			volumesAttached = append(volumesAttached, fakeObject{
+				"id":                    volume["id"],
+				"delete_on_termination": deleteOnTermination,
+			})
+		}
+	}
+
+	view := fakeMerge(fakeObject{}, server)
+	delete(view, "created_ports")
+	view["addresses"] = addresses
+	view["security_groups"] = groups
+	view["os-extended-volumes:volumes_attached"] = volumesAttached
+	return view
+}
+
+// serverPorts returns the Neutron ports of a server.
+func (api *fakeAPI) serverPorts(id string) []fakeObject {
+	return api.coll("ports", "id").list(func(port fakeObject) bool {
+		return port.str("device_id") == id
+	})
+}
+
+// secGroupIDs returns the IDs of the Neutron security groups with the given
+// names.
+func (api *fakeAPI) secGroupIDs(names []string) ([]string, error) {
+	ids := []string{}
+	for _, name := range names {
+		var id string
+		for _, sg := range api.coll("security-groups", "id").list(nil) {
+			if sg.str("name") == name || sg.str("id") == name {
+				id = sg.str("id")
+			}
+		}
+		if id == "" {
+			return nil, fmt.Errorf("security group %s not found", name)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// registerNovaSecGroups adds the Nova API of the security groups, which is
+// a proxy of the Neutron one showing only the ingress rules.
+func (api *fakeAPI) registerNovaSecGroups(base string) {
+	secgroups := api.coll("security-groups", "id")
+	rules := api.coll("security-group-rules", "id")
+
+	ruleView := func(rule fakeObject) fakeObject {
+		view := fakeObject{
+			"id":              rule["id"],
+			"parent_group_id": rule["security_group_id"],
+			"ip_protocol":     rule["protocol"],
+			"from_port":       rule["port_range_min"],
+			"to_port":         rule["port_range_max"],
+			"ip_range":        fakeObject{},
+			"group":           fakeObject{},
+		}
+		if cidr := rule.str("remote_ip_prefix"); cidr != "" {
+			view["ip_range"] = fakeObject{"cidr": cidr}
+		}
+		if group, ok := secgroups.get(rule.str("remote_group_id")); ok {
+			view["group"] = fakeObject{"name": group["name"], "tenant_id": group["tenant_id"]}
+		}
+		return view
+	}
+	view := func(sg fakeObject) fakeObject {
+		list := []fakeObject{}
+		for _, rule := range rules.list(nil) {
+			if rule["security_group_id"] == sg["id"] && rule["direction"] == "ingress" {
+				list = append(list, ruleView(rule))
+			}
+		}
+		return fakeObject{
+			"id":          sg["id"],
+			"name":        sg["name"],
+			"description": sg["description"],
+			"tenant_id":   sg["tenant_id"],
+			"rules":       list,
+		}
+	}
+
+	api.handle("GET", base+"os-security-groups", func(r *fakeRequest) (int, interface{}) {
+		list := []fakeObject{}
+		for _, sg := range secgroups.list(nil) {
+			list = append(list, view(sg))
+		}
+		return http.StatusOK, fakeObject{"security_groups": list}
+	})
+	api.handle("POST", base+"os-security-groups", func(r *fakeRequest) (int, interface{}) {
+		opts := r.object("security_group")
+		sg, _ := api.createSecGroup(fakeObject{"name": opts["name"], "description": opts["description"]})
+		return http.StatusOK, fakeObject{"security_group": view(sg)}
+	})
+	api.handle("GET", base+"os-security-groups/{id}", func(r *fakeRequest) (int, interface{}) {
+		sg, ok := secgroups.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		return http.StatusOK, fakeObject{"security_group": view(sg)}
+	})
+	api.handle("PUT", base+"os-security-groups/{id}", func(r *fakeRequest) (int, interface{}) {
+		sg, ok := secgroups.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		opts := r.object("security_group")
+		sg["name"] = opts["name"]
+		sg["description"] = opts["description"]
+		return http.StatusOK, fakeObject{"security_group": view(sg)}
+	})
+	api.handle("DELETE", base+"os-security-groups/{id}", func(r *fakeRequest) (int, interface{}) {
+		sg, ok := secgroups.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		if err := api.deleteNeutron("security_group", sg); err != nil {
+			return http.StatusConflict, fakeError(http.StatusConflict, err.Error())
+		}
+		secgroups.remove(r.vars["id"])
+		return http.StatusAccepted, nil
+	})
+
+	api.handle("POST", base+"os-security-group-rules", func(r *fakeRequest) (int, interface{}) {
+		opts := r.object("security_group_rule")
+		rule, err := api.createSecGroupRule(fakeObject{
+			"security_group_id": opts["parent_group_id"],
+			"direction":         "ingress",
+			"ethertype":         "IPv4",
+			"protocol":          opts["ip_protocol"],
+			"port_range_min":    fakeInt(opts["from_port"]),
+			"port_range_max":    fakeInt(opts["to_port"]),
+			"remote_ip_prefix":  opts["cidr"],
+			"remote_group_id":   opts["group_id"],
+		})
+		if err != nil {
+			return fakeBadRequest(err.Error())
+		}
+		return http.StatusOK, fakeObject{"security_group_rule": ruleView(rule)}
+	})
+	api.handle("DELETE", base+"os-security-group-rules/{id}", func(r *fakeRequest) (int, interface{}) {
+		if !rules.remove(r.vars["id"]) {
+			return fakeNotFound()
+		}
+		return http.StatusAccepted, nil
+	})
+}
+
+// registerNovaFloatingIPs adds the Nova API of the floating IPs, which is a
+// proxy of the Neutron one.
+func (api *fakeAPI) registerNovaFloatingIPs(base string) {
+	floatingips := api.coll("floatingips", "id")
+
+	view := func(fip fakeObject) fakeObject {
+		network, _ := api.coll("networks", "id").get(fip.str("floating_network_id"))
+		v := fakeObject{
+			"id":          fip["id"],
+			"ip":          fip["floating_ip_address"],
+			"pool":        network["name"],
+			"fixed_ip":    nil,
+			"instance_id": nil,
+		}
+		if port, ok := api.coll("ports", "id").get(fip.str("port_id")); ok {
+			v["fixed_ip"] = fip["fixed_ip_address"]
+			v["instance_id"] = port["device_id"]
+		}
+		return v
+	}
+
+	api.handle("GET", base+"os-floating-ips", func(r *fakeRequest) (int, interface{}) {
+		list := []fakeObject{}
+		for _, fip := range floatingips.list(nil) {
+			list = append(list, view(fip))
+		}
+		return http.StatusOK, fakeObject{"floating_ips": list}
+	})
+	api.handle("POST", base+"os-floating-ips", func(r *fakeRequest) (int, interface{}) {
+		pool := r.body.str("pool")
+		var networkID string
+		for _, network := range api.coll("networks", "id").list(nil) {
+			if network.str("name") == pool {
+				networkID = network.str("id")
+			}
+		}
+		fip, err := api.createFloatingIP(fakeObject{"floating_network_id": networkID})
+		if err != nil {
+			return fakeBadRequest(err.Error())
+		}
+		return http.StatusOK, fakeObject{"floating_ip": view(fip)}
+	})
+	api.handle("GET", base+"os-floating-ips/{id}", func(r *fakeRequest) (int, interface{}) {
+		fip, ok := floatingips.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		return http.StatusOK, fakeObject{"floating_ip": view(fip)}
+	})
+	api.handle("DELETE", base+"os-floating-ips/{id}", func(r *fakeRequest) (int, interface{}) {
+		if !floatingips.remove(r.vars["id"]) {
+			return fakeNotFound()
+		}
+		return http.StatusAccepted, nil
+	})
+}
+
+// toFakeMap returns a field of a request as a map, or an empty map if it is
+// not an object.
+func toFakeMap(v interface{}) map[string]interface{} {
+	if m, ok := v.(map[string]interface{}); ok {
+		return m
+	}
+	return map[string]interface{}{}
+}
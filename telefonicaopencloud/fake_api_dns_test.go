@@ -0,0 +1,328 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// registerDNS adds the DNS v2 API of zones and record sets.
+func (api *fakeAPI) registerDNS() {
+	base := "/dns/v2/"
+
+	zones := api.coll("zones", "id")
+	recordsets := api.coll("recordsets", "id")
+
+	// The type query selects either public or private zones, public ones
+	// by default, or filters on the type of the zone.
+	api.handle("GET", base+"zones", func(r *fakeRequest) (int, interface{}) {
+		zoneType, rrType := "public", r.URL.Query().Get("type")
+		if rrType == "public" || rrType == "private" {
+			zoneType, rrType = rrType, ""
+		}
+		filter := r.filter("sort_key", "sort_dir", "type")
+		return http.StatusOK, fakeObject{"zones": zones.list(func(zone fakeObject) bool {
+			return zone["zone_type"] == zoneType && (rrType == "" || zone["type"] == rrType) && filter(zone)
+		})}
+	})
+	api.handle("POST", base+"zones", func(r *fakeRequest) (int, interface{}) {
+		opts := r.body
+		for _, zone := range zones.list(nil) {
+			if zone["name"] == opts["name"] {
+				return http.StatusConflict, fakeError(http.StatusConflict, "the zone already exists")
+			}
+		}
+		var routers []fakeObject
+		switch opts["zone_type"] {
+		case nil, "public":
+			if opts["router"] != nil {
+				return fakeBadRequest("only private zones have routers")
+			}
+		case "private":
+			router, status := api.dnsZoneRouter(r.object("router"))
+			if router == nil {
+				return fakeBadRequest(status)
+			}
+			routers = append(routers, router)
+		default:
+			return fakeBadRequest("invalid zone type")
+		}
+		delete(opts, "router")
+		zone := fakeDefaults(fakeMerge(fakeObject{}, opts), fakeObject{
+			"id":          api.newID(),
+			"pool_id":     api.newID(),
+			"project_id":  fakeAPIProjectID,
+			"email":       "",
+			"description": "",
+			"ttl":         300,
+			"type":        "PRIMARY",
+			"zone_type":   "public",
+			"attributes":  fakeObject{},
+			"masters":     []string{},
+			"serial":      1,
+			"version":     1,
+			"created_at":  fakeTime(),
+			"updated_at":  "",
+		})
+		if routers != nil {
+			zone["routers"] = routers
+		}
+		zone["status"] = "ACTIVE"
+		zone["action"] = "CREATE"
+		return http.StatusAccepted, zones.add(zone)
+	})
+	api.handle("POST", base+"zones/{id}/associaterouter", func(r *fakeRequest) (int, interface{}) {
+		zone, ok := zones.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		if zone["zone_type"] != "private" {
+			return fakeBadRequest("only private zones have routers")
+		}
+		router, status := api.dnsZoneRouter(r.object("router"))
+		if router == nil {
+			return fakeBadRequest(status)
+		}
+		routers := zone["routers"].([]fakeObject)
+		for _, o := range routers {
+			if o["router_id"] == router["router_id"] {
+				return fakeBadRequest("the router is already associated with the zone")
+			}
+		}
+		zone["routers"] = append(routers, router)
+		return http.StatusAccepted, router
+	})
+	api.handle("POST", base+"zones/{id}/disassociaterouter", func(r *fakeRequest) (int, interface{}) {
+		zone, ok := zones.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		if zone["zone_type"] != "private" {
+			return fakeBadRequest("only private zones have routers")
+		}
+		routerID := r.object("router").str("router_id")
+		routers := zone["routers"].([]fakeObject)
+		for i, o := range routers {
+			if o["router_id"] != routerID {
+				continue
+			}
+			if len(routers) == 1 {
+				return fakeBadRequest("the last router of a zone can't be disassociated")
+			}
+			zone["routers"] = append(routers[:i:i], routers[i+1:]...)
+			return http.StatusAccepted, o
+		}
+		return fakeNotFound()
+	})
+	api.handle("GET", base+"zones/{id}", func(r *fakeRequest) (int, interface{}) {
+		zone, ok := zones.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		return http.StatusOK, zone
+	})
+	api.handle("PATCH", base+"zones/{id}", func(r *fakeRequest) (int, interface{}) {
+		zone, ok := zones.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		for _, k := range []string{"email", "ttl", "masters", "description"} {
+			if v, ok := r.body[k]; ok {
+				zone[k] = v
+			}
+		}
+		zone["serial"] = fakeInt(zone["serial"]) + 1
+		zone["version"] = fakeInt(zone["version"]) + 1
+		zone["action"] = "UPDATE"
+		zone["updated_at"] = fakeTime()
+		return http.StatusAccepted, zone
+	})
+	api.handle("DELETE", base+"zones/{id}", func(r *fakeRequest) (int, interface{}) {
+		zone, ok := zones.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		for _, rs := range recordsets.list(nil) {
+			if rs["zone_id"] == zone["id"] {
+				recordsets.remove(rs.str("id"))
+			}
+		}
+		zones.remove(r.vars["id"])
+		zone["action"] = "DELETE"
+		return http.StatusAccepted, zone
+	})
+
+	api.handle("GET", base+"zones/{zone}/recordsets", func(r *fakeRequest) (int, interface{}) {
+		if _, ok := zones.get(r.vars["zone"]); !ok {
+			return fakeNotFound()
+		}
+		filter := r.filter()
+		return http.StatusOK, fakeObject{"recordsets": recordsets.list(func(rs fakeObject) bool {
+			return rs["zone_id"] == r.vars["zone"] && filter(rs)
+		})}
+	})
+	api.handle("POST", base+"zones/{zone}/recordsets", func(r *fakeRequest) (int, interface{}) {
+		zone, ok := zones.get(r.vars["zone"])
+		if !ok {
+			return fakeNotFound()
+		}
+		opts := r.body
+		if len(fakeStrings(opts["records"])) == 0 {
+			return fakeBadRequest("the record set has no records")
+		}
+		for _, rs := range recordsets.list(nil) {
+			if rs["zone_id"] == zone["id"] && rs["name"] == opts["name"] && rs["type"] == opts["type"] {
+				return http.StatusConflict, fakeError(http.StatusConflict,
+					fmt.Sprintf("a %s record set named %s already exists", opts["type"], opts["name"]))
+			}
+		}
+		rs := fakeDefaults(fakeMerge(fakeObject{}, opts), fakeObject{
+			"id":          api.newID(),
+			"description": "",
+			"ttl":         zone["ttl"],
+			"version":     1,
+			"created_at":  fakeTime(),
+			"updated_at":  "",
+		})
+		rs["records"] = fakeStrings(opts["records"])
+		rs["zone_id"] = zone["id"]
+		rs["zone_name"] = zone["name"]
+		rs["project_id"] = fakeAPIProjectID
+		rs["status"] = "ACTIVE"
+		rs["action"] = "CREATE"
+		return http.StatusAccepted, recordsets.add(rs)
+	})
+	api.handle("GET", base+"zones/{zone}/recordsets/{id}", func(r *fakeRequest) (int, interface{}) {
+		rs, ok := recordsets.get(r.vars["id"])
+		if !ok || rs["zone_id"] != r.vars["zone"] {
+			return fakeNotFound()
+		}
+		return http.StatusOK, rs
+	})
+	api.handle("PUT", base+"zones/{zone}/recordsets/{id}", func(r *fakeRequest) (int, interface{}) {
+		rs, ok := recordsets.get(r.vars["id"])
+		if !ok || rs["zone_id"] != r.vars["zone"] {
+			return fakeNotFound()
+		}
+		for _, k := range []string{"description", "ttl"} {
+			if v, ok := r.body[k]; ok {
+				rs[k] = v
+			}
+		}
+		if records := fakeStrings(r.body["records"]); len(records) > 0 {
+			rs["records"] = records
+		}
+		rs["version"] = fakeInt(rs["version"]) + 1
+		rs["action"] = "UPDATE"
+		rs["updated_at"] = fakeTime()
+		return http.StatusAccepted, rs
+	})
+	api.handle("DELETE", base+"zones/{zone}/recordsets/{id}", func(r *fakeRequest) (int, interface{}) {
+		rs, ok := recordsets.get(r.vars["id"])
+		if !ok || rs["zone_id"] != r.vars["zone"] {
+			return fakeNotFound()
+		}
+		recordsets.remove(r.vars["id"])
+		rs["action"] = "DELETE"
+		return http.StatusAccepted, rs
+	})
+
+	api.registerDNSPtrRecords()
+}
+
+// dnsZoneRouter checks a router to associate with a private zone, and returns
+// it as it is listed in the zone, or nil and the error message.
+func (api *fakeAPI) dnsZoneRouter(opts fakeObject) (fakeObject, string) {
+	if _, ok := api.coll("vpcs", "id").get(opts.str("router_id")); !ok {
+		return nil, "the router doesn't exist"
+	}
+	region := opts.str("router_region")
+	if region == "" {
+		region = fakeAPIRegion
+	}
+	if region != fakeAPIRegion {
+		return nil, "the router region doesn't exist"
+	}
+	return fakeObject{
+		"router_id":     opts["router_id"],
+		"router_region": region,
+		"status":        "ACTIVE",
+	}, ""
+}
+
+// registerDNSPtrRecords adds the DNS v2 API of the reverse records of
+// floating IPs, which are identified by <region>:<floatingip_id>.
+func (api *fakeAPI) registerDNSPtrRecords() {
+	base := "/dns/v2/"
+
+	ptrs := api.coll("ptrrecords", "id")
+
+	// floatingIPAddress returns the address of an EIP or a Neutron floating
+	// IP, they share their IDs.
+	floatingIPAddress := func(id string) (string, bool) {
+		parts := strings.SplitN(id, ":", 2)
+		if len(parts) != 2 || parts[0] != fakeAPIRegion {
+			return "", false
+		}
+		if eip, ok := api.coll("publicips", "id").get(parts[1]); ok {
+			return eip.str("public_ip_address"), true
+		}
+		if fip, ok := api.coll("floatingips", "id").get(parts[1]); ok {
+			return fip.str("floating_ip_address"), true
+		}
+		return "", false
+	}
+
+	api.handle("GET", base+"reverse/floatingips/{id}", func(r *fakeRequest) (int, interface{}) {
+		if _, ok := floatingIPAddress(r.vars["id"]); !ok {
+			return fakeNotFound()
+		}
+		ptr, ok := ptrs.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		return http.StatusOK, ptr
+	})
+	api.handle("PATCH", base+"reverse/floatingips/{id}", func(r *fakeRequest) (int, interface{}) {
+		address, ok := floatingIPAddress(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		name, ok := r.body["ptrdname"]
+		if !ok {
+			return fakeBadRequest("ptrdname is required")
+		}
+		if name == nil {
+			ptr, ok := ptrs.get(r.vars["id"])
+			if !ok {
+				return fakeNotFound()
+			}
+			ptrs.remove(r.vars["id"])
+			ptr["ptrdname"] = nil
+			ptr["action"] = "DELETE"
+			return http.StatusAccepted, ptr
+		}
+		if !strings.HasSuffix(fmt.Sprint(name), ".") {
+			return fakeBadRequest("ptrdname must be a fully qualified domain name")
+		}
+		ptr, ok := ptrs.get(r.vars["id"])
+		if !ok {
+			ptr = ptrs.add(fakeObject{
+				"id":          r.vars["id"],
+				"address":     address,
+				"description": "",
+				"ttl":         300,
+				"action":      "CREATE",
+			})
+		} else {
+			ptr["action"] = "UPDATE"
+		}
+		for _, k := range []string{"ptrdname", "description", "ttl"} {
+			if v, ok := r.body[k]; ok {
+				ptr[k] = v
+			}
+		}
+		ptr["status"] = "ACTIVE"
+		return http.StatusAccepted, ptr
+	})
+}
@@ -0,0 +1,126 @@
+package telefonicaopencloud
+
+import (
+	"net/http"
+	"strings"
+)
+
+// registerImage adds the Glance v2 API of images. The images are shared
+// with the Nova proxy registered by registerCompute.
+func (api *fakeAPI) registerImage() {
+	base := "/ims/v2/"
+
+	images := api.coll("images", "id")
+
+	for _, image := range []fakeObject{
+		{
+			"id":         fakeAPIImageID,
+			"name":       fakeAPIImageName,
+			"visibility": "public",
+			"created_at": "2018-01-01T00:00:00Z",
+			"properties": fakeObject{"__os_type": "Linux"},
+		},
+		{
+			"id":         "0b6f5a4c-0d2e-4a1b-9c8d-1e2f3a4b5c6d",
+			"name":       "fake-ubuntu-20180601",
+			"visibility": "private",
+			"owner":      fakeAPIProjectID,
+			"tags":       []string{"ubuntu"},
+			"created_at": "2018-06-01T00:00:00Z",
+			"properties": fakeObject{"__os_type": "Linux", "os_distro": "ubuntu"},
+		},
+		{
+			"id":         "7a3c2b1d-4e5f-4a6b-8c7d-9e0f1a2b3c4d",
+			"name":       "fake-ubuntu-20180901",
+			"visibility": "private",
+			"owner":      fakeAPIProjectID,
+			"tags":       []string{"ubuntu", "latest"},
+			"created_at": "2018-09-01T00:00:00Z",
+			"properties": fakeObject{"__os_type": "Linux", "os_distro": "ubuntu"},
+		},
+	} {
+		if existing, ok := images.get(image.str("id")); ok {
+			image = fakeMerge(existing, image)
+		}
+		images.add(fakeDefaults(image, fakeObject{
+			"status":           "ACTIVE",
+			"progress":         100,
+			"minDisk":          0,
+			"minRam":           0,
+			"created":          fakeTime(),
+			"updated":          fakeTime(),
+			"metadata":         fakeObject{},
+			"owner":            "fake-public-owner",
+			"tags":             []string{},
+			"disk_format":      "qcow2",
+			"container_format": "bare",
+			"size":             1073741824,
+		}))
+	}
+
+	api.handle("GET", base+"images", func(r *fakeRequest) (int, interface{}) {
+		query := r.URL.Query()
+		list := []fakeObject{}
+		for _, image := range images.list(nil) {
+			view := api.imageView(image)
+			if !r.filter("tag")(view) || !fakeImageHasTags(view, query["tag"]) {
+				continue
+			}
+			list = append(list, view)
+		}
+		return http.StatusOK, fakeObject{"images": list, "schema": "/v2/schemas/images", "first": "/v2/images"}
+	})
+	api.handle("GET", base+"images/{id}", func(r *fakeRequest) (int, interface{}) {
+		image, ok := images.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		return http.StatusOK, api.imageView(image)
+	})
+}
+
+// imageView returns the Glance representation of an image, with its
+// additional properties alongside its attributes.
+func (api *fakeAPI) imageView(image fakeObject) fakeObject {
+	view := fakeObject{
+		"id":               image["id"],
+		"name":             image["name"],
+		"status":           strings.ToLower(image.str("status")),
+		"visibility":       image["visibility"],
+		"owner":            image["owner"],
+		"tags":             image["tags"],
+		"disk_format":      image["disk_format"],
+		"container_format": image["container_format"],
+		"min_disk":         image["minDisk"],
+		"min_ram":          image["minRam"],
+		"size":             image["size"],
+		"protected":        false,
+		"created_at":       image["created_at"],
+		"updated_at":       image["created_at"],
+		"file":             "/v2/images/" + image.str("id") + "/file",
+		"self":             "/v2/images/" + image.str("id"),
+		"schema":           "/v2/schemas/image",
+	}
+	if properties, ok := image["properties"].(fakeObject); ok {
+		for k, v := range properties {
+			view[k] = v
+		}
+	}
+	return view
+}
+
+// fakeImageHasTags reports whether an image has all the given tags.
+func fakeImageHasTags(image fakeObject, tags []string) bool {
+	for _, tag := range tags {
+		found := false
+		for _, t := range fakeStrings(image["tags"]) {
+			if t == tag {
+				found = true
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
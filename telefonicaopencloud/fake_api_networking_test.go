@@ -0,0 +1,542 @@
+package telefonicaopencloud
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// registerNetworking adds the Neutron v2 API, i.e. networks, subnets, ports,
+// routers, floating IPs and security groups.
+func (api *fakeAPI) registerNetworking() {
+	base := "/vpc/v2.0/"
+
+	networks := api.coll("networks", "id")
+	subnets := api.coll("subnets", "id")
+	ports := api.coll("ports", "id")
+	routers := api.coll("routers", "id")
+	floatingips := api.coll("floatingips", "id")
+	secgroups := api.coll("security-groups", "id")
+	rules := api.coll("security-group-rules", "id")
+
+	api.createNetwork(fakeObject{"id": fakeAPINetworkID, "name": "fake-network"})
+	api.createSubnet(fakeObject{
+		"network_id": fakeAPINetworkID,
+		"name":       "fake-subnet",
+		"cidr":       "192.168.0.0/24",
+	})
+	api.createNetwork(fakeObject{
+		"id":              fakeAPIExtGwID,
+		"name":            fakeAPIPoolName,
+		"router:external": true,
+	})
+	api.createSubnet(fakeObject{
+		"network_id": fakeAPIExtGwID,
+		"name":       fakeAPIPoolName,
+		"cidr":       "100.125.0.0/16",
+	})
+
+	api.createSecGroup(fakeObject{"name": "default", "description": "Default security group"})
+
+	api.handleNeutron(base, "network", "networks", networks, api.createNetwork)
+	api.handleNeutron(base, "subnet", "subnets", subnets, api.createSubnet)
+	api.handleNeutron(base, "port", "ports", ports, api.createPort)
+	api.handleNeutron(base, "router", "routers", routers, api.createRouter)
+	api.handleNeutron(base, "floatingip", "floatingips", floatingips, api.createFloatingIP)
+	api.handleNeutron(base, "security_group", "security-groups", secgroups, api.createSecGroup)
+	api.handleNeutron(base, "security_group_rule", "security-group-rules", rules, api.createSecGroupRule)
+
+	api.handle("PUT", base+"routers/{id}/add_router_interface", api.addRouterInterface)
+	api.handle("PUT", base+"routers/{id}/remove_router_interface", api.removeRouterInterface)
+}
+
+// handleNeutron adds the CRUD operations of a Neutron resource. The create
+// function validates a new resource and fills in its defaults.
+func (api *fakeAPI) handleNeutron(base, singular, plural string, c *fakeCollection,
+	create func(fakeObject) (fakeObject, error)) {
+	view := func(obj fakeObject) fakeObject {
+		if singular == "security_group" {
+			return api.secGroupWithRules(obj)
+		}
+		return obj
+	}
+
+	api.handle("GET", base+plural, func(r *fakeRequest) (int, interface{}) {
+		objs := c.list(r.filter("fixed_ips"))
+		for _, obj := range objs {
+			view(obj)
+		}
+		return http.StatusOK, fakeObject{strings.Replace(plural, "-", "_", -1): objs}
+	})
+	api.handle("POST", base+plural, func(r *fakeRequest) (int, interface{}) {
+		obj, err := create(r.object(singular))
+		if err != nil {
+			return fakeBadRequest(err.Error())
+		}
+		return http.StatusCreated, fakeObject{singular: obj}
+	})
+	api.handle("GET", base+plural+"/{id}", func(r *fakeRequest) (int, interface{}) {
+		obj, ok := c.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		return http.StatusOK, fakeObject{singular: view(obj)}
+	})
+	api.handle("PUT", base+plural+"/{id}", func(r *fakeRequest) (int, interface{}) {
+		obj, ok := c.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		opts := r.object(singular)
+		for k, v := range opts {
+			obj[k] = v
+		}
+		if singular == "floatingip" {
+			api.bindFloatingIP(obj)
+		}
+		return http.StatusOK, fakeObject{singular: view(obj)}
+	})
+	api.handle("DELETE", base+plural+"/{id}", func(r *fakeRequest) (int, interface{}) {
+		obj, ok := c.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		if err := api.deleteNeutron(singular, obj); err != nil {
+			return http.StatusConflict, fakeError(http.StatusConflict, err.Error())
+		}
+		c.remove(r.vars["id"])
+		return http.StatusNoContent, nil
+	})
+}
+
+// deleteNeutron checks that a Neutron resource is not in use and removes
+// the resources which depend on it.
+func (api *fakeAPI) deleteNeutron(singular string, obj fakeObject) error {
+	id := obj.str("id")
+	switch singular {
+	case "network":
+		for _, port := range api.coll("ports", "id").list(nil) {
+			if port.str("network_id") == id && port.str("device_owner") != "network:dhcp" {
+				return fmt.Errorf("network %s is in use by port %s", id, port["id"])
+			}
+		}
+		for _, subnet := range api.coll("subnets", "id").list(nil) {
+			if subnet.str("network_id") == id {
+				api.coll("subnets", "id").remove(subnet.str("id"))
+			}
+		}
+	case "subnet":
+		for _, port := range api.coll("ports", "id").list(nil) {
+			for _, ip := range fakeFixedIPs(port) {
+				if ip.str("subnet_id") == id {
+					return fmt.Errorf("subnet %s is in use by port %s", id, port["id"])
+				}
+			}
+		}
+		if network, ok := api.coll("networks", "id").get(obj.str("network_id")); ok {
+			var ids []string
+			for _, s := range fakeStrings(network["subnets"]) {
+				if s != id {
+					ids = append(ids, s)
+				}
+			}
+			network["subnets"] = ids
+		}
+	case "port":
+		if obj.str("device_owner") == "network:router_interface" {
+			return fmt.Errorf("port %s is a router interface", id)
+		}
+		for _, fip := range api.coll("floatingips", "id").list(nil) {
+			if fip.str("port_id") == id {
+				fip["port_id"] = nil
+				api.bindFloatingIP(fip)
+			}
+		}
+	case "router":
+		for _, port := range api.coll("ports", "id").list(nil) {
+			if port.str("device_id") == id && port.str("device_owner") == "network:router_interface" {
+				return fmt.Errorf("router %s still has interfaces", id)
+			}
+		}
+	case "security_group":
+		for _, port := range api.coll("ports", "id").list(nil) {
+			for _, sg := range fakeStrings(port["security_groups"]) {
+				if sg == id {
+					return fmt.Errorf("security group %s is in use by port %s", id, port["id"])
+				}
+			}
+		}
+		rules := api.coll("security-group-rules", "id")
+		for _, rule := range rules.list(nil) {
+			if rule.str("security_group_id") == id {
+				rules.remove(rule.str("id"))
+			}
+		}
+	}
+	return nil
+}
+
+func (api *fakeAPI) createNetwork(network fakeObject) (fakeObject, error) {
+	fakeDefaults(network, fakeObject{
+		"id":                      api.newID(),
+		"name":                    "",
+		"status":                  "ACTIVE",
+		"admin_state_up":          true,
+		"shared":                  false,
+		"router:external":         false,
+		"subnets":                 []string{},
+		"tenant_id":               fakeAPIProjectID,
+		"availability_zone_hints": []string{},
+	})
+	return api.coll("networks", "id").add(network), nil
+}
+
+func (api *fakeAPI) createSubnet(subnet fakeObject) (fakeObject, error) {
+	network, ok := api.coll("networks", "id").get(subnet.str("network_id"))
+	if !ok {
+		return nil, fmt.Errorf("network %s not found", subnet["network_id"])
+	}
+	_, ipnet, err := net.ParseCIDR(subnet.str("cidr"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid CIDR %q", subnet["cidr"])
+	}
+
+	first, last := fakeIPRange(ipnet)
+	gateway, hasGateway := subnet["gateway_ip"]
+	if !hasGateway {
+		subnet["gateway_ip"] = fakeIPString(first)
+	} else if gateway == "" {
+		subnet["gateway_ip"] = nil
+	}
+	if _, ok := subnet["allocation_pools"]; !ok {
+		start := first
+		if subnet["gateway_ip"] != nil {
+			start++
+		}
+		subnet["allocation_pools"] = []fakeObject{{"start": fakeIPString(start), "end": fakeIPString(last)}}
+	}
+
+	fakeDefaults(subnet, fakeObject{
+		"id":                api.newID(),
+		"name":              "",
+		"ip_version":        4,
+		"enable_dhcp":       true,
+		"dns_nameservers":   []string{},
+		"host_routes":       []fakeObject{},
+		"tenant_id":         fakeAPIProjectID,
+		"ipv6_address_mode": "",
+		"ipv6_ra_mode":      "",
+		"subnetpool_id":     "",
+	})
+	subnet["cidr"] = ipnet.String()
+
+	network["subnets"] = append(fakeStrings(network["subnets"]), subnet.str("id"))
+	return api.coll("subnets", "id").add(subnet), nil
+}
+
+func (api *fakeAPI) createPort(port fakeObject) (fakeObject, error) {
+	network, ok := api.coll("networks", "id").get(port.str("network_id"))
+	if !ok {
+		return nil, fmt.Errorf("network %s not found", port["network_id"])
+	}
+
+	id := api.newID()
+	var fixedIPs []fakeObject
+	if _, ok := port["fixed_ips"]; ok {
+		fixedIPs = fakeFixedIPs(port)
+	} else if subnetIDs := fakeStrings(network["subnets"]); len(subnetIDs) > 0 {
+		fixedIPs = []fakeObject{{"subnet_id": subnetIDs[0]}}
+	}
+	for _, ip := range fixedIPs {
+		subnet, ok := api.coll("subnets", "id").get(ip.str("subnet_id"))
+		if !ok {
+			return nil, fmt.Errorf("subnet %s not found", ip["subnet_id"])
+		}
+		if ip.str("ip_address") == "" {
+			address, err := api.allocateIP(subnet)
+			if err != nil {
+				return nil, err
+			}
+			ip["ip_address"] = address
+		} else if api.ipInUse(subnet.str("id"), ip.str("ip_address")) {
+			return nil, fmt.Errorf("IP address %s is already allocated in subnet %s", ip["ip_address"], subnet["id"])
+		}
+	}
+
+	securityGroups := []string{}
+	if _, ok := port["security_groups"]; ok {
+		securityGroups = fakeStrings(port["security_groups"])
+	}
+
+	fakeDefaults(port, fakeObject{
+		"id":                    id,
+		"name":                  "",
+		"status":                "ACTIVE",
+		"admin_state_up":        true,
+		"mac_address":           fmt.Sprintf("fa:16:3e:%02x:%02x:%02x", api.nextID>>16&0xff, api.nextID>>8&0xff, api.nextID&0xff),
+		"device_owner":          "",
+		"device_id":             "",
+		"allowed_address_pairs": []fakeObject{},
+		"tenant_id":             fakeAPIProjectID,
+	})
+	port["fixed_ips"] = fixedIPs
+	port["security_groups"] = securityGroups
+	return api.coll("ports", "id").add(port), nil
+}
+
+func (api *fakeAPI) createRouter(router fakeObject) (fakeObject, error) {
+	fakeDefaults(router, fakeObject{
+		"id":             api.newID(),
+		"name":           "",
+		"status":         "ACTIVE",
+		"admin_state_up": true,
+		"distributed":    false,
+		"routes":         []fakeObject{},
+		"tenant_id":      fakeAPIProjectID,
+	})
+	return api.coll("routers", "id").add(router), nil
+}
+
+func (api *fakeAPI) createFloatingIP(fip fakeObject) (fakeObject, error) {
+	network, ok := api.coll("networks", "id").get(fip.str("floating_network_id"))
+	if !ok {
+		return nil, fmt.Errorf("network %s not found", fip["floating_network_id"])
+	}
+	if fip.str("floating_ip_address") == "" {
+		subnetIDs := fakeStrings(network["subnets"])
+		if len(subnetIDs) == 0 {
+			return nil, fmt.Errorf("network %s has no subnets", network["id"])
+		}
+		subnet, _ := api.coll("subnets", "id").get(subnetIDs[0])
+		address, err := api.allocateIP(subnet)
+		if err != nil {
+			return nil, err
+		}
+		fip["floating_ip_address"] = address
+	}
+
+	fakeDefaults(fip, fakeObject{
+		"id":        api.newID(),
+		"tenant_id": fakeAPIProjectID,
+		"router_id": "",
+	})
+	api.bindFloatingIP(fip)
+	return api.coll("floatingips", "id").add(fip), nil
+}
+
+// bindFloatingIP sets the fixed IP and the status of a floating IP according
+// to the port it is associated with.
+func (api *fakeAPI) bindFloatingIP(fip fakeObject) {
+	port, ok := api.coll("ports", "id").get(fip.str("port_id"))
+	if !ok {
+		fip["port_id"] = ""
+		fip["fixed_ip_address"] = ""
+		fip["status"] = "DOWN"
+		return
+	}
+
+	fixedIPs := fakeFixedIPs(port)
+	if fip.str("fixed_ip_address") == "" && len(fixedIPs) > 0 {
+		fip["fixed_ip_address"] = fixedIPs[0]["ip_address"]
+	}
+	fip["status"] = "ACTIVE"
+}
+
+func (api *fakeAPI) createSecGroup(sg fakeObject) (fakeObject, error) {
+	fakeDefaults(sg, fakeObject{
+		"id":          api.newID(),
+		"name":        "",
+		"description": "",
+		"tenant_id":   fakeAPIProjectID,
+	})
+	api.coll("security-groups", "id").add(sg)
+
+	for _, ethertype := range []string{"IPv4", "IPv6"} {
+		api.createSecGroupRule(fakeObject{
+			"security_group_id": sg["id"],
+			"direction":         "egress",
+			"ethertype":         ethertype,
+		})
+	}
+	return api.secGroupWithRules(sg), nil
+}
+
+// secGroupWithRules returns a security group with its rules.
+func (api *fakeAPI) secGroupWithRules(sg fakeObject) fakeObject {
+	sg["security_group_rules"] = api.coll("security-group-rules", "id").list(func(rule fakeObject) bool {
+		return rule["security_group_id"] == sg["id"]
+	})
+	return sg
+}
+
+func (api *fakeAPI) createSecGroupRule(rule fakeObject) (fakeObject, error) {
+	if _, ok := api.coll("security-groups", "id").get(rule.str("security_group_id")); !ok {
+		return nil, fmt.Errorf("security group %s not found", rule["security_group_id"])
+	}
+
+	rule["remote_ip_prefix"] = strings.ToLower(rule.str("remote_ip_prefix"))
+	fakeDefaults(rule, fakeObject{
+		"id":               api.newID(),
+		"description":      "",
+		"protocol":         "",
+		"port_range_min":   0,
+		"port_range_max":   0,
+		"remote_group_id":  "",
+		"remote_ip_prefix": "",
+		"tenant_id":        fakeAPIProjectID,
+	})
+	api.coll("security-group-rules", "id").add(rule)
+	return rule, nil
+}
+
+func (api *fakeAPI) addRouterInterface(r *fakeRequest) (int, interface{}) {
+	router, ok := api.coll("routers", "id").get(r.vars["id"])
+	if !ok {
+		return fakeNotFound()
+	}
+
+	var port fakeObject
+	if portID := r.body.str("port_id"); portID != "" {
+		port, ok = api.coll("ports", "id").get(portID)
+		if !ok {
+			return fakeNotFound()
+		}
+		if port.str("device_id") != "" {
+			return http.StatusConflict, fakeError(http.StatusConflict, "port is in use")
+		}
+	} else {
+		subnet, ok := api.coll("subnets", "id").get(r.body.str("subnet_id"))
+		if !ok {
+			return fakeNotFound()
+		}
+		if subnet["gateway_ip"] == nil {
+			return fakeBadRequest("subnet has no gateway IP")
+		}
+		var err error
+		port, err = api.createPort(fakeObject{
+			"network_id": subnet["network_id"],
+			"fixed_ips":  []interface{}{map[string]interface{}{"subnet_id": subnet["id"], "ip_address": subnet["gateway_ip"]}},
+		})
+		if err != nil {
+			return fakeBadRequest(err.Error())
+		}
+	}
+	port["device_id"] = router["id"]
+	port["device_owner"] = "network:router_interface"
+
+	fixedIPs := fakeFixedIPs(port)
+	subnetID := ""
+	if len(fixedIPs) > 0 {
+		subnetID = fixedIPs[0].str("subnet_id")
+	}
+	return http.StatusOK, fakeObject{
+		"id":        router["id"],
+		"port_id":   port["id"],
+		"subnet_id": subnetID,
+		"tenant_id": fakeAPIProjectID,
+	}
+}
+
+func (api *fakeAPI) removeRouterInterface(r *fakeRequest) (int, interface{}) {
+	routerID := r.vars["id"]
+	if _, ok := api.coll("routers", "id").get(routerID); !ok {
+		return fakeNotFound()
+	}
+
+	ports := api.coll("ports", "id")
+	for _, port := range ports.list(nil) {
+		if port.str("device_id") != routerID {
+			continue
+		}
+		fixedIPs := fakeFixedIPs(port)
+		if port.str("id") == r.body.str("port_id") ||
+			(len(fixedIPs) > 0 && fixedIPs[0].str("subnet_id") == r.body.str("subnet_id")) {
+			ports.remove(port.str("id"))
+			return http.StatusOK, fakeObject{
+				"id":        routerID,
+				"port_id":   port["id"],
+				"subnet_id": fixedIPs[0]["subnet_id"],
+				"tenant_id": fakeAPIProjectID,
+			}
+		}
+	}
+	return fakeNotFound()
+}
+
+// allocateIP returns the first free address of the allocation pools of a
+// subnet.
+func (api *fakeAPI) allocateIP(subnet fakeObject) (string, error) {
+	pools, _ := subnet["allocation_pools"].([]fakeObject)
+	if pools == nil {
+		for _, p := range subnet["allocation_pools"].([]interface{}) {
+			pools = append(pools, fakeObject(p.(map[string]interface{})))
+		}
+	}
+	for _, pool := range pools {
+		start := fakeIPToInt(net.ParseIP(pool.str("start")))
+		end := fakeIPToInt(net.ParseIP(pool.str("end")))
+		for ip := start; ip <= end; ip++ {
+			if !api.ipInUse(subnet.str("id"), fakeIPString(ip)) {
+				return fakeIPString(ip), nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no more IP addresses available in subnet %s", subnet["id"])
+}
+
+func (api *fakeAPI) ipInUse(subnetID, address string) bool {
+	for _, port := range api.coll("ports", "id").list(nil) {
+		for _, ip := range fakeFixedIPs(port) {
+			if ip.str("subnet_id") == subnetID && ip.str("ip_address") == address {
+				return true
+			}
+		}
+	}
+	for _, fip := range api.coll("floatingips", "id").list(nil) {
+		if fip.str("floating_ip_address") == address {
+			return true
+		}
+	}
+	return false
+}
+
+// fakeFixedIPs returns the fixed IPs of a port.
+func fakeFixedIPs(port fakeObject) []fakeObject {
+	switch ips := port["fixed_ips"].(type) {
+	case []fakeObject:
+		return ips
+	case []interface{}:
+		objs := make([]fakeObject, 0, len(ips))
+		for _, ip := range ips {
+			objs = append(objs, fakeObject(ip.(map[string]interface{})))
+		}
+		port["fixed_ips"] = objs
+		return objs
+	}
+	return nil
+}
+
+// fakeIPRange returns the first and the last usable IPv4 address of a
+// network.
+func fakeIPRange(ipnet *net.IPNet) (uint32, uint32) {
+	network := fakeIPToInt(ipnet.IP)
+	ones, bits := ipnet.Mask.Size()
+	size := uint32(1) << uint(bits-ones)
+	return network + 1, network + size - 2
+}
+
+func fakeIPToInt(ip net.IP) uint32 {
+	ip4 := ip.To4()
+	if ip4 == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint32(ip4)
+}
+
+func fakeIPString(ip uint32) string {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, ip)
+	return net.IP(b).String()
+}
@@ -0,0 +1,96 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// registerSMN adds the SMN v2 API of topics and subscriptions.
+func (api *fakeAPI) registerSMN() {
+	base := "/smn/v2/{project}/notifications/"
+
+	topics := api.coll("topics", "topic_urn")
+	subscriptions := api.coll("subscriptions", "subscription_urn")
+
+	api.handle("GET", base+"topics", func(r *fakeRequest) (int, interface{}) {
+		list := topics.list(nil)
+		return http.StatusOK, fakeObject{"request_id": api.newID(), "topic_count": len(list), "topics": list}
+	})
+	api.handle("POST", base+"topics", func(r *fakeRequest) (int, interface{}) {
+		name := r.body.str("name")
+		if name == "" {
+			return fakeBadRequest("the name of the topic is required")
+		}
+		urn := fmt.Sprintf("urn:smn:%s:%s:%s", fakeAPIRegion, fakeAPIProjectID, name)
+		if _, ok := topics.get(urn); ok {
+			return http.StatusConflict, fakeError(http.StatusConflict, "the topic already exists")
+		}
+		topics.add(fakeObject{
+			"topic_urn":    urn,
+			"name":         name,
+			"display_name": r.body.str("display_name"),
+			"push_policy":  0,
+			"create_time":  fakeTime(),
+			"update_time":  fakeTime(),
+		})
+		return http.StatusCreated, fakeObject{"request_id": api.newID(), "topic_urn": urn}
+	})
+	api.handle("GET", base+"topics/{urn}", func(r *fakeRequest) (int, interface{}) {
+		topic, ok := topics.get(r.vars["urn"])
+		if !ok {
+			return fakeNotFound()
+		}
+		return http.StatusOK, topic
+	})
+	api.handle("PUT", base+"topics/{urn}", func(r *fakeRequest) (int, interface{}) {
+		topic, ok := topics.get(r.vars["urn"])
+		if !ok {
+			return fakeNotFound()
+		}
+		topic["display_name"] = r.body.str("display_name")
+		topic["update_time"] = fakeTime()
+		return http.StatusOK, fakeObject{"request_id": api.newID()}
+	})
+	api.handle("DELETE", base+"topics/{urn}", func(r *fakeRequest) (int, interface{}) {
+		if !topics.remove(r.vars["urn"]) {
+			return fakeNotFound()
+		}
+		for _, s := range subscriptions.list(nil) {
+			if s["topic_urn"] == r.vars["urn"] {
+				subscriptions.remove(s.str("subscription_urn"))
+			}
+		}
+		return http.StatusNoContent, nil
+	})
+
+	api.handle("GET", base+"subscriptions", func(r *fakeRequest) (int, interface{}) {
+		list := subscriptions.list(nil)
+		return http.StatusOK, fakeObject{"request_id": api.newID(), "subscription_count": len(list), "subscriptions": list}
+	})
+	api.handle("GET", base+"topics/{urn}/subscriptions", func(r *fakeRequest) (int, interface{}) {
+		list := subscriptions.list(func(s fakeObject) bool { return s["topic_urn"] == r.vars["urn"] })
+		return http.StatusOK, fakeObject{"request_id": api.newID(), "subscription_count": len(list), "subscriptions": list}
+	})
+	api.handle("POST", base+"topics/{urn}/subscriptions", func(r *fakeRequest) (int, interface{}) {
+		if _, ok := topics.get(r.vars["urn"]); !ok {
+			return fakeNotFound()
+		}
+		urn := r.vars["urn"] + ":" + api.newID()
+		subscriptions.add(fakeObject{
+			"subscription_urn": urn,
+			"topic_urn":        r.vars["urn"],
+			"protocol":         r.body.str("protocol"),
+			"endpoint":         r.body.str("endpoint"),
+			"remark":           r.body.str("remark"),
+			"owner":            fakeAPIProjectID,
+			"status":           0,
+		})
+		return http.StatusCreated, fakeObject{"request_id": api.newID(), "subscription_urn": urn}
+	})
+	api.handle("DELETE", base+"subscriptions/{urn}", func(r *fakeRequest) (int, interface{}) {
+		if !subscriptions.remove(r.vars["urn"]) {
+			return fakeNotFound()
+		}
+		return http.StatusNoContent, nil
+	})
+}
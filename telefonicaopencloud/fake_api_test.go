@@ -0,0 +1,466 @@
+package telefonicaopencloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// The acceptance tests run against an in-process fake of the
+// TelefonicaOpenCloud API when TF_ACC is set but OS_AUTH_URL is not. The fake
+// serves a Keystone v3 token with a service catalog and keeps the state of
+// VPC v1, Neutron v2, Nova v2, EVS v2, DNS v2 and SMN resources in memory.
+var testAccFakeAPI = os.Getenv("TF_ACC") != "" && os.Getenv("OS_AUTH_URL") == ""
+
+const (
+	fakeAPIRegion    = "fake-region-1"
+	fakeAPIProjectID = "c5d8b8f6a2b34d0c8e1f7a9b3c4d5e6f"
+	fakeAPIDomain    = "fake-domain"
+	fakeAPIToken     = "fake-token"
+
+	fakeAPIImageID   = "5e8a1b2c-3d4e-4f50-8a6b-7c8d9e0f1a2b"
+	fakeAPIImageName = "fake-image"
+	fakeAPIFlavorID  = "s1.medium"
+	fakeAPIBmsFlavor = "physical.o2.medium"
+	fakeAPINetworkID = "0a1b2c3d-4e5f-4a6b-8c7d-8e9f0a1b2c3d"
+	fakeAPIVpcID     = "1b2c3d4e-5f6a-4b7c-8d9e-0f1a2b3c4d5e"
+	fakeAPIExtGwID   = "2c3d4e5f-6a7b-4c8d-9e0f-1a2b3c4d5e6f"
+	fakeAPIPoolName  = "admin_external_net"
+	fakeAPIAZ        = "fake-region-1a"
+)
+
+// testAccFakeEnv holds the values of the OS_* variables used by the
+// acceptance tests when they run against the fake API.
+var testAccFakeEnv = map[string]string{
+	"OS_REGION_NAME":       fakeAPIRegion,
+	"OS_IMAGE_ID":          fakeAPIImageID,
+	"OS_IMAGE_NAME":        fakeAPIImageName,
+	"OS_FLAVOR_ID":         fakeAPIFlavorID,
+	"OS_FLAVOR_NAME":       fakeAPIFlavorID,
+	"OS_BMS_FLAVOR_NAME":   fakeAPIBmsFlavor,
+	"OS_NETWORK_ID":        fakeAPINetworkID,
+	"OS_VPC_ID":            fakeAPIVpcID,
+	"OS_EXTGW_ID":          fakeAPIExtGwID,
+	"OS_POOL_NAME":         fakeAPIPoolName,
+	"OS_AVAILABILITY_ZONE": fakeAPIAZ,
+	"OS_TENANT_ID":         fakeAPIProjectID,
+}
+
+// testAccFakeAPITests lists the prefixes of the acceptance tests covered by
+// the fake API. All other acceptance tests are skipped when it is used. Each
+// prefix must also be matched by one of the test jobs of .travis.yml.
+var testAccFakeAPITests = []string{
+	"TestAccBlockStorageV2",
+	"TestAccComputeV2BmsInstance",
+	"TestAccComputeV2FlavorDataSource",
+	"TestAccComputeV2FloatingIP",
+	"TestAccComputeV2Instance",
+	"TestAccComputeV2InterfaceAttach",
+	"TestAccComputeV2Keypair",
+	"TestAccComputeV2SecGroup",
+	"TestAccComputeV2ServerGroup",
+	"TestAccComputeV2VolumeAttach",
+	"TestAccDNSV2",
+	"TestAccImagesImageV2DataSource",
+	"TestAccNetworkingV2",
+	"TestAccOTCVpcPeeringConnectionV2",
+	"TestAccSMNV2",
+	"TestAccTelefonicaOpenCloudDNSZoneV2DataSource",
+	"TestAccTelefonicaOpenCloudNetworking",
+	"TestAccVpcBandWidthV2",
+	"TestAccVpcRouteV2",
+	"TestAccVpcSubnet",
+	"TestAccVpcV1",
+}
+
+// testAccEnv returns the value of an OS_* variable, or the value the fake
+// API expects when the acceptance tests run against it.
+func testAccEnv(key string) string {
+	if testAccFakeAPI {
+		return testAccFakeEnv[key]
+	}
+	return os.Getenv(key)
+}
+
+// testAccPreCheckFakeAPI skips the tests which are not covered by the fake
+// API.
+func testAccPreCheckFakeAPI(t *testing.T) {
+	for _, prefix := range testAccFakeAPITests {
+		if strings.HasPrefix(t.Name(), prefix) {
+			return
+		}
+	}
+	t.Skip("This test is not covered by the fake API, set OS_AUTH_URL to run it")
+}
+
+func TestMain(m *testing.M) {
+	if !testAccFakeAPI {
+		os.Exit(m.Run())
+	}
+
+	api := newFakeAPI()
+	for _, k := range []string{
+		"OS_ACCESS_KEY", "OS_SECRET_KEY", "OS_AUTH_TOKEN", "OS_CLOUD",
+		"OS_USER_ID", "OS_TENANT_ID", "OS_PROJECT_ID", "OS_ENDPOINT_TYPE",
+		"OS_INSECURE", "OS_CACERT", "OS_CERT", "OS_KEY", "OS_SWAUTH",
+	} {
+		os.Unsetenv(k)
+	}
+	for k, v := range testAccFakeEnv {
+		// The resources read some of them as defaults, e.g. OS_IMAGE_ID.
+		if k != "OS_TENANT_ID" {
+			os.Setenv(k, v)
+		}
+	}
+	os.Setenv("OS_AUTH_URL", api.server.URL+"/v3")
+	os.Setenv("OS_USERNAME", "fake-user")
+	os.Setenv("OS_PASSWORD", "fake-password")
+	os.Setenv("OS_TENANT_NAME", fakeAPIRegion)
+	os.Setenv("OS_DOMAIN_NAME", fakeAPIDomain)
+
+	code := m.Run()
+	api.server.Close()
+	os.Exit(code)
+}
+
+// fakeObject is a resource kept by the fake API, in the form it is
+// serialized to JSON.
+type fakeObject map[string]interface{}
+
+func (o fakeObject) str(key string) string {
+	v, _ := o[key].(string)
+	return v
+}
+
+// fakeCollection is an ordered set of resources of the same kind.
+type fakeCollection struct {
+	key   string
+	items map[string]fakeObject
+	order []string
+}
+
+func newFakeCollection(key string) *fakeCollection {
+	return &fakeCollection{
+		key:   key,
+		items: make(map[string]fakeObject),
+	}
+}
+
+func (c *fakeCollection) add(obj fakeObject) fakeObject {
+	id := obj.str(c.key)
+	if _, ok := c.items[id]; !ok {
+		c.order = append(c.order, id)
+	}
+	c.items[id] = obj
+	return obj
+}
+
+func (c *fakeCollection) get(id string) (fakeObject, bool) {
+	obj, ok := c.items[id]
+	return obj, ok
+}
+
+func (c *fakeCollection) remove(id string) bool {
+	if _, ok := c.items[id]; !ok {
+		return false
+	}
+	delete(c.items, id)
+	for i, v := range c.order {
+		if v == id {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// list returns the resources for which match returns true. A nil match
+// returns all of them.
+func (c *fakeCollection) list(match func(fakeObject) bool) []fakeObject {
+	objs := []fakeObject{}
+	for _, id := range c.order {
+		if match == nil || match(c.items[id]) {
+			objs = append(objs, c.items[id])
+		}
+	}
+	return objs
+}
+
+// fakeRequest is a request routed to a handler of the fake API.
+type fakeRequest struct {
+	*http.Request
+	vars   map[string]string
+	body   fakeObject
+	header http.Header
+}
+
+// object returns the object wrapped in the given key of the request body.
+func (r *fakeRequest) object(key string) fakeObject {
+	if obj, ok := r.body[key].(map[string]interface{}); ok {
+		return fakeObject(obj)
+	}
+	return fakeObject{}
+}
+
+// filter returns a match function comparing the query parameters of the
+// request with the fields of a resource. Parameters which are not fields of
+// the resources are ignored.
+func (r *fakeRequest) filter(ignore ...string) func(fakeObject) bool {
+	query := r.URL.Query()
+	return func(obj fakeObject) bool {
+		for k, vs := range query {
+			skip := false
+			for _, i := range append(ignore, "limit", "marker", "fields", "offset") {
+				if k == i {
+					skip = true
+				}
+			}
+			v, ok := obj[k]
+			if skip || !ok || len(vs) == 0 {
+				continue
+			}
+			if fmt.Sprint(v) != vs[0] {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+type fakeHandler func(r *fakeRequest) (int, interface{})
+
+type fakeRoute struct {
+	method  string
+	pattern []string
+	handler fakeHandler
+}
+
+// fakeAPI is the in-process fake of the TelefonicaOpenCloud API.
+type fakeAPI struct {
+	server *httptest.Server
+	routes []fakeRoute
+
+	mu     sync.Mutex
+	nextID int
+	colls  map[string]*fakeCollection
+}
+
+func newFakeAPI() *fakeAPI {
+	api := &fakeAPI{
+		colls: make(map[string]*fakeCollection),
+	}
+	api.handle("POST", "/v3/auth/tokens", api.createToken)
+	api.registerVpc()
+	api.registerNetworking()
+	api.registerCompute()
+	api.registerImage()
+	api.registerBlockStorage()
+	api.registerDNS()
+	api.registerSMN()
+
+	api.server = httptest.NewServer(api)
+	return api
+}
+
+// handle registers a handler for a method and a path pattern. Segments of
+// the pattern in braces match any value, which is passed in the vars of the
+// request.
+func (api *fakeAPI) handle(method, pattern string, handler fakeHandler) {
+	api.routes = append(api.routes, fakeRoute{
+		method:  method,
+		pattern: strings.Split(strings.Trim(pattern, "/"), "/"),
+		handler: handler,
+	})
+}
+
+// coll returns the collection with the given name, creating it if needed.
+// Its resources are identified by the value of the key field.
+func (api *fakeAPI) coll(name, key string) *fakeCollection {
+	c, ok := api.colls[name]
+	if !ok {
+		c = newFakeCollection(key)
+		api.colls[name] = c
+	}
+	return c
+}
+
+func (api *fakeAPI) newID() string {
+	api.nextID++
+	return fmt.Sprintf("%08x-%04x-4%03x-8%03x-%012x",
+		api.nextID, api.nextID%0x10000, api.nextID%0x1000, api.nextID%0x1000, api.nextID)
+}
+
+func (api *fakeAPI) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	path := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	for _, route := range api.routes {
+		if route.method != req.Method || len(route.pattern) != len(path) {
+			continue
+		}
+		vars, ok := matchFakeRoute(route.pattern, path)
+		if !ok {
+			continue
+		}
+
+		r := &fakeRequest{Request: req, vars: vars, body: fakeObject{}, header: w.Header()}
+		if req.Body != nil {
+			json.NewDecoder(req.Body).Decode(&r.body)
+		}
+		code, body := route.handler(r)
+		writeFakeResponse(w, code, body)
+		return
+	}
+
+	log.Printf("[DEBUG] Fake API has no route for %s %s", req.Method, req.URL.Path)
+	writeFakeResponse(w, http.StatusNotFound, fakeError(http.StatusNotFound, "no route"))
+}
+
+func matchFakeRoute(pattern, path []string) (map[string]string, bool) {
+	vars := make(map[string]string)
+	for i, p := range pattern {
+		if strings.HasPrefix(p, "{") && strings.HasSuffix(p, "}") {
+			vars[p[1:len(p)-1]] = path[i]
+			continue
+		}
+		if p != path[i] {
+			return nil, false
+		}
+	}
+	return vars, true
+}
+
+func writeFakeResponse(w http.ResponseWriter, code int, body interface{}) {
+	if body == nil {
+		w.WriteHeader(code)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(body)
+}
+
+func fakeError(code int, msg string) fakeObject {
+	return fakeObject{"code": code, "message": msg}
+}
+
+func fakeNotFound() (int, interface{}) {
+	return http.StatusNotFound, fakeError(http.StatusNotFound, "resource could not be found")
+}
+
+func fakeBadRequest(msg string) (int, interface{}) {
+	return http.StatusBadRequest, fakeError(http.StatusBadRequest, msg)
+}
+
+// fakeTime returns the current time in the format used by most services.
+func fakeTime() string {
+	return time.Now().UTC().Format("2006-01-02T15:04:05")
+}
+
+// fakeMerge copies the fields of src into dst. Fields with a nil value are
+// ignored.
+func fakeMerge(dst, src fakeObject) fakeObject {
+	for k, v := range src {
+		if v != nil {
+			dst[k] = v
+		}
+	}
+	return dst
+}
+
+// fakeDefaults sets the fields of obj which are not set yet.
+func fakeDefaults(obj, defaults fakeObject) fakeObject {
+	for k, v := range defaults {
+		if _, ok := obj[k]; !ok || obj[k] == nil {
+			obj[k] = v
+		}
+	}
+	return obj
+}
+
+// fakeInt returns a numeric field of a request as an int.
+func fakeInt(v interface{}) int {
+	switch n := v.(type) {
+	case float64:
+		return int(n)
+	case int:
+		return n
+	case string:
+		i, _ := strconv.Atoi(n)
+		return i
+	}
+	return 0
+}
+
+// fakeStrings returns a list field of a request as strings.
+func fakeStrings(v interface{}) []string {
+	if s, ok := v.([]string); ok {
+		return s
+	}
+	items, _ := v.([]interface{})
+	s := make([]string, 0, len(items))
+	for _, item := range items {
+		s = append(s, fmt.Sprint(item))
+	}
+	return s
+}
+
+func sortedFakeKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (api *fakeAPI) createToken(r *fakeRequest) (int, interface{}) {
+	base := api.server.URL
+	endpoint := func(url string) []fakeObject {
+		return []fakeObject{{
+			"id":        api.newID(),
+			"interface": "public",
+			"region":    fakeAPIRegion,
+			"region_id": fakeAPIRegion,
+			"url":       base + url,
+		}}
+	}
+	catalog := []fakeObject{
+		{"type": "identity", "name": "keystone", "endpoints": endpoint("/v3/")},
+		{"type": "network", "name": "neutron", "endpoints": endpoint("/vpc/")},
+		{"type": "compute", "name": "nova", "endpoints": endpoint("/ecs/v2/" + fakeAPIProjectID + "/")},
+		{"type": "volumev2", "name": "cinderv2", "endpoints": endpoint("/evs/v2/" + fakeAPIProjectID + "/")},
+		{"type": "dns", "name": "designate", "endpoints": endpoint("/dns/")},
+		{"type": "image", "name": "glance", "endpoints": endpoint("/ims/")},
+	}
+
+	token := fakeObject{
+		"expires_at": time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339),
+		"issued_at":  time.Now().UTC().Format(time.RFC3339),
+		"methods":    []string{"password"},
+		"project": fakeObject{
+			"id":     fakeAPIProjectID,
+			"name":   fakeAPIRegion,
+			"domain": fakeObject{"id": fakeAPIDomain, "name": fakeAPIDomain},
+		},
+		"user": fakeObject{
+			"id":     "fake-user",
+			"name":   "fake-user",
+			"domain": fakeObject{"id": fakeAPIDomain, "name": fakeAPIDomain},
+		},
+		"catalog": catalog,
+	}
+
+	r.header.Set("X-Subject-Token", fakeAPIToken)
+	return http.StatusCreated, fakeObject{"token": token}
+}
@@ -0,0 +1,459 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// registerVpc adds the VPC v1 API, i.e. VPCs, subnets, EIPs and bandwidths,
+// the VPC v2.0 peerings, routes and shared bandwidths, and the tags of VPCs
+// and EIPs.
+func (api *fakeAPI) registerVpc() {
+	base := "/vpc/v1/{project}/"
+
+	vpcs := api.coll("vpcs", "id")
+	vpcs.add(fakeObject{
+		"id":                 fakeAPIVpcID,
+		"name":               "fake-vpc",
+		"cidr":               "192.168.0.0/16",
+		"status":             "OK",
+		"routes":             []fakeObject{},
+		"enable_shared_snat": false,
+	})
+
+	api.handle("GET", base+"vpcs", func(r *fakeRequest) (int, interface{}) {
+		return http.StatusOK, fakeObject{"vpcs": vpcs.list(nil)}
+	})
+	api.handle("POST", base+"vpcs", func(r *fakeRequest) (int, interface{}) {
+		vpc := fakeDefaults(r.object("vpc"), fakeObject{
+			"id":                 api.newID(),
+			"status":             "OK",
+			"routes":             []fakeObject{},
+			"enable_shared_snat": false,
+		})
+		return http.StatusOK, fakeObject{"vpc": vpcs.add(vpc)}
+	})
+	api.handle("GET", base+"vpcs/{id}", func(r *fakeRequest) (int, interface{}) {
+		vpc, ok := vpcs.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		return http.StatusOK, fakeObject{"vpc": vpc}
+	})
+	api.handle("PUT", base+"vpcs/{id}", func(r *fakeRequest) (int, interface{}) {
+		vpc, ok := vpcs.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		return http.StatusOK, fakeObject{"vpc": fakeMerge(vpc, r.object("vpc"))}
+	})
+	api.handle("DELETE", base+"vpcs/{id}", func(r *fakeRequest) (int, interface{}) {
+		id := r.vars["id"]
+		if len(api.coll("vpc_subnets", "id").list(func(o fakeObject) bool { return o.str("vpc_id") == id })) > 0 {
+			return http.StatusConflict, fakeError(http.StatusConflict, "the VPC still has subnets")
+		}
+		if !vpcs.remove(id) {
+			return fakeNotFound()
+		}
+		return http.StatusNoContent, nil
+	})
+
+	subnets := api.coll("vpc_subnets", "id")
+	api.handle("GET", base+"subnets", func(r *fakeRequest) (int, interface{}) {
+		return http.StatusOK, fakeObject{"subnets": subnets.list(r.filter())}
+	})
+	api.handle("POST", base+"subnets", func(r *fakeRequest) (int, interface{}) {
+		subnet := r.object("subnet")
+		if _, ok := vpcs.get(subnet.str("vpc_id")); !ok {
+			return fakeBadRequest("the VPC does not exist")
+		}
+		id := api.newID()
+		fakeDefaults(subnet, fakeObject{
+			"id":                id,
+			"status":            "ACTIVE",
+			"dnsList":           []string{},
+			"dhcp_enable":       true,
+			"primary_dns":       "",
+			"secondary_dns":     "",
+			"availability_zone": "",
+			"neutron_subnet_id": id,
+		})
+		return http.StatusOK, fakeObject{"subnet": subnets.add(subnet)}
+	})
+	api.handle("GET", base+"subnets/{id}", func(r *fakeRequest) (int, interface{}) {
+		subnet, ok := subnets.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		return http.StatusOK, fakeObject{"subnet": subnet}
+	})
+	api.handle("PUT", base+"vpcs/{vpc}/subnets/{id}", func(r *fakeRequest) (int, interface{}) {
+		subnet, ok := subnets.get(r.vars["id"])
+		if !ok || subnet.str("vpc_id") != r.vars["vpc"] {
+			return fakeNotFound()
+		}
+		fakeMerge(subnet, r.object("subnet"))
+		return http.StatusOK, fakeObject{"subnet": fakeObject{"id": subnet["id"], "status": subnet["status"]}}
+	})
+	api.handle("DELETE", base+"vpcs/{vpc}/subnets/{id}", func(r *fakeRequest) (int, interface{}) {
+		subnet, ok := subnets.get(r.vars["id"])
+		if !ok || subnet.str("vpc_id") != r.vars["vpc"] {
+			return fakeNotFound()
+		}
+		subnets.remove(r.vars["id"])
+		return http.StatusNoContent, nil
+	})
+
+	eips := api.coll("publicips", "id")
+	bandwidths := api.coll("bandwidths", "id")
+	api.handle("POST", base+"publicips", func(r *fakeRequest) (int, interface{}) {
+		ip := r.object("publicip")
+		bw := r.object("bandwidth")
+		bandwidth := bandwidths.add(fakeObject{
+			"id":             api.newID(),
+			"name":           bw["name"],
+			"size":           fakeInt(bw["size"]),
+			"share_type":     bw["share_type"],
+			"charge_mode":    bw["charge_mode"],
+			"bandwidth_type": "bgp",
+			"tenant_id":      fakeAPIProjectID,
+		})
+		address := ip.str("ip_address")
+		if address == "" {
+			address = fmt.Sprintf("100.64.%d.%d", len(eips.order)/250, len(eips.order)%250+1)
+		}
+		eip := eips.add(fakeObject{
+			"id":                   api.newID(),
+			"status":               "DOWN",
+			"type":                 ip["type"],
+			"public_ip_address":    address,
+			"private_ip_address":   "",
+			"port_id":              "",
+			"tenant_id":            fakeAPIProjectID,
+			"create_time":          fakeTime(),
+			"bandwidth_id":         bandwidth["id"],
+			"bandwidth_size":       bandwidth["size"],
+			"bandwidth_share_type": bandwidth["share_type"],
+		})
+		return http.StatusOK, fakeObject{"publicip": eip}
+	})
+	api.handle("GET", base+"publicips/{id}", func(r *fakeRequest) (int, interface{}) {
+		eip, ok := eips.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		return http.StatusOK, fakeObject{"publicip": eip}
+	})
+	api.handle("PUT", base+"publicips/{id}", func(r *fakeRequest) (int, interface{}) {
+		eip, ok := eips.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		eip["port_id"] = r.object("publicip").str("port_id")
+		eip["status"] = "DOWN"
+		if eip.str("port_id") != "" {
+			eip["status"] = "ACTIVE"
+		}
+		return http.StatusOK, fakeObject{"publicip": eip}
+	})
+	api.handle("DELETE", base+"publicips/{id}", func(r *fakeRequest) (int, interface{}) {
+		eip, ok := eips.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		if eip.str("bandwidth_share_type") == "PER" {
+			bandwidths.remove(eip.str("bandwidth_id"))
+		}
+		eips.remove(r.vars["id"])
+		return http.StatusNoContent, nil
+	})
+	api.handle("GET", base+"bandwidths/{id}", func(r *fakeRequest) (int, interface{}) {
+		bandwidth, ok := bandwidths.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		return http.StatusOK, fakeObject{"bandwidth": bandwidth}
+	})
+	api.handle("PUT", base+"bandwidths/{id}", func(r *fakeRequest) (int, interface{}) {
+		bandwidth, ok := bandwidths.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		opts := r.object("bandwidth")
+		if name := opts.str("name"); name != "" {
+			bandwidth["name"] = name
+		}
+		if size := fakeInt(opts["size"]); size != 0 {
+			bandwidth["size"] = size
+		}
+		for _, eip := range eips.list(func(o fakeObject) bool { return o["bandwidth_id"] == bandwidth["id"] }) {
+			eip["bandwidth_size"] = bandwidth["size"]
+		}
+		return http.StatusOK, fakeObject{"bandwidth": bandwidth}
+	})
+
+	api.registerSharedBandwidths()
+	api.registerVpcPeerings()
+	api.registerTags("/vpc/v2.0/{project}/", "vpcs", "publicips")
+}
+
+// registerSharedBandwidths adds the shared bandwidths of the VPC v2.0 API.
+// They are read and updated with the VPC v1 API.
+func (api *fakeAPI) registerSharedBandwidths() {
+	base := "/vpc/v2.0/{project}/"
+
+	eips := api.coll("publicips", "id")
+	bandwidths := api.coll("bandwidths", "id")
+
+	publicIPs := func(bandwidth fakeObject) []fakeObject {
+		return eips.list(func(o fakeObject) bool { return o["bandwidth_id"] == bandwidth["id"] })
+	}
+	publicIPInfo := func(r *fakeRequest) ([]fakeObject, bool) {
+		var list []fakeObject
+		items, _ := r.object("bandwidth")["publicip_info"].([]interface{})
+		for _, item := range items {
+			info, _ := item.(map[string]interface{})
+			eip, ok := eips.get(fakeObject(info).str("publicip_id"))
+			if !ok {
+				return nil, false
+			}
+			list = append(list, eip)
+		}
+		return list, len(list) > 0
+	}
+
+	api.handle("POST", base+"bandwidths", func(r *fakeRequest) (int, interface{}) {
+		bw := r.object("bandwidth")
+		bandwidth := bandwidths.add(fakeObject{
+			"id":             api.newID(),
+			"name":           bw["name"],
+			"size":           fakeInt(bw["size"]),
+			"share_type":     "WHOLE",
+			"charge_mode":    "bandwidth",
+			"bandwidth_type": "share",
+			"tenant_id":      fakeAPIProjectID,
+		})
+		return http.StatusOK, fakeObject{"bandwidth": bandwidth}
+	})
+	api.handle("DELETE", base+"bandwidths/{id}", func(r *fakeRequest) (int, interface{}) {
+		bandwidth, ok := bandwidths.get(r.vars["id"])
+		if !ok || bandwidth.str("share_type") != "WHOLE" {
+			return fakeNotFound()
+		}
+		if len(publicIPs(bandwidth)) > 0 {
+			return http.StatusConflict, fakeError(http.StatusConflict, "the bandwidth still has EIPs")
+		}
+		bandwidths.remove(r.vars["id"])
+		return http.StatusNoContent, nil
+	})
+	api.handle("POST", base+"bandwidths/{id}/insert", func(r *fakeRequest) (int, interface{}) {
+		bandwidth, ok := bandwidths.get(r.vars["id"])
+		if !ok || bandwidth.str("share_type") != "WHOLE" {
+			return fakeNotFound()
+		}
+		list, ok := publicIPInfo(r)
+		if !ok {
+			return fakeBadRequest("the EIP does not exist")
+		}
+		for _, eip := range list {
+			if eip.str("bandwidth_share_type") != "PER" {
+				return fakeBadRequest("the EIP is already in a shared bandwidth")
+			}
+		}
+		for _, eip := range list {
+			bandwidths.remove(eip.str("bandwidth_id"))
+			eip["bandwidth_id"] = bandwidth["id"]
+			eip["bandwidth_size"] = bandwidth["size"]
+			eip["bandwidth_share_type"] = "WHOLE"
+		}
+		return http.StatusOK, fakeObject{"bandwidth": bandwidth}
+	})
+	api.handle("POST", base+"bandwidths/{id}/remove", func(r *fakeRequest) (int, interface{}) {
+		bandwidth, ok := bandwidths.get(r.vars["id"])
+		if !ok || bandwidth.str("share_type") != "WHOLE" {
+			return fakeNotFound()
+		}
+		list, ok := publicIPInfo(r)
+		if !ok {
+			return fakeBadRequest("the EIP does not exist")
+		}
+		opts := r.object("bandwidth")
+		for _, eip := range list {
+			if eip["bandwidth_id"] != bandwidth["id"] {
+				return fakeBadRequest("the EIP is not in the shared bandwidth")
+			}
+		}
+		for _, eip := range list {
+			dedicated := bandwidths.add(fakeObject{
+				"id":             api.newID(),
+				"name":           "bandwidth-" + eip.str("public_ip_address"),
+				"size":           fakeInt(opts["size"]),
+				"share_type":     "PER",
+				"charge_mode":    opts["charge_mode"],
+				"bandwidth_type": "bgp",
+				"tenant_id":      fakeAPIProjectID,
+			})
+			eip["bandwidth_id"] = dedicated["id"]
+			eip["bandwidth_size"] = dedicated["size"]
+			eip["bandwidth_share_type"] = "PER"
+		}
+		return http.StatusNoContent, nil
+	})
+}
+
+// registerVpcPeerings adds the VPC peerings and routes of the VPC v2.0 API.
+// All VPCs are in the same project, so peerings are active once created.
+func (api *fakeAPI) registerVpcPeerings() {
+	base := "/vpc/v2.0/vpc/"
+
+	vpcs := api.coll("vpcs", "id")
+	peerings := api.coll("peerings", "id")
+	routes := api.coll("vpc_routes", "id")
+
+	vpcInfo := func(info fakeObject) fakeObject {
+		return fakeDefaults(info, fakeObject{"tenant_id": fakeAPIProjectID})
+	}
+
+	api.handle("GET", base+"peerings", func(r *fakeRequest) (int, interface{}) {
+		return http.StatusOK, fakeObject{"peerings": peerings.list(r.filter())}
+	})
+	api.handle("POST", base+"peerings", func(r *fakeRequest) (int, interface{}) {
+		peering := r.object("peering")
+		request := vpcInfo(fakeObject(peering["request_vpc_info"].(map[string]interface{})))
+		accept := vpcInfo(fakeObject(peering["accept_vpc_info"].(map[string]interface{})))
+		for _, info := range []fakeObject{request, accept} {
+			if _, ok := vpcs.get(info.str("vpc_id")); !ok {
+				return fakeBadRequest("the VPC does not exist")
+			}
+		}
+		peering["id"] = api.newID()
+		peering["status"] = "ACTIVE"
+		peering["request_vpc_info"] = request
+		peering["accept_vpc_info"] = accept
+		return http.StatusCreated, fakeObject{"peering": peerings.add(peering)}
+	})
+	api.handle("GET", base+"peerings/{id}", func(r *fakeRequest) (int, interface{}) {
+		peering, ok := peerings.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		return http.StatusOK, fakeObject{"peering": peering}
+	})
+	api.handle("PUT", base+"peerings/{id}", func(r *fakeRequest) (int, interface{}) {
+		peering, ok := peerings.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		if name := r.object("peering").str("name"); name != "" {
+			peering["name"] = name
+		}
+		return http.StatusOK, fakeObject{"peering": peering}
+	})
+	api.handle("DELETE", base+"peerings/{id}", func(r *fakeRequest) (int, interface{}) {
+		id := r.vars["id"]
+		if len(routes.list(func(o fakeObject) bool { return o.str("nexthop") == id })) > 0 {
+			return http.StatusConflict, fakeError(http.StatusConflict, "the peering still has routes")
+		}
+		if !peerings.remove(id) {
+			return fakeNotFound()
+		}
+		return http.StatusNoContent, nil
+	})
+
+	// vpcRoutes keeps the routes listed in a VPC in sync with its routes.
+	vpcRoutes := func(vpcID string) {
+		vpc, ok := vpcs.get(vpcID)
+		if !ok {
+			return
+		}
+		list := []fakeObject{}
+		for _, route := range routes.list(func(o fakeObject) bool { return o.str("vpc_id") == vpcID }) {
+			list = append(list, fakeObject{"destination": route["destination"], "nexthop": route["nexthop"]})
+		}
+		vpc["routes"] = list
+	}
+
+	api.handle("GET", base+"routes", func(r *fakeRequest) (int, interface{}) {
+		return http.StatusOK, fakeObject{"routes": routes.list(r.filter())}
+	})
+	api.handle("POST", base+"routes", func(r *fakeRequest) (int, interface{}) {
+		route := r.object("route")
+		vpcID := route.str("vpc_id")
+		if _, ok := vpcs.get(vpcID); !ok {
+			return fakeBadRequest("the VPC does not exist")
+		}
+		peering, ok := peerings.get(route.str("nexthop"))
+		if route.str("type") != "peering" || !ok || peering.str("status") != "ACTIVE" {
+			return fakeBadRequest("the next hop is not an active peering")
+		}
+		for _, other := range routes.list(func(o fakeObject) bool { return o.str("vpc_id") == vpcID }) {
+			if other.str("destination") == route.str("destination") {
+				return fakeBadRequest("the VPC already has a route to the destination")
+			}
+		}
+		fakeDefaults(route, fakeObject{"id": api.newID(), "tenant_id": fakeAPIProjectID})
+		routes.add(route)
+		vpcRoutes(vpcID)
+		return http.StatusCreated, fakeObject{"route": route}
+	})
+	api.handle("GET", base+"routes/{id}", func(r *fakeRequest) (int, interface{}) {
+		route, ok := routes.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		return http.StatusOK, fakeObject{"route": route}
+	})
+	api.handle("DELETE", base+"routes/{id}", func(r *fakeRequest) (int, interface{}) {
+		route, ok := routes.get(r.vars["id"])
+		if !ok {
+			return fakeNotFound()
+		}
+		routes.remove(r.vars["id"])
+		vpcRoutes(route.str("vpc_id"))
+		return http.StatusNoContent, nil
+	})
+}
+
+// registerTags adds the tags API of the given resource types below base.
+func (api *fakeAPI) registerTags(base string, types ...string) {
+	tags := api.coll("tags", "id")
+	for _, t := range types {
+		resourceType := t
+		tagsID := func(r *fakeRequest) string {
+			return resourceType + "/" + r.vars["id"]
+		}
+
+		api.handle("GET", base+resourceType+"/{id}/tags", func(r *fakeRequest) (int, interface{}) {
+			list := []fakeObject{}
+			if obj, ok := tags.get(tagsID(r)); ok {
+				m := obj["tags"].(map[string]interface{})
+				for _, k := range sortedFakeKeys(m) {
+					list = append(list, fakeObject{"key": k, "value": m[k]})
+				}
+			}
+			return http.StatusOK, fakeObject{"tags": list}
+		})
+		api.handle("POST", base+resourceType+"/{id}/tags/action", func(r *fakeRequest) (int, interface{}) {
+			obj, ok := tags.get(tagsID(r))
+			if !ok {
+				obj = tags.add(fakeObject{"id": tagsID(r), "tags": map[string]interface{}{}})
+			}
+			m := obj["tags"].(map[string]interface{})
+			action := strings.ToLower(r.body.str("action"))
+			items, _ := r.body["tags"].([]interface{})
+			for _, item := range items {
+				tag := fakeObject(item.(map[string]interface{}))
+				switch action {
+				case "create":
+					m[tag.str("key")] = tag["value"]
+				case "delete":
+					delete(m, tag.str("key"))
+				default:
+					return fakeBadRequest("unknown tags action " + action)
+				}
+			}
+			return http.StatusNoContent, nil
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package telefonicaopencloud
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/huaweicloud/golangsdk"
+)
+
+// imageV2 is an image, as returned by the Image v2 API.
+type imageV2 struct {
+	ID              string    `json:"id"`
+	Name            string    `json:"name"`
+	Status          string    `json:"status"`
+	Visibility      string    `json:"visibility"`
+	Owner           string    `json:"owner"`
+	Tags            []string  `json:"tags"`
+	ContainerFormat string    `json:"container_format"`
+	DiskFormat      string    `json:"disk_format"`
+	MinDisk         int       `json:"min_disk"`
+	MinRAM          int       `json:"min_ram"`
+	Protected       bool      `json:"protected"`
+	Checksum        string    `json:"checksum"`
+	SizeBytes       int64     `json:"size"`
+	File            string    `json:"file"`
+	Schema          string    `json:"schema"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+
+	// Properties holds the additional properties of the image, which the API
+	// returns alongside the attributes above.
+	Properties map[string]string `json:"-"`
+}
+
+// imageV2Attributes are the JSON keys of the attributes of an image which
+// are not additional properties.
+var imageV2Attributes = []string{
+	"id", "name", "status", "visibility", "owner", "tags", "container_format",
+	"disk_format", "min_disk", "min_ram", "protected", "checksum", "size",
+	"virtual_size", "file", "schema", "self", "created_at", "updated_at",
+	"direct_url", "locations",
+}
+
+func (r *imageV2) UnmarshalJSON(b []byte) error {
+	type tmp imageV2
+	var s tmp
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	*r = imageV2(s)
+
+	var all map[string]interface{}
+	if err := json.Unmarshal(b, &all); err != nil {
+		return err
+	}
+	for _, k := range imageV2Attributes {
+		delete(all, k)
+	}
+
+	r.Properties = make(map[string]string)
+	for k, v := range all {
+		if v, ok := v.(string); ok {
+			r.Properties[k] = v
+		}
+	}
+
+	return nil
+}
+
+// imageV2ListOpts filters the images returned by listImagesV2.
+type imageV2ListOpts struct {
+	Name       string `q:"name"`
+	Visibility string `q:"visibility"`
+	Owner      string `q:"owner"`
+	Status     string `q:"status"`
+
+	// Tags are all required on the images returned.
+	Tags []string `q:"tag"`
+
+	SortKey string `q:"sort_key"`
+	SortDir string `q:"sort_dir"`
+}
+
+func imagesV2URL(client *golangsdk.ServiceClient) string {
+	return client.ServiceURL("images")
+}
+
+// listImagesV2 retrieves the images matching the given filters, following
+// the pages of the list.
+func listImagesV2(client *golangsdk.ServiceClient, opts imageV2ListOpts) ([]imageV2, error) {
+	q, err := golangsdk.BuildQueryString(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var allImages []imageV2
+	url := imagesV2URL(client) + q.String()
+	for url != "" {
+		var r struct {
+			Images []imageV2 `json:"images"`
+			Next   string    `json:"next"`
+		}
+		_, err = client.Get(url, &r, nil)
+		if err != nil {
+			return nil, err
+		}
+		allImages = append(allImages, r.Images...)
+
+		// The link to the next page is relative to the root of the API,
+		// e.g. /v2/images?marker=...
+		url = ""
+		if r.Next != "" {
+			url = client.ResourceBase + strings.TrimPrefix(r.Next, "/v2/")
+		}
+	}
+
+	return allImages, nil
+}
@@ -0,0 +1,32 @@
+package telefonicaopencloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccASV1Configuration_importBasic(t *testing.T) {
+	resourceName := "telefonicaopencloud_as_configuration_v1.hth_as_config"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccAsConfigPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckASV1ConfigurationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccASV1Configuration_basic,
+			},
+
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"instance_config.0.user_data",
+					"instance_config.0.personality",
+				},
+			},
+		},
+	})
+}
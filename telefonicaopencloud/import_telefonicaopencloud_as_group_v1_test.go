@@ -0,0 +1,28 @@
+package telefonicaopencloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccASV1Group_importBasic(t *testing.T) {
+	resourceName := "telefonicaopencloud_as_group_v1.hth_as_group"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccAsConfigPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckASV1GroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testASV1Group_basic,
+			},
+
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,28 @@
+package telefonicaopencloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccBlockStorageV2Snapshot_importBasic(t *testing.T) {
+	resourceName := "telefonicaopencloud_blockstorage_snapshot_v2.snapshot_1"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBlockStorageV2SnapshotDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBlockStorageV2Snapshot_basic,
+			},
+
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,28 @@
+package telefonicaopencloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccComputeV2BmsInstance_importBasic(t *testing.T) {
+	resourceName := "telefonicaopencloud_compute_bms_server_v2.instance_1"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccBmsFlavorPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeV2BmsInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccComputeV2BmsInstance_basic,
+			},
+
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,28 @@
+package telefonicaopencloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDNSV2ZoneRecords_importBasic(t *testing.T) {
+	zoneName := randomZoneName()
+	resourceName := "telefonicaopencloud_dns_zone_records_v2.records_1"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckDNS(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDNSV2ZoneRecordsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDNSV2ZoneRecords_basic(zoneName),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,65 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// The import ID of a backend is made of the IDs of its listener and server,
+// which are only known once they are created, so the importer is run by a
+// check function rather than by an ImportState step.
+func TestAccELBBackend_importBasic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckELBBackendDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: TestAccELBBackendConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckELBBackendImport("telefonicaopencloud_elb_backendecs.backend_1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckELBBackendImport(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		importID := fmt.Sprintf("%s/%s", rs.Primary.Attributes["listener_id"], rs.Primary.Attributes["server_id"])
+		d := resourceELBBackendECS().Data(nil)
+		d.SetId(importID)
+
+		imported, err := resourceELBBackendECSImport(d, testAccProvider.Meta())
+		if err != nil {
+			return fmt.Errorf("Error importing %s: %s", importID, err)
+		}
+		if len(imported) != 1 {
+			return fmt.Errorf("Expected one backend imported from %s, got %d", importID, len(imported))
+		}
+
+		d = imported[0]
+		if err := resourceELBBackendECSRead(d, testAccProvider.Meta()); err != nil {
+			return fmt.Errorf("Error reading the backend imported from %s: %s", importID, err)
+		}
+
+		if d.Id() != rs.Primary.ID {
+			return fmt.Errorf("Expected backend %s imported from %s, got %s", rs.Primary.ID, importID, d.Id())
+		}
+		for _, k := range []string{"listener_id", "server_id", "private_address"} {
+			if v := d.Get(k).(string); v != rs.Primary.Attributes[k] {
+				return fmt.Errorf("Expected %s of the imported backend to be %q, got %q", k, rs.Primary.Attributes[k], v)
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,31 @@
+package telefonicaopencloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccELBLoadBalancer_importBasic(t *testing.T) {
+	resourceName := "telefonicaopencloud_elb_loadbalancer.loadbalancer_1"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckELB(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckELBLoadBalancerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccELBLoadBalancerConfig_basic,
+			},
+
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"charge_mode",
+				},
+			},
+		},
+	})
+}
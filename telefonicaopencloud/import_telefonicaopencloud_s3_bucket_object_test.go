@@ -0,0 +1,36 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccS3BucketObject_importBasic(t *testing.T) {
+	resourceName := "telefonicaopencloud_s3_bucket_object.object"
+	rInt := acctest.RandInt()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckS3BucketObjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccS3BucketObjectConfigContent(rInt),
+			},
+
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("tf-object-test-bucket-%d/test-key", rInt),
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"acl",
+					"content",
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,31 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccS3BucketPolicy_importBasic(t *testing.T) {
+	resourceName := "telefonicaopencloud_s3_bucket_policy.bucket"
+	name := fmt.Sprintf("tf-test-bucket-%d", acctest.RandInt())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckObs(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccS3BucketPolicyConfig(name),
+			},
+
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,28 @@
+package telefonicaopencloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccSMNV2Subscription_importBasic(t *testing.T) {
+	resourceName := "telefonicaopencloud_smn_subscription_v2.subscription_1"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSMNSubscriptionV2Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: TestAccSMNV2SubscriptionConfig_basic,
+			},
+
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
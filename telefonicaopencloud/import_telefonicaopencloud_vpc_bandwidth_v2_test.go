@@ -0,0 +1,52 @@
+package telefonicaopencloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccVpcBandWidthV2_importBasic(t *testing.T) {
+	resourceName := "telefonicaopencloud_vpc_bandwidth_v2.bandwidth_1"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpcBandWidthV2Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpcBandWidthV2_basic,
+			},
+
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccVpcBandWidthV2Associate_importBasic(t *testing.T) {
+	resourceName := "telefonicaopencloud_vpc_bandwidth_associate_v2.associate_1"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpcBandWidthV2Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpcBandWidthV2Associate_basic,
+			},
+
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"dedicated_name", "dedicated_size", "dedicated_charge_mode",
+				},
+			},
+		},
+	})
+}
@@ -15,6 +15,14 @@ import (
 	"github.com/huaweicloud/golangsdk/openstack/networking/v2/extensions/lbaas_v2/pools"
 )
 
+// lbPendingStatuses are the provisioning statuses a load balancer passes
+// through while it is being created or updated.
+var lbPendingStatuses = []string{"PENDING_CREATE", "PENDING_UPDATE"}
+
+// lbPendingDeleteStatuses are the statuses a load balancer or one of its
+// child resources may report while it is being deleted.
+var lbPendingDeleteStatuses = []string{"ACTIVE", "PENDING_UPDATE", "PENDING_DELETE"}
+
 func waitForLBV2Listener(networkingClient *golangsdk.ServiceClient, id string, target string, pending []string, timeout time.Duration) error {
 	log.Printf("[DEBUG] Waiting for listener %s to become %s.", id, target)
 
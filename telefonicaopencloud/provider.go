@@ -1,6 +1,9 @@
 package telefonicaopencloud
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/hashicorp/terraform/helper/mutexkv"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
@@ -167,33 +170,96 @@ func Provider() terraform.ResourceProvider {
 				DefaultFunc: schema.EnvDefaultFunc("OS_CLOUD", ""),
 				Description: descriptions["cloud"],
 			},
+
+			"default_tags": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: descriptions["default_tags"],
+			},
+
+			"max_retries": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("OS_MAX_RETRIES", defaultMaxRetries),
+				Description: descriptions["max_retries"],
+			},
+
+			"max_backoff": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("OS_MAX_BACKOFF", int(defaultMaxBackoff/time.Second)),
+				Description: descriptions["max_backoff"],
+			},
+
+			"rate_limits": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: descriptions["rate_limits"],
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"service": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+								return ValidateStringList(v, k, rateLimitServices)
+							},
+						},
+						"requests_per_second": {
+							Type:     schema.TypeFloat,
+							Required: true,
+							ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+								if v.(float64) <= 0 {
+									errors = append(errors, fmt.Errorf("%q must be greater than 0", k))
+								}
+								return
+							},
+						},
+						"burst": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"concurrency": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+					},
+				},
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"telefonicaopencloud_dns_zone_v2":            dataSourceDNSZoneV2(),
-			"telefonicaopencloud_networking_network_v2":  dataSourceNetworkingNetworkV2(),
-			"telefonicaopencloud_networking_subnet_v2":   dataSourceNetworkingSubnetV2(),
-			"telefonicaopencloud_networking_secgroup_v2": dataSourceNetworkingSecGroupV2(),
-			"telefonicaopencloud_rds_flavors_v1":         dataSourceRdsFlavorV1(),
-			"telefonicaopencloud_s3_bucket_object":       dataSourceS3BucketObject(),
-			"telefonicaopencloud_vpc_v1":                 dataSourceVirtualPrivateCloudVpcV1(),
-			"telefonicaopencloud_vpc_subnet_v1":          dataSourceVpcSubnetV1(),
-			"telefonicaopencloud_vpc_subnet_ids_v1":      dataSourceVpcSubnetIdsV1(),
-			"telefonicaopencloud_rts_stack_v1":           dataSourceRTSStackV1(),
-			"telefonicaopencloud_rts_stack_resource_v1":  dataSourceRTSStackResourcesV1(),
-			"telefonicaopencloud_rts_software_config_v1": dataSourceRtsSoftwareConfigV1(),
-			"telefonicaopencloud_sfs_file_system_v2":     dataSourceSFSFileSystemV2(),
-			"telefonicaopencloud_csbs_backup_v1":         dataSourceCSBSBackupV1(),
-			"telefonicaopencloud_csbs_backup_policy_v1":  dataSourceCSBSBackupPolicyV1(),
-			"telefonicaopencloud_vbs_backup_v2":          dataSourceVBSBackupV2(),
-			"telefonicaopencloud_vbs_backup_policy_v2":   dataSourceVBSBackupPolicyV2(),
-			"telefonicaopencloud_cts_tracker_v1":         dataSourceCTSTrackerV1(),
-			"telefonicaopencloud_dcs_az_v1":              dataSourceDcsAZV1(),
-			"telefonicaopencloud_dcs_maintainwindow_v1":  dataSourceDcsMaintainWindowV1(),
-			"telefonicaopencloud_dcs_product_v1":         dataSourceDcsProductV1(),
+			"telefonicaopencloud_blockstorage_snapshot_v2": dataSourceBlockStorageSnapshotV2(),
+			"telefonicaopencloud_blockstorage_volume_v2":   dataSourceBlockStorageVolumeV2(),
+			"telefonicaopencloud_compute_flavor_v2":        dataSourceComputeFlavorV2(),
+			"telefonicaopencloud_compute_instance_v2":      dataSourceComputeInstanceV2(),
+			"telefonicaopencloud_compute_instances_v2":     dataSourceComputeInstancesV2(),
+			"telefonicaopencloud_dns_zone_v2":              dataSourceDNSZoneV2(),
+			"telefonicaopencloud_images_image_v2":          dataSourceImagesImageV2(),
+			"telefonicaopencloud_networking_network_v2":    dataSourceNetworkingNetworkV2(),
+			"telefonicaopencloud_networking_subnet_v2":     dataSourceNetworkingSubnetV2(),
+			"telefonicaopencloud_networking_secgroup_v2":   dataSourceNetworkingSecGroupV2(),
+			"telefonicaopencloud_rds_flavors_v1":           dataSourceRdsFlavorV1(),
+			"telefonicaopencloud_s3_bucket_object":         dataSourceS3BucketObject(),
+			"telefonicaopencloud_vpc_v1":                   dataSourceVirtualPrivateCloudVpcV1(),
+			"telefonicaopencloud_vpc_subnet_v1":            dataSourceVpcSubnetV1(),
+			"telefonicaopencloud_vpc_subnet_ids_v1":        dataSourceVpcSubnetIdsV1(),
+			"telefonicaopencloud_vpc_route_v2":             dataSourceVPCRouteV2(),
+			"telefonicaopencloud_rts_stack_v1":             dataSourceRTSStackV1(),
+			"telefonicaopencloud_rts_stack_resource_v1":    dataSourceRTSStackResourcesV1(),
+			"telefonicaopencloud_rts_software_config_v1":   dataSourceRtsSoftwareConfigV1(),
+			"telefonicaopencloud_sfs_file_system_v2":       dataSourceSFSFileSystemV2(),
+			"telefonicaopencloud_csbs_backup_v1":           dataSourceCSBSBackupV1(),
+			"telefonicaopencloud_csbs_backup_policy_v1":    dataSourceCSBSBackupPolicyV1(),
+			"telefonicaopencloud_vbs_backup_v2":            dataSourceVBSBackupV2(),
+			"telefonicaopencloud_vbs_backup_policy_v2":     dataSourceVBSBackupPolicyV2(),
+			"telefonicaopencloud_cts_tracker_v1":           dataSourceCTSTrackerV1(),
+			"telefonicaopencloud_dcs_az_v1":                dataSourceDcsAZV1(),
+			"telefonicaopencloud_dcs_maintainwindow_v1":    dataSourceDcsMaintainWindowV1(),
+			"telefonicaopencloud_dcs_product_v1":           dataSourceDcsProductV1(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
+			"telefonicaopencloud_blockstorage_snapshot_v2":           resourceBlockStorageSnapshotV2(),
 			"telefonicaopencloud_blockstorage_volume_v2":             resourceBlockStorageVolumeV2(),
 			"telefonicaopencloud_compute_bms_server_v2":              resourceComputeBMSInstanceV2(),
 			"telefonicaopencloud_compute_instance_v2":                resourceComputeInstanceV2(),
@@ -202,8 +268,11 @@ func Provider() terraform.ResourceProvider {
 			"telefonicaopencloud_compute_servergroup_v2":             resourceComputeServerGroupV2(),
 			"telefonicaopencloud_compute_floatingip_v2":              resourceComputeFloatingIPV2(),
 			"telefonicaopencloud_compute_floatingip_associate_v2":    resourceComputeFloatingIPAssociateV2(),
+			"telefonicaopencloud_compute_interface_attach_v2":        resourceComputeInterfaceAttachV2(),
 			"telefonicaopencloud_compute_volume_attach_v2":           resourceComputeVolumeAttachV2(),
+			"telefonicaopencloud_dns_ptrrecord_v2":                   resourceDNSPtrRecordV2(),
 			"telefonicaopencloud_dns_recordset_v2":                   resourceDNSRecordSetV2(),
+			"telefonicaopencloud_dns_zone_records_v2":                resourceDNSZoneRecordsV2(),
 			"telefonicaopencloud_dns_zone_v2":                        resourceDNSZoneV2(),
 			"telefonicaopencloud_dcs_instance_v1":                    resourceDcsInstanceV1(),
 			"telefonicaopencloud_elb_loadbalancer":                   resourceELBLoadBalancer(),
@@ -213,6 +282,11 @@ func Provider() terraform.ResourceProvider {
 			"telefonicaopencloud_fw_firewall_group_v2":               resourceFWFirewallGroupV2(),
 			"telefonicaopencloud_fw_policy_v2":                       resourceFWPolicyV2(),
 			"telefonicaopencloud_fw_rule_v2":                         resourceFWRuleV2(),
+			"telefonicaopencloud_lb_loadbalancer_v2":                 resourceLoadBalancerV2(),
+			"telefonicaopencloud_lb_listener_v2":                     resourceListenerV2(),
+			"telefonicaopencloud_lb_pool_v2":                         resourcePoolV2(),
+			"telefonicaopencloud_lb_member_v2":                       resourceMemberV2(),
+			"telefonicaopencloud_lb_monitor_v2":                      resourceMonitorV2(),
 			"telefonicaopencloud_networking_network_v2":              resourceNetworkingNetworkV2(),
 			"telefonicaopencloud_networking_subnet_v2":               resourceNetworkingSubnetV2(),
 			"telefonicaopencloud_networking_floatingip_v2":           resourceNetworkingFloatingIPV2(),
@@ -225,6 +299,7 @@ func Provider() terraform.ResourceProvider {
 			"telefonicaopencloud_as_group_v1":                        resourceASGroup(),
 			"telefonicaopencloud_as_configuration_v1":                resourceASConfiguration(),
 			"telefonicaopencloud_as_policy_v1":                       resourceASPolicy(),
+			"telefonicaopencloud_as_instance_attach_v1":              resourceASInstanceAttach(),
 			"telefonicaopencloud_vpc_eip_v1":                         resourceVpcEIPV1(),
 			"telefonicaopencloud_ces_alarmrule":                      resourceAlarmRule(),
 			"telefonicaopencloud_smn_topic_v2":                       resourceTopic(),
@@ -251,6 +326,9 @@ func Provider() terraform.ResourceProvider {
 			"telefonicaopencloud_antiddos_v1":                        resourceAntiDdosV1(),
 			"telefonicaopencloud_vpc_peering_connection_v2":          resourceVpcPeeringConnectionV2(),
 			"telefonicaopencloud_vpc_peering_connection_accepter_v2": resourceVpcPeeringConnectionAccepterV2(),
+			"telefonicaopencloud_vpc_route_v2":                       resourceVPCRouteV2(),
+			"telefonicaopencloud_vpc_bandwidth_v2":                   resourceVpcBandWidthV2(),
+			"telefonicaopencloud_vpc_bandwidth_associate_v2":         resourceVpcBandWidthAssociateV2(),
 		},
 
 		ConfigureFunc: configureProvider,
@@ -300,6 +378,17 @@ func init() {
 			"service (Octavia) instead of the Networking service (Neutron).",
 
 		"cloud": "An entry in a `clouds.yaml` file to use.",
+
+		"default_tags": "Tags to add to every resource that supports tags. Tags\n" +
+			"set on a resource take precedence.",
+
+		"max_retries": "How many times a request is retried after throttling or a\n" +
+			"transient error. Set to 0 to disable retries.",
+
+		"max_backoff": "The longest time in seconds to wait between two retries of a request.",
+
+		"rate_limits": "Client-side limits of the requests per second, and of the\n" +
+			"requests in flight, sent to a service.",
 	}
 }
 
@@ -316,6 +405,8 @@ func configureProvider(d *schema.ResourceData) (interface{}, error) {
 		EndpointType:     d.Get("endpoint_type").(string),
 		IdentityEndpoint: d.Get("auth_url").(string),
 		Insecure:         d.Get("insecure").(bool),
+		MaxBackoff:       time.Duration(d.Get("max_backoff").(int)) * time.Second,
+		MaxRetries:       d.Get("max_retries").(int),
 		Password:         d.Get("password").(string),
 		Region:           d.Get("region").(string),
 		Swauth:           d.Get("swauth").(bool),
@@ -327,6 +418,31 @@ func configureProvider(d *schema.ResourceData) (interface{}, error) {
 		useOctavia:       d.Get("use_octavia").(bool),
 	}
 
+	defaultTags := d.Get("default_tags").(map[string]interface{})
+	if len(defaultTags) > 0 {
+		config.DefaultTags = make(map[string]string, len(defaultTags))
+		for k, v := range defaultTags {
+			config.DefaultTags[k] = v.(string)
+		}
+	}
+
+	rateLimits := d.Get("rate_limits").(*schema.Set).List()
+	if len(rateLimits) > 0 {
+		config.RateLimits = make(map[string]RateLimit, len(rateLimits))
+		for _, v := range rateLimits {
+			limit := v.(map[string]interface{})
+			service := limit["service"].(string)
+			if _, ok := config.RateLimits[service]; ok {
+				return nil, fmt.Errorf("Duplicate rate_limits for service %q", service)
+			}
+			config.RateLimits[service] = RateLimit{
+				RequestsPerSecond: limit["requests_per_second"].(float64),
+				Burst:             limit["burst"].(int),
+				Concurrency:       limit["concurrency"].(int),
+			}
+		}
+	}
+
 	if err := config.LoadAndValidate(); err != nil {
 		return nil, err
 	}
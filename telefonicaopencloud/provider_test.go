@@ -13,28 +13,28 @@ import (
 )
 
 var (
-	OS_DB_ENVIRONMENT         = os.Getenv("OS_DB_ENVIRONMENT")
-	OS_DB_DATASTORE_VERSION   = os.Getenv("OS_DB_DATASTORE_VERSION")
-	OS_DB_DATASTORE_TYPE      = os.Getenv("OS_DB_DATASTORE_TYPE")
-	OS_DEPRECATED_ENVIRONMENT = os.Getenv("OS_DEPRECATED_ENVIRONMENT")
-	OS_DNS_ENVIRONMENT        = os.Getenv("OS_DNS_ENVIRONMENT")
-	OS_EXTGW_ID               = os.Getenv("OS_EXTGW_ID")
-	OS_FLAVOR_ID              = os.Getenv("OS_FLAVOR_ID")
-	OS_FLAVOR_NAME            = os.Getenv("OS_FLAVOR_NAME")
-	OS_IMAGE_ID               = os.Getenv("OS_IMAGE_ID")
-	OS_IMAGE_NAME             = os.Getenv("OS_IMAGE_NAME")
-	OS_NETWORK_ID             = os.Getenv("OS_NETWORK_ID")
-	OS_VPC_ID                 = os.Getenv("OS_VPC_ID")
-	OS_POOL_NAME              = os.Getenv("OS_POOL_NAME")
-	OS_REGION_NAME            = os.Getenv("OS_REGION_NAME")
-	OS_SWIFT_ENVIRONMENT      = os.Getenv("OS_SWIFT_ENVIRONMENT")
-	OS_AVAILABILITY_ZONE      = os.Getenv("OS_AVAILABILITY_ZONE")
-	OS_ACCESS_KEY             = os.Getenv("OS_ACCESS_KEY")
-	OS_SECRET_KEY             = os.Getenv("OS_SECRET_KEY")
-	OS_SRC_ACCESS_KEY         = os.Getenv("OS_SRC_ACCESS_KEY")
-	OS_SRC_SECRET_KEY         = os.Getenv("OS_SRC_SECRET_KEY")
-	OS_TENANT_ID              = os.Getenv("OS_TENANT_ID")
-	OS_BMS_FLAVOR_NAME        = os.Getenv("OS_BMS_FLAVOR_NAME")
+	OS_DB_ENVIRONMENT         = testAccEnv("OS_DB_ENVIRONMENT")
+	OS_DB_DATASTORE_VERSION   = testAccEnv("OS_DB_DATASTORE_VERSION")
+	OS_DB_DATASTORE_TYPE      = testAccEnv("OS_DB_DATASTORE_TYPE")
+	OS_DEPRECATED_ENVIRONMENT = testAccEnv("OS_DEPRECATED_ENVIRONMENT")
+	OS_DNS_ENVIRONMENT        = testAccEnv("OS_DNS_ENVIRONMENT")
+	OS_EXTGW_ID               = testAccEnv("OS_EXTGW_ID")
+	OS_FLAVOR_ID              = testAccEnv("OS_FLAVOR_ID")
+	OS_FLAVOR_NAME            = testAccEnv("OS_FLAVOR_NAME")
+	OS_IMAGE_ID               = testAccEnv("OS_IMAGE_ID")
+	OS_IMAGE_NAME             = testAccEnv("OS_IMAGE_NAME")
+	OS_NETWORK_ID             = testAccEnv("OS_NETWORK_ID")
+	OS_VPC_ID                 = testAccEnv("OS_VPC_ID")
+	OS_POOL_NAME              = testAccEnv("OS_POOL_NAME")
+	OS_REGION_NAME            = testAccEnv("OS_REGION_NAME")
+	OS_SWIFT_ENVIRONMENT      = testAccEnv("OS_SWIFT_ENVIRONMENT")
+	OS_AVAILABILITY_ZONE      = testAccEnv("OS_AVAILABILITY_ZONE")
+	OS_ACCESS_KEY             = testAccEnv("OS_ACCESS_KEY")
+	OS_SECRET_KEY             = testAccEnv("OS_SECRET_KEY")
+	OS_SRC_ACCESS_KEY         = testAccEnv("OS_SRC_ACCESS_KEY")
+	OS_SRC_SECRET_KEY         = testAccEnv("OS_SRC_SECRET_KEY")
+	OS_TENANT_ID              = testAccEnv("OS_TENANT_ID")
+	OS_BMS_FLAVOR_NAME        = testAccEnv("OS_BMS_FLAVOR_NAME")
 )
 
 var testAccProviders map[string]terraform.ResourceProvider
@@ -48,6 +48,10 @@ func init() {
 }
 
 func testAccPreCheckRequiredEnvVars(t *testing.T) {
+	if testAccFakeAPI {
+		testAccPreCheckFakeAPI(t)
+	}
+
 	v := os.Getenv("OS_AUTH_URL")
 	if v == "" {
 		t.Fatal("OS_AUTH_URL must be set for acceptance tests")
@@ -103,7 +107,7 @@ func testAccPreCheckDeprecated(t *testing.T) {
 func testAccPreCheckDNS(t *testing.T) {
 	testAccPreCheckRequiredEnvVars(t)
 
-	if OS_DNS_ENVIRONMENT == "" {
+	if OS_DNS_ENVIRONMENT == "" && !testAccFakeAPI {
 		t.Skip("This environment does not support DNS tests")
 	}
 }
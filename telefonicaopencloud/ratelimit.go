@@ -0,0 +1,187 @@
+package telefonicaopencloud
+
+import (
+	"context"
+	"log"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// rateLimitServices are the services that can be given a rate limit. Each
+// one groups the service clients built by Config which talk to the same API.
+var rateLimitServices = []string{
+	"antiddos", "autoscaling", "blockstorage", "ces", "compute", "csbs", "cts",
+	"dcs", "dms", "dns", "elb", "identity", "image", "maas", "mrs", "network",
+	"objectstorage", "orchestration", "rds", "sfs", "smn", "vbs",
+}
+
+// RateLimit is the client-side limit of the requests sent to a service.
+type RateLimit struct {
+	// RequestsPerSecond is the rate at which requests may be sent.
+	RequestsPerSecond float64
+
+	// Burst is how many requests may be sent at once after the service was
+	// idle. It defaults to RequestsPerSecond, rounded up.
+	Burst int
+
+	// Concurrency caps the number of requests in flight. Zero means no cap.
+	Concurrency int
+}
+
+// serviceLimiter is a token bucket holding up to burst tokens which are
+// refilled at rate tokens per second. Every request takes one token.
+type serviceLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+
+	slots chan struct{}
+}
+
+func newServiceLimiter(limit RateLimit) *serviceLimiter {
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = math.Max(1, math.Ceil(limit.RequestsPerSecond))
+	}
+
+	l := &serviceLimiter{
+		rate:   limit.RequestsPerSecond,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+	if limit.Concurrency > 0 {
+		l.slots = make(chan struct{}, limit.Concurrency)
+	}
+	return l
+}
+
+// reserve takes a token and returns how long to wait before it may be used.
+// The token is taken in advance, so callers waiting concurrently are served
+// in order.
+func (l *serviceLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+
+	l.tokens--
+	if l.tokens >= 0 || l.rate <= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// wait blocks until a request may be sent, and until one of the concurrency
+// slots is free if there is a cap. release must be called once the request
+// is done.
+func (l *serviceLimiter) wait(ctx context.Context) error {
+	if delay := l.reserve(); delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func (l *serviceLimiter) release() {
+	if l.slots != nil {
+		<-l.slots
+	}
+}
+
+// RateLimitRoundTripper satisfies the http.RoundTripper interface and limits
+// the rate of requests sent to each service. Requests are matched to a
+// service by the endpoints registered for it when its service clients are
+// built. Requests to other endpoints are not limited.
+type RateLimitRoundTripper struct {
+	Rt http.RoundTripper
+
+	mu        sync.RWMutex
+	limiters  map[string]*serviceLimiter
+	endpoints map[string]string
+}
+
+// NewRateLimitRoundTripper returns a RateLimitRoundTripper applying the
+// given limits, keyed by service.
+func NewRateLimitRoundTripper(rt http.RoundTripper, limits map[string]RateLimit) *RateLimitRoundTripper {
+	limiters := make(map[string]*serviceLimiter, len(limits))
+	for service, limit := range limits {
+		limiters[service] = newServiceLimiter(limit)
+	}
+
+	return &RateLimitRoundTripper{
+		Rt:        rt,
+		limiters:  limiters,
+		endpoints: make(map[string]string),
+	}
+}
+
+// register routes the requests sent to an endpoint through the limiter of a
+// service.
+func (rlrt *RateLimitRoundTripper) register(service, endpoint string) {
+	if _, ok := rlrt.limiters[service]; !ok || endpoint == "" {
+		return
+	}
+
+	rlrt.mu.Lock()
+	defer rlrt.mu.Unlock()
+
+	if s, ok := rlrt.endpoints[endpoint]; ok && s != service {
+		log.Printf("[WARN] Endpoint %s is used by both %s and %s, applying the rate limit of %s",
+			endpoint, s, service, s)
+		return
+	}
+	rlrt.endpoints[endpoint] = service
+}
+
+// limiter returns the limiter of the service with the longest endpoint
+// matching a URL, or nil if no service matches.
+func (rlrt *RateLimitRoundTripper) limiter(url string) *serviceLimiter {
+	rlrt.mu.RLock()
+	defer rlrt.mu.RUnlock()
+
+	var match string
+	for endpoint := range rlrt.endpoints {
+		if strings.HasPrefix(url, endpoint) && len(endpoint) > len(match) {
+			match = endpoint
+		}
+	}
+	if match == "" {
+		return nil
+	}
+	return rlrt.limiters[rlrt.endpoints[match]]
+}
+
+// RoundTrip waits until the service of the request allows another request,
+// then performs it.
+func (rlrt *RateLimitRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	l := rlrt.limiter(request.URL.String())
+	if l == nil {
+		return rlrt.Rt.RoundTrip(request)
+	}
+
+	if err := l.wait(request.Context()); err != nil {
+		return nil, err
+	}
+	defer l.release()
+
+	return rlrt.Rt.RoundTrip(request)
+}
@@ -0,0 +1,94 @@
+package telefonicaopencloud
+
+import (
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type countingRoundTripper struct {
+	inFlight    int32
+	maxInFlight int32
+	delay       time.Duration
+}
+
+func (c *countingRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	n := atomic.AddInt32(&c.inFlight, 1)
+	defer atomic.AddInt32(&c.inFlight, -1)
+	for {
+		max := atomic.LoadInt32(&c.maxInFlight)
+		if n <= max || atomic.CompareAndSwapInt32(&c.maxInFlight, max, n) {
+			break
+		}
+	}
+
+	time.Sleep(c.delay)
+	return &http.Response{StatusCode: 200, Header: http.Header{}}, nil
+}
+
+func TestRateLimitRoundTripper_limiter(t *testing.T) {
+	rlrt := NewRateLimitRoundTripper(nil, map[string]RateLimit{
+		"network": {RequestsPerSecond: 10},
+		"dns":     {RequestsPerSecond: 5},
+	})
+	rlrt.register("network", "https://vpc.example.com/")
+	rlrt.register("dns", "https://vpc.example.com/dns/")
+	rlrt.register("compute", "https://ecs.example.com/")
+
+	cases := map[string]*serviceLimiter{
+		"https://vpc.example.com/v2.0/security-group-rules": rlrt.limiters["network"],
+		"https://vpc.example.com/dns/v2/zones":              rlrt.limiters["dns"],
+		"https://ecs.example.com/v2/servers":                nil,
+		"https://evs.example.com/v2/volumes":                nil,
+	}
+	for url, expected := range cases {
+		if actual := rlrt.limiter(url); actual != expected {
+			t.Fatalf("Expected limiter %p for %s, got %p", expected, url, actual)
+		}
+	}
+}
+
+func TestRateLimitRoundTripper_rate(t *testing.T) {
+	rlrt := NewRateLimitRoundTripper(&countingRoundTripper{}, map[string]RateLimit{
+		"dns": {RequestsPerSecond: 50, Burst: 2},
+	})
+	rlrt.register("dns", "https://dns.example.com/")
+
+	start := time.Now()
+	for i := 0; i < 7; i++ {
+		request, _ := http.NewRequest("GET", "https://dns.example.com/v2/zones", nil)
+		if _, err := rlrt.RoundTrip(request); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	}
+
+	// The first 2 requests are served by the burst, the other 5 take 20ms each.
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Fatalf("Expected 7 requests at 50 per second with a burst of 2 to take at least 100ms, took %s", elapsed)
+	}
+}
+
+func TestRateLimitRoundTripper_concurrency(t *testing.T) {
+	rt := &countingRoundTripper{delay: 10 * time.Millisecond}
+	rlrt := NewRateLimitRoundTripper(rt, map[string]RateLimit{
+		"network": {RequestsPerSecond: 1000, Burst: 100, Concurrency: 2},
+	})
+	rlrt.register("network", "https://vpc.example.com/")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			request, _ := http.NewRequest("POST", "https://vpc.example.com/v2.0/ports", nil)
+			rlrt.RoundTrip(request)
+		}()
+	}
+	wg.Wait()
+
+	if rt.maxInFlight != 2 {
+		t.Fatalf("Expected at most 2 requests in flight, got %d", rt.maxInFlight)
+	}
+}
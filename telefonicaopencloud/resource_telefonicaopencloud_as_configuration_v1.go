@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"log"
 	"regexp"
+	"time"
 
+	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/huaweicloud/golangsdk"
 	"github.com/huaweicloud/golangsdk/openstack/autoscaling/v1/configurations"
@@ -19,6 +21,13 @@ func resourceASConfiguration() *schema.Resource {
 		Read:   resourceASConfigurationRead,
 		Update: nil,
 		Delete: resourceASConfigurationDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(1 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"region": {
@@ -28,9 +37,17 @@ func resourceASConfiguration() *schema.Resource {
 				ForceNew: true,
 			},
 			"scaling_configuration_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ValidateFunc:  resourceASConfigurationValidateName,
+				ForceNew:      true,
+				ConflictsWith: []string{"name_prefix"},
+			},
+			"name_prefix": {
 				Type:         schema.TypeString,
-				Required:     true,
-				ValidateFunc: resourceASConfigurationValidateName,
+				Optional:     true,
+				ValidateFunc: resourceASConfigurationValidateNamePrefix,
 				ForceNew:     true,
 			},
 			"instance_config": {
@@ -283,8 +300,18 @@ func resourceASConfigurationCreate(d *schema.ResourceData, meta interface{}) err
 	if err1 != nil {
 		return fmt.Errorf("Error when getting instance_config info: %s", err1)
 	}
+
+	var name string
+	if v, ok := d.GetOk("scaling_configuration_name"); ok {
+		name = v.(string)
+	} else if v, ok := d.GetOk("name_prefix"); ok {
+		name = resource.PrefixedUniqueId(v.(string))
+	} else {
+		name = resource.UniqueId()
+	}
+
 	createOpts := configurations.CreateOpts{
-		Name:           d.Get("scaling_configuration_name").(string),
+		Name:           name,
 		InstanceConfig: instanceConfig,
 	}
 
@@ -313,25 +340,104 @@ func resourceASConfigurationRead(d *schema.ResourceData, meta interface{}) error
 
 	log.Printf("[DEBUG] Retrieved ASConfiguration %q: %+v", d.Id(), asConfig)
 
+	d.Set("scaling_configuration_name", asConfig.Name)
+
+	// The instance configuration can't be changed, so it is only filled in
+	// on import. Values such as user_data and personality are returned
+	// encoded by the API and can't be compared with the configuration.
+	if len(d.Get("instance_config").([]interface{})) == 0 {
+		if err := d.Set("instance_config", flattenASInstanceConfig(asConfig.InstanceConfig)); err != nil {
+			return fmt.Errorf("Error saving instance_config to ASConfiguration %q: %s", d.Id(), err)
+		}
+	}
+
+	d.Set("region", GetRegion(d, config))
+
 	return nil
 }
 
+func flattenASInstanceConfig(instanceConfig configurations.InstanceConfig) []map[string]interface{} {
+	disks := make([]map[string]interface{}, len(instanceConfig.Disk))
+	for i, disk := range instanceConfig.Disk {
+		disks[i] = map[string]interface{}{
+			"size":        disk.Size,
+			"volume_type": disk.VolumeType,
+			"disk_type":   disk.DiskType,
+		}
+	}
+
+	config := map[string]interface{}{
+		"instance_id": instanceConfig.InstanceID,
+		"flavor":      instanceConfig.FlavorRef,
+		"image":       instanceConfig.ImageRef,
+		"key_name":    instanceConfig.SSHKey,
+		"disk":        disks,
+		"metadata":    instanceConfig.Metadata,
+	}
+
+	if eip := instanceConfig.PublicIp.Eip; eip.Type != "" {
+		config["public_ip"] = []map[string]interface{}{
+			{
+				"eip": []map[string]interface{}{
+					{
+						"ip_type": eip.Type,
+						"bandwidth": []map[string]interface{}{
+							{
+								"size":          eip.Bandwidth.Size,
+								"share_type":    eip.Bandwidth.ShareType,
+								"charging_mode": eip.Bandwidth.ChargingMode,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	return []map[string]interface{}{config}
+}
+
 func resourceASConfigurationDelete(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 	asClient, err := config.autoscalingV1Client(GetRegion(d, config))
 	if err != nil {
 		return fmt.Errorf("Error creating TelefonicaOpenCloud autoscaling client: %s", err)
 	}
-	groups, err1 := getASGroupsByConfiguration(asClient, d.Id())
-	if err1 != nil {
-		return fmt.Errorf("Error getting AS groups by configuration ID %q: %s", d.Id(), err1)
-	}
-	if len(groups) > 0 {
-		var groupIds []string
-		for _, group := range groups {
-			groupIds = append(groupIds, group.ID)
+	// With create_before_destroy the groups are switched to the replacing
+	// configuration before this one is deleted, but the AS service may still
+	// list them as using it for a short while. Only that window is waited
+	// for: a group which really uses the configuration fails the deletion
+	// right away.
+	err = resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		usedGroups, err := getASGroupsByConfiguration(asClient, d.Id())
+		if err != nil {
+			return resource.NonRetryableError(fmt.Errorf("Error getting AS groups by configuration ID %q: %s", d.Id(), err))
+		}
+		if len(usedGroups) == 0 {
+			return nil
 		}
-		return fmt.Errorf("Can not delete the configuration %q, it is used by AS groups %s.", d.Id(), groupIds)
+
+		var groupNames []string
+		released := true
+		for _, group := range usedGroups {
+			groupNames = append(groupNames, group.Name)
+			g, err := groups.Get(asClient, group.ID).Extract()
+			if err != nil {
+				return resource.NonRetryableError(fmt.Errorf("Error getting AS group %q: %s", group.Name, err))
+			}
+			if g.ConfigurationID == d.Id() {
+				released = false
+			}
+		}
+		err = fmt.Errorf("Can not delete the configuration %q, it is used by AS groups %v. "+
+			"Use create_before_destroy to switch the groups to a new configuration first.", d.Id(), groupNames)
+		if !released {
+			return resource.NonRetryableError(err)
+		}
+		return resource.RetryableError(err)
+	})
+	if err != nil {
+		return err
 	}
 	log.Printf("[DEBUG] Begin to delete AS configuration %q", d.Id())
 	if delErr := configurations.Delete(asClient, d.Id()).ExtractErr(); delErr != nil {
@@ -428,6 +534,18 @@ func resourceASConfigurationValidateVolumeType(v interface{}, k string) (ws []st
 	return
 }
 
+func resourceASConfigurationValidateNamePrefix(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	// resource.PrefixedUniqueId appends 26 characters to the prefix.
+	if len(value) > 38 {
+		errors = append(errors, fmt.Errorf("%q cannot be longer than 38 characters", k))
+	}
+	if !regexp.MustCompile(`^[0-9a-zA-Z-_]*$`).MatchString(value) {
+		errors = append(errors, fmt.Errorf("only alphanumeric characters, hyphens, and underscores allowed in %q", k))
+	}
+	return
+}
+
 func resourceASConfigurationValidateName(v interface{}, k string) (ws []string, errors []error) {
 	value := v.(string)
 	if len(value) > 64 || len(value) < 1 {
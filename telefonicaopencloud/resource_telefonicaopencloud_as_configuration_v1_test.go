@@ -3,6 +3,7 @@ package telefonicaopencloud
 import (
 	"fmt"
 	"log"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
@@ -29,6 +30,26 @@ func TestAccASV1Configuration_basic(t *testing.T) {
 	})
 }
 
+func TestAccASV1Configuration_namePrefix(t *testing.T) {
+	var asConfig configurations.Configuration
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccAsConfigPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckASV1ConfigurationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccASV1Configuration_namePrefix,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckASV1ConfigurationExists("telefonicaopencloud_as_configuration_v1.hth_as_config", &asConfig),
+					resource.TestMatchResourceAttr(
+						"telefonicaopencloud_as_configuration_v1.hth_as_config", "scaling_configuration_name", regexp.MustCompile("^hth_as_config_")),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckASV1ConfigurationDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*Config)
 	asClient, err := config.autoscalingV1Client(OS_REGION_NAME)
@@ -103,3 +124,27 @@ resource "telefonicaopencloud_as_configuration_v1" "hth_as_config"{
   }
 }
 `, OS_IMAGE_ID)
+
+var testAccASV1Configuration_namePrefix = fmt.Sprintf(`
+resource "telefonicaopencloud_compute_keypair_v2" "hth_key" {
+  name = "hth_key"
+  public_key = "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQDAjpC1hwiOCCmKEWxJ4qzTTsJbKzndLo1BCz5PcwtUnflmU+gHJtWMZKpuEGVi29h0A/+ydKek1O18k10Ff+4tyFjiHDQAT9+OfgWf7+b1yK+qDip3X1C0UPMbwHlTfSGWLGZquwhvEFx9k3h/M+VtMvwR1lJ9LUyTAImnNjWG7TAIPmui30HvM2UiFEmqkr4ijq45MyX2+fLIePLRIFuu1p4whjHAQYufqyno3BS48icQb4p6iVEZPo4AE2o9oIyQvj2mx4dk5Y8CgSETOZTYDOR3rU2fZTRDRgPJDH9FWvQjF5tA0p3d9CoWWd2s6GKKbfoUIi8R/Db1BSPJwkqB jrp-hp-pc"
+}
+
+resource "telefonicaopencloud_as_configuration_v1" "hth_as_config"{
+  name_prefix = "hth_as_config_"
+  instance_config = {
+    image = "%s"
+    disk = [
+      {size = 40
+      volume_type = "SATA"
+      disk_type = "SYS"}
+    ]
+    key_name = "${telefonicaopencloud_compute_keypair_v2.hth_key.id}"
+  }
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+`, OS_IMAGE_ID)
@@ -21,6 +21,9 @@ func resourceASGroup() *schema.Resource {
 		Read:   resourceASGroupRead,
 		Update: resourceASGroupUpdate,
 		Delete: resourceASGroupDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceASGroupImport,
+		},
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
@@ -50,6 +53,7 @@ func resourceASGroup() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 				ForceNew: false,
+				Computed: true,
 			},
 			"min_instance_number": {
 				Type:     schema.TypeInt,
@@ -81,6 +85,7 @@ func resourceASGroup() *schema.Resource {
 			"available_zones": {
 				Type:     schema.TypeList,
 				Optional: true,
+				Computed: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 				ForceNew: false,
 			},
@@ -160,10 +165,21 @@ func resourceASGroup() *schema.Resource {
 			"instances": {
 				Type:        schema.TypeList,
 				Optional:    true,
+				Computed:    true,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				ForceNew:    false,
 				Description: "The instances id list in the as group.",
 			},
+			"enable": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the AS group is enabled (INSERVICE) or disabled (PAUSED).",
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -409,6 +425,11 @@ func resourceASGroupCreate(d *schema.ResourceData, meta interface{}) error {
 
 	d.SetId(asgId)
 
+	if !d.Get("enable").(bool) {
+		log.Printf("[DEBUG] ASGroup %q is left disabled", asgId)
+		return resourceASGroupRead(d, meta)
+	}
+
 	//enable asg
 	enableResult := groups.Enable(asClient, asgId)
 	if enableResult.Err != nil {
@@ -459,6 +480,29 @@ func resourceASGroupRead(d *schema.ResourceData, meta interface{}) error {
 	if len(asg.Notifications) >= 1 {
 		d.Set("notifications", asg.Notifications)
 	}
+	d.Set("available_zones", asg.AvailableZones)
+	d.Set("status", asg.Status)
+	d.Set("enable", asg.Status == "INSERVICE")
+
+	networks := make([]map[string]interface{}, len(asg.Networks))
+	for i, network := range asg.Networks {
+		networks[i] = map[string]interface{}{
+			"id": network.ID,
+		}
+	}
+	if err := d.Set("networks", networks); err != nil {
+		return fmt.Errorf("[DEBUG] Error saving networks to ASGroup %q: %s", d.Id(), err)
+	}
+
+	secGroups := make([]map[string]interface{}, len(asg.SecurityGroups))
+	for i, secGroup := range asg.SecurityGroups {
+		secGroups[i] = map[string]interface{}{
+			"id": secGroup.ID,
+		}
+	}
+	if err := d.Set("security_groups", secGroups); err != nil {
+		return fmt.Errorf("[DEBUG] Error saving security_groups to ASGroup %q: %s", d.Id(), err)
+	}
 
 	var opts instances.ListOptsBuilder
 	allIns, err := getInstancesInGroup(asClient, d.Id(), opts)
@@ -473,6 +517,25 @@ func resourceASGroupRead(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
+// resourceASGroupImport imports an AS group by its ID. Whether its instances
+// are deleted when removed from the group is not returned by the API, so
+// delete_instances is set to its default.
+func resourceASGroupImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.Set("delete_instances", "no")
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// asGroupV1UpdateOpts holds the changed attributes of an AS group. Unlike
+// groups.UpdateOpts, whose fields are all omitempty, it keeps the attributes
+// changed to an empty value, so that e.g. the listener can be removed or the
+// minimum number of instances set to 0.
+type asGroupV1UpdateOpts map[string]interface{}
+
+func (opts asGroupV1UpdateOpts) ToGroupUpdateMap() (map[string]interface{}, error) {
+	return opts, nil
+}
+
 func resourceASGroupUpdate(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 	asClient, err := config.autoscalingV1Client(GetRegion(d, config))
@@ -480,6 +543,11 @@ func resourceASGroupUpdate(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("Error creating TelefonicaOpenCloud autoscaling client: %s", err)
 	}
 	d.Partial(true)
+
+	// Only the changed attributes are sent, since the AS service refuses
+	// changes to e.g. the networks while the group has instances even when
+	// the networks sent are unchanged.
+	updateOpts := asGroupV1UpdateOpts{}
 	if d.HasChange("min_instance_number") || d.HasChange("max_instance_number") || d.HasChange("desire_instance_number") {
 		minNum := d.Get("min_instance_number").(int)
 		maxNum := d.Get("max_instance_number").(int)
@@ -490,35 +558,76 @@ func resourceASGroupUpdate(d *schema.ResourceData, meta interface{}) error {
 		if desireNum < minNum || desireNum > maxNum {
 			return fmt.Errorf("Invalid parameters: it should be min_instance_number<=desire_instance_number<=max_instance_number")
 		}
-
+		updateOpts["min_instance_number"] = minNum
+		updateOpts["max_instance_number"] = maxNum
+		// The desired number also changes when instances are attached to
+		// the group, so it's only sent when it is changed here.
+		if d.HasChange("desire_instance_number") {
+			updateOpts["desire_instance_number"] = desireNum
+		}
+	}
+	if d.HasChange("scaling_group_name") {
+		updateOpts["scaling_group_name"] = d.Get("scaling_group_name").(string)
+	}
+	if d.HasChange("scaling_configuration_id") {
+		updateOpts["scaling_configuration_id"] = d.Get("scaling_configuration_id").(string)
+	}
+	if d.HasChange("cool_down_time") {
+		updateOpts["cool_down_time"] = d.Get("cool_down_time").(int)
+	}
+	if d.HasChange("lb_listener_id") {
+		updateOpts["lb_listener_id"] = d.Get("lb_listener_id").(string)
+	}
+	if d.HasChange("available_zones") {
+		updateOpts["available_zones"] = getAllAvailableZones(d)
+	}
+	if d.HasChange("networks") {
+		networks := getAllNetworks(d, meta)
+		updateOpts["networks"] = expandNetworks(networks)
+	}
+	if d.HasChange("security_groups") {
+		secGroups := getAllSecurityGroups(d, meta)
+		updateOpts["security_groups"] = expandGroups(secGroups)
+	}
+	if d.HasChange("health_periodic_audit_method") {
+		updateOpts["health_periodic_audit_method"] = d.Get("health_periodic_audit_method").(string)
+	}
+	if d.HasChange("health_periodic_audit_time") {
+		updateOpts["health_periodic_audit_time"] = d.Get("health_periodic_audit_time").(int)
+	}
+	if d.HasChange("instance_terminate_policy") {
+		updateOpts["instance_terminate_policy"] = d.Get("instance_terminate_policy").(string)
+	}
+	if d.HasChange("notifications") {
+		updateOpts["notifications"] = getAllNotifications(d)
+	}
+	if d.HasChange("delete_publicip") {
+		updateOpts["delete_publicip"] = d.Get("delete_publicip").(bool)
 	}
 
-	networks := getAllNetworks(d, meta)
-	asgNetworks := expandNetworks(networks)
-
-	secGroups := getAllSecurityGroups(d, meta)
-	asgSecGroups := expandGroups(secGroups)
-	updateOpts := groups.UpdateOpts{
-		Name:                      d.Get("scaling_group_name").(string),
-		ConfigurationID:           d.Get("scaling_configuration_id").(string),
-		DesireInstanceNumber:      d.Get("desire_instance_number").(int),
-		MinInstanceNumber:         d.Get("min_instance_number").(int),
-		MaxInstanceNumber:         d.Get("max_instance_number").(int),
-		CoolDownTime:              d.Get("cool_down_time").(int),
-		LBListenerID:              d.Get("lb_listener_id").(string),
-		AvailableZones:            getAllAvailableZones(d),
-		Networks:                  asgNetworks,
-		SecurityGroup:             asgSecGroups,
-		HealthPeriodicAuditMethod: d.Get("health_periodic_audit_method").(string),
-		HealthPeriodicAuditTime:   d.Get("health_periodic_audit_time").(int),
-		InstanceTerminatePolicy:   d.Get("instance_terminate_policy").(string),
-		Notifications:             getAllNotifications(d),
-		IsDeletePublicip:          d.Get("delete_publicip").(bool),
+	if len(updateOpts) > 0 {
+		log.Printf("[DEBUG] Update Options: %#v", updateOpts)
+		_, err := groups.Update(asClient, d.Id(), updateOpts).Extract()
+		if err != nil {
+			return fmt.Errorf("Error updating ASGroup %q: %s", d.Id(), err)
+		}
 	}
-	asgID, err := groups.Update(asClient, d.Id(), updateOpts).Extract()
-	if err != nil {
-		return fmt.Errorf("Error updating ASGroup %q: %s", asgID, err)
+
+	if d.HasChange("enable") {
+		if d.Get("enable").(bool) {
+			if err := groups.Enable(asClient, d.Id()).Err; err != nil {
+				return fmt.Errorf("Error enabling ASGroup %q: %s", d.Id(), err)
+			}
+			log.Printf("[DEBUG] Enable ASGroup %q success!", d.Id())
+		} else {
+			if err := groups.Disable(asClient, d.Id()).Err; err != nil {
+				return fmt.Errorf("Error disabling ASGroup %q: %s", d.Id(), err)
+			}
+			log.Printf("[DEBUG] Disable ASGroup %q success!", d.Id())
+		}
+		d.SetPartial("enable")
 	}
+
 	d.Partial(false)
 	return resourceASGroupRead(d, meta)
 }
@@ -29,6 +29,62 @@ func TestAccASV1Group_basic(t *testing.T) {
 	})
 }
 
+func TestAccASV1Group_update(t *testing.T) {
+	var asGroup groups.Group
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccAsConfigPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckASV1GroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testASV1Group_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckASV1GroupExists("telefonicaopencloud_as_group_v1.hth_as_group", &asGroup),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_as_group_v1.hth_as_group", "enable", "true"),
+				),
+			},
+			{
+				Config: testASV1Group_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckASV1GroupExists("telefonicaopencloud_as_group_v1.hth_as_group", &asGroup),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_as_group_v1.hth_as_group", "enable", "false"),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_as_group_v1.hth_as_group", "status", "PAUSED"),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_as_group_v1.hth_as_group", "health_periodic_audit_time", "15"),
+				),
+			},
+			{
+				Config: testASV1Group_instanceNumbers(1, 1, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckASV1GroupExists("telefonicaopencloud_as_group_v1.hth_as_group", &asGroup),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_as_group_v1.hth_as_group", "min_instance_number", "1"),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_as_group_v1.hth_as_group", "desire_instance_number", "1"),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_as_group_v1.hth_as_group", "delete_publicip", "true"),
+				),
+			},
+			{
+				Config: testASV1Group_instanceNumbers(0, 0, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckASV1GroupExists("telefonicaopencloud_as_group_v1.hth_as_group", &asGroup),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_as_group_v1.hth_as_group", "min_instance_number", "0"),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_as_group_v1.hth_as_group", "desire_instance_number", "0"),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_as_group_v1.hth_as_group", "delete_publicip", "false"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckASV1GroupDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*Config)
 	asClient, err := config.autoscalingV1Client(OS_REGION_NAME)
@@ -120,3 +176,88 @@ resource "telefonicaopencloud_as_group_v1" "hth_as_group"{
   vpc_id = "%s"
 }
 `, OS_IMAGE_ID, OS_NETWORK_ID, OS_VPC_ID)
+
+var testASV1Group_update = fmt.Sprintf(`
+resource "telefonicaopencloud_networking_secgroup_v2" "secgroup" {
+  name        = "terraform"
+  description = "This is a terraform test security group"
+}
+
+resource "telefonicaopencloud_compute_keypair_v2" "hth_key" {
+  name = "hth_key"
+  public_key = "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQDAjpC1hwiOCCmKEWxJ4qzTTsJbKzndLo1BCz5PcwtUnflmU+gHJtWMZKpuEGVi29h0A/+ydKek1O18k10Ff+4tyFjiHDQAT9+OfgWf7+b1yK+qDip3X1C0UPMbwHlTfSGWLGZquwhvEFx9k3h/M+VtMvwR1lJ9LUyTAImnNjWG7TAIPmui30HvM2UiFEmqkr4ijq45MyX2+fLIePLRIFuu1p4whjHAQYufqyno3BS48icQb4p6iVEZPo4AE2o9oIyQvj2mx4dk5Y8CgSETOZTYDOR3rU2fZTRDRgPJDH9FWvQjF5tA0p3d9CoWWd2s6GKKbfoUIi8R/Db1BSPJwkqB jrp-hp-pc"
+}
+
+resource "telefonicaopencloud_as_configuration_v1" "hth_as_config"{
+  scaling_configuration_name = "hth_as_config"
+  instance_config = {
+    image = "%s"
+    disk = [
+      {size = 40
+      volume_type = "SATA"
+      disk_type = "SYS"}
+    ]
+    key_name = "${telefonicaopencloud_compute_keypair_v2.hth_key.id}"
+  }
+}
+
+resource "telefonicaopencloud_as_group_v1" "hth_as_group"{
+  scaling_group_name = "hth_as_group"
+  scaling_configuration_id = "${telefonicaopencloud_as_configuration_v1.hth_as_config.id}"
+  networks = [
+    {id = "%s"},
+  ]
+  security_groups = [
+    {id = "${telefonicaopencloud_networking_secgroup_v2.secgroup.id}"},
+  ]
+  vpc_id = "%s"
+  health_periodic_audit_time = 15
+  enable = false
+}
+`, OS_IMAGE_ID, OS_NETWORK_ID, OS_VPC_ID)
+
+func testASV1Group_instanceNumbers(min, desire int, deletePublicip bool) string {
+	return fmt.Sprintf(`
+resource "telefonicaopencloud_networking_secgroup_v2" "secgroup" {
+  name        = "terraform"
+  description = "This is a terraform test security group"
+}
+
+resource "telefonicaopencloud_compute_keypair_v2" "hth_key" {
+  name = "hth_key"
+  public_key = "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQDAjpC1hwiOCCmKEWxJ4qzTTsJbKzndLo1BCz5PcwtUnflmU+gHJtWMZKpuEGVi29h0A/+ydKek1O18k10Ff+4tyFjiHDQAT9+OfgWf7+b1yK+qDip3X1C0UPMbwHlTfSGWLGZquwhvEFx9k3h/M+VtMvwR1lJ9LUyTAImnNjWG7TAIPmui30HvM2UiFEmqkr4ijq45MyX2+fLIePLRIFuu1p4whjHAQYufqyno3BS48icQb4p6iVEZPo4AE2o9oIyQvj2mx4dk5Y8CgSETOZTYDOR3rU2fZTRDRgPJDH9FWvQjF5tA0p3d9CoWWd2s6GKKbfoUIi8R/Db1BSPJwkqB jrp-hp-pc"
+}
+
+resource "telefonicaopencloud_as_configuration_v1" "hth_as_config"{
+  scaling_configuration_name = "hth_as_config"
+  instance_config = {
+    image = "%s"
+    disk = [
+      {size = 40
+      volume_type = "SATA"
+      disk_type = "SYS"}
+    ]
+    key_name = "${telefonicaopencloud_compute_keypair_v2.hth_key.id}"
+  }
+}
+
+resource "telefonicaopencloud_as_group_v1" "hth_as_group"{
+  scaling_group_name = "hth_as_group"
+  scaling_configuration_id = "${telefonicaopencloud_as_configuration_v1.hth_as_config.id}"
+  networks = [
+    {id = "%s"},
+  ]
+  security_groups = [
+    {id = "${telefonicaopencloud_networking_secgroup_v2.secgroup.id}"},
+  ]
+  vpc_id = "%s"
+  health_periodic_audit_time = 15
+  enable = false
+  min_instance_number = %d
+  desire_instance_number = %d
+  max_instance_number = 2
+  delete_publicip = %t
+  delete_instances = "yes"
+}
+`, OS_IMAGE_ID, OS_NETWORK_ID, OS_VPC_ID, min, desire, deletePublicip)
+}
@@ -0,0 +1,206 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/autoscaling/v1/instances"
+)
+
+func resourceASInstanceAttach() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceASInstanceAttachCreate,
+		Read:   resourceASInstanceAttachRead,
+		Delete: resourceASInstanceAttachDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"scaling_group_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"instance_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"delete_instance": {
+				Description: "Whether to delete the instance when it is removed from the AS group.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+			},
+			"life_cycle_state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"health_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func parseASInstanceAttachID(id string) (string, string, error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("Invalid format specified for AS instance attachment. Format must be <scaling_group_id>/<instance_id>")
+	}
+	return parts[0], parts[1], nil
+}
+
+func getASGroupInstance(asClient *golangsdk.ServiceClient, groupID, instanceID string) (*instances.Instance, error) {
+	var opts instances.ListOptsBuilder
+	allIns, err := getInstancesInGroup(asClient, groupID, opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, ins := range allIns {
+		if ins.ID == instanceID {
+			return &ins, nil
+		}
+	}
+	return nil, nil
+}
+
+func refreshASGroupInstanceState(asClient *golangsdk.ServiceClient, groupID, instanceID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		ins, err := getASGroupInstance(asClient, groupID, instanceID)
+		if err != nil {
+			return nil, "ERROR", err
+		}
+		if ins == nil {
+			return "", "REMOVED", nil
+		}
+		log.Printf("[DEBUG] Get lifecycle status of instance %s in group %s: %s", instanceID, groupID, ins.LifeCycleStatus)
+		return ins, ins.LifeCycleStatus, nil
+	}
+}
+
+func resourceASInstanceAttachCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	asClient, err := config.autoscalingV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud autoscaling client: %s", err)
+	}
+
+	groupID := d.Get("scaling_group_id").(string)
+	instanceID := d.Get("instance_id").(string)
+
+	log.Printf("[DEBUG] Adding instance %s to ASGroup %q", instanceID, groupID)
+	batchResult := instances.BatchAdd(asClient, groupID, []string{instanceID})
+	if batchResult.Err != nil {
+		return fmt.Errorf("Error adding instance %s to ASGroup %q: %s", instanceID, groupID, batchResult.Err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", groupID, instanceID))
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"REMOVED", "PENDING", "ADDING_TO_ELB"},
+		Target:  []string{"INSERVICE"},
+		Refresh: refreshASGroupInstanceState(asClient, groupID, instanceID),
+		Timeout: d.Timeout(schema.TimeoutCreate),
+		Delay:   10 * time.Second,
+	}
+
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error waiting for instance %s in ASGroup %q to become inservice: %s", instanceID, groupID, err)
+	}
+
+	return resourceASInstanceAttachRead(d, meta)
+}
+
+func resourceASInstanceAttachRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	asClient, err := config.autoscalingV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud autoscaling client: %s", err)
+	}
+
+	groupID, instanceID, err := parseASInstanceAttachID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	ins, err := getASGroupInstance(asClient, groupID, instanceID)
+	if err != nil {
+		return CheckDeleted(d, err, "AS group")
+	}
+	if ins == nil {
+		log.Printf("[WARN] Instance %s is no longer in ASGroup %q", instanceID, groupID)
+		d.SetId("")
+		return nil
+	}
+	log.Printf("[DEBUG] Retrieved instance %s in ASGroup %q: %+v", instanceID, groupID, ins)
+
+	d.Set("scaling_group_id", groupID)
+	d.Set("instance_id", instanceID)
+	d.Set("life_cycle_state", ins.LifeCycleStatus)
+	d.Set("health_status", ins.HealthStatus)
+	d.Set("region", GetRegion(d, config))
+
+	return nil
+}
+
+func resourceASInstanceAttachDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	asClient, err := config.autoscalingV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud autoscaling client: %s", err)
+	}
+
+	groupID, instanceID, err := parseASInstanceAttachID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	deleteIns := "no"
+	if d.Get("delete_instance").(bool) {
+		deleteIns = "yes"
+	}
+
+	log.Printf("[DEBUG] Removing instance %s from ASGroup %q", instanceID, groupID)
+	batchResult := instances.BatchDelete(asClient, groupID, []string{instanceID}, deleteIns)
+	if batchResult.Err != nil {
+		return CheckDeleted(d, batchResult.Err, "AS instance attachment")
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"INSERVICE", "REMOVING", "REMOVING_FROM_ELB"},
+		Target:  []string{"REMOVED"},
+		Refresh: refreshASGroupInstanceState(asClient, groupID, instanceID),
+		Timeout: d.Timeout(schema.TimeoutDelete),
+		Delay:   10 * time.Second,
+	}
+
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error waiting for instance %s to be removed from ASGroup %q: %s", instanceID, groupID, err)
+	}
+
+	d.SetId("")
+	return nil
+}
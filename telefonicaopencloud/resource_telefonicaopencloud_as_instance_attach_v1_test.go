@@ -0,0 +1,128 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccASV1InstanceAttach_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccAsConfigPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckASV1InstanceAttachDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testASV1InstanceAttach_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckASV1InstanceAttachExists("telefonicaopencloud_as_instance_attach_v1.attach_1"),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_as_instance_attach_v1.attach_1", "life_cycle_state", "INSERVICE"),
+				),
+			},
+			{
+				// Attaching the instance changes the desired number and
+				// the instances of the group, which must not show up as
+				// a diff of the group.
+				Config:   testASV1InstanceAttach_basic,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccCheckASV1InstanceAttachDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+	asClient, err := config.autoscalingV1Client(OS_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating telefonicaopencloud autoscaling client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "telefonicaopencloud_as_instance_attach_v1" {
+			continue
+		}
+
+		groupID, instanceID, err := parseASInstanceAttachID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		ins, err := getASGroupInstance(asClient, groupID, instanceID)
+		if err == nil && ins != nil {
+			return fmt.Errorf("Instance %s is still in AS group %s", instanceID, groupID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckASV1InstanceAttachExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		asClient, err := config.autoscalingV1Client(OS_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating telefonicaopencloud autoscaling client: %s", err)
+		}
+
+		groupID, instanceID, err := parseASInstanceAttachID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		ins, err := getASGroupInstance(asClient, groupID, instanceID)
+		if err != nil {
+			return err
+		}
+		if ins == nil {
+			return fmt.Errorf("Instance %s not found in AS group %s", instanceID, groupID)
+		}
+
+		return nil
+	}
+}
+
+var testASV1InstanceAttach_basic = fmt.Sprintf(`
+resource "telefonicaopencloud_networking_secgroup_v2" "secgroup" {
+  name        = "terraform"
+  description = "This is a terraform test security group"
+}
+
+resource "telefonicaopencloud_as_group_v1" "hth_as_group"{
+  scaling_group_name = "hth_as_group"
+  max_instance_number = 1
+  networks = [
+    {id = "%s"},
+  ]
+  security_groups = [
+    {id = "${telefonicaopencloud_networking_secgroup_v2.secgroup.id}"},
+  ]
+  vpc_id = "%s"
+}
+
+resource "telefonicaopencloud_compute_instance_v2" "instance_1" {
+  name = "instance_1"
+  image_id = "%s"
+  flavor_id = "%s"
+  security_groups = ["${telefonicaopencloud_networking_secgroup_v2.secgroup.name}"]
+  network {
+    uuid = "%s"
+  }
+}
+
+resource "telefonicaopencloud_as_instance_attach_v1" "attach_1" {
+  scaling_group_id = "${telefonicaopencloud_as_group_v1.hth_as_group.id}"
+  instance_id = "${telefonicaopencloud_compute_instance_v2.instance_1.id}"
+}
+`, OS_NETWORK_ID, OS_VPC_ID, OS_IMAGE_ID, OS_FLAVOR_ID, OS_NETWORK_ID)
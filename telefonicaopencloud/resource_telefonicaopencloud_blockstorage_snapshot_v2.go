@@ -0,0 +1,215 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceBlockStorageSnapshotV2() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBlockStorageSnapshotV2Create,
+		Read:   resourceBlockStorageSnapshotV2Read,
+		Update: resourceBlockStorageSnapshotV2Update,
+		Delete: resourceBlockStorageSnapshotV2Delete,
+		Importer: &schema.ResourceImporter{
+			State: resourceBlockStorageSnapshotV2Import,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"volume_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: false,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: false,
+			},
+			"force": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: false,
+				Computed: true,
+			},
+			"size": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceBlockStorageSnapshotV2Create(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	blockStorageClient, err := config.blockStorageV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud block storage client: %s", err)
+	}
+
+	createOpts := snapshotV2CreateOpts{
+		VolumeID:    d.Get("volume_id").(string),
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		Force:       d.Get("force").(bool),
+		Metadata:    resourceVolumeMetadataV2(d),
+	}
+
+	log.Printf("[DEBUG] Create Options: %#v", createOpts)
+	s, err := createSnapshotV2(blockStorageClient, createOpts)
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud snapshot: %s", err)
+	}
+	log.Printf("[INFO] Snapshot ID: %s", s.ID)
+
+	// Store the ID now
+	d.SetId(s.ID)
+
+	log.Printf("[DEBUG] Waiting for snapshot (%s) to become available", s.ID)
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"creating"},
+		Target:     []string{"available"},
+		Refresh:    SnapshotV2StateRefreshFunc(blockStorageClient, s.ID),
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Delay:      10 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf(
+			"Error waiting for snapshot (%s) to become ready: %s",
+			s.ID, err)
+	}
+
+	return resourceBlockStorageSnapshotV2Read(d, meta)
+}
+
+func resourceBlockStorageSnapshotV2Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	blockStorageClient, err := config.blockStorageV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud block storage client: %s", err)
+	}
+
+	s, err := getSnapshotV2(blockStorageClient, d.Id())
+	if err != nil {
+		return CheckDeleted(d, err, "snapshot")
+	}
+
+	log.Printf("[DEBUG] Retrieved snapshot %s: %+v", d.Id(), s)
+
+	d.Set("volume_id", s.VolumeID)
+	d.Set("name", s.Name)
+	d.Set("description", s.Description)
+	d.Set("metadata", s.Metadata)
+	d.Set("size", s.Size)
+	d.Set("status", s.Status)
+	d.Set("region", GetRegion(d, config))
+
+	return nil
+}
+
+func resourceBlockStorageSnapshotV2Update(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	blockStorageClient, err := config.blockStorageV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud block storage client: %s", err)
+	}
+
+	if d.HasChange("name") || d.HasChange("description") {
+		updateOpts := snapshotV2UpdateOpts{
+			Name:        d.Get("name").(string),
+			Description: d.Get("description").(string),
+		}
+
+		log.Printf("[DEBUG] Update Options: %#v", updateOpts)
+		if err := updateSnapshotV2(blockStorageClient, d.Id(), updateOpts); err != nil {
+			return fmt.Errorf("Error updating TelefonicaOpenCloud snapshot: %s", err)
+		}
+	}
+
+	if d.HasChange("metadata") {
+		metadata := resourceVolumeMetadataV2(d)
+		log.Printf("[DEBUG] Metadata: %#v", metadata)
+		if err := updateSnapshotV2Metadata(blockStorageClient, d.Id(), metadata); err != nil {
+			return fmt.Errorf("Error updating TelefonicaOpenCloud snapshot metadata: %s", err)
+		}
+	}
+
+	return resourceBlockStorageSnapshotV2Read(d, meta)
+}
+
+func resourceBlockStorageSnapshotV2Delete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	blockStorageClient, err := config.blockStorageV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud block storage client: %s", err)
+	}
+
+	if err := deleteSnapshotV2(blockStorageClient, d.Id()); err != nil {
+		return CheckDeleted(d, err, "snapshot")
+	}
+
+	// Wait for the snapshot to delete before moving on.
+	log.Printf("[DEBUG] Waiting for snapshot (%s) to delete", d.Id())
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"available", "deleting"},
+		Target:     []string{"deleted"},
+		Refresh:    SnapshotV2StateRefreshFunc(blockStorageClient, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		Delay:      10 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf(
+			"Error waiting for snapshot (%s) to delete: %s",
+			d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// resourceBlockStorageSnapshotV2Import imports a snapshot by its ID. Whether
+// it was forced is not returned by the API, so force is set to its default to
+// avoid replacing the snapshot on the next plan.
+func resourceBlockStorageSnapshotV2Import(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.Set("force", false)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -16,16 +16,18 @@ import (
 
 func resourceBlockStorageVolumeV2() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceBlockStorageVolumeV2Create,
-		Read:   resourceBlockStorageVolumeV2Read,
-		Update: resourceBlockStorageVolumeV2Update,
-		Delete: resourceBlockStorageVolumeV2Delete,
+		Create:        resourceBlockStorageVolumeV2Create,
+		Read:          resourceBlockStorageVolumeV2Read,
+		Update:        resourceBlockStorageVolumeV2Update,
+		Delete:        resourceBlockStorageVolumeV2Delete,
+		CustomizeDiff: resourceBlockStorageVolumeV2CustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
@@ -40,7 +42,7 @@ func resourceBlockStorageVolumeV2() *schema.Resource {
 			"size": {
 				Type:     schema.TypeInt,
 				Required: true,
-				ForceNew: true,
+				ForceNew: false,
 			},
 			"name": {
 				Type:     schema.TypeString,
@@ -82,7 +84,7 @@ func resourceBlockStorageVolumeV2() *schema.Resource {
 			"volume_type": {
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
+				ForceNew: false,
 				Computed: true,
 			},
 			"consistency_group_id": {
@@ -121,6 +123,7 @@ func resourceBlockStorageVolumeV2() *schema.Resource {
 				Optional: true,
 				Default:  false,
 			},
+			"tags": tagsSchema(),
 		},
 	}
 }
@@ -177,6 +180,10 @@ func resourceBlockStorageVolumeV2Create(d *schema.ResourceData, meta interface{}
 	// Store the ID now
 	d.SetId(v.ID)
 
+	if err := updateResourceTags(d, config, blockStorageClient, "cloudvolumes", v.ID); err != nil {
+		return err
+	}
+
 	return resourceBlockStorageVolumeV2Read(d, meta)
 }
 
@@ -228,6 +235,10 @@ OUTER:
 	}
 	d.Set("attachment", attachments)
 
+	if err := readResourceTags(d, config, blockStorageClient, "cloudvolumes", d.Id()); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -252,9 +263,67 @@ func resourceBlockStorageVolumeV2Update(d *schema.ResourceData, meta interface{}
 		return fmt.Errorf("Error updating TelefonicaOpenCloud volume: %s", err)
 	}
 
+	if d.HasChange("size") {
+		v, err := volumes.Get(blockStorageClient, d.Id()).Extract()
+		if err != nil {
+			return fmt.Errorf("Error retrieving TelefonicaOpenCloud volume: %s", err)
+		}
+
+		extendOpts := volumeV2ExtendOpts{
+			NewSize: d.Get("size").(int),
+		}
+		log.Printf("[DEBUG] Extend Options: %#v", extendOpts)
+		err = extendVolumeV2(blockStorageClient, d.Id(), v.Status == "in-use", extendOpts)
+		if err != nil {
+			return fmt.Errorf("Error extending TelefonicaOpenCloud volume: %s", err)
+		}
+
+		if err := waitForVolumeV2Status(d, blockStorageClient, []string{"extending"}, v.Status); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("volume_type") {
+		v, err := volumes.Get(blockStorageClient, d.Id()).Extract()
+		if err != nil {
+			return fmt.Errorf("Error retrieving TelefonicaOpenCloud volume: %s", err)
+		}
+
+		retypeOpts := volumeV2RetypeOpts{
+			NewType:         d.Get("volume_type").(string),
+			MigrationPolicy: "on-demand",
+		}
+		log.Printf("[DEBUG] Retype Options: %#v", retypeOpts)
+		err = retypeVolumeV2(blockStorageClient, d.Id(), retypeOpts)
+		if err != nil {
+			return fmt.Errorf("Error retyping TelefonicaOpenCloud volume: %s", err)
+		}
+
+		if err := waitForVolumeV2Status(d, blockStorageClient, []string{"retyping"}, v.Status); err != nil {
+			return err
+		}
+	}
+
+	if err := updateResourceTags(d, config, blockStorageClient, "cloudvolumes", d.Id()); err != nil {
+		return err
+	}
+
 	return resourceBlockStorageVolumeV2Read(d, meta)
 }
 
+// A volume can only be extended, so a smaller size is rejected when planning
+// instead of failing half way through the update.
+func resourceBlockStorageVolumeV2CustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && d.HasChange("size") {
+		o, n := d.GetChange("size")
+		if n.(int) < o.(int) {
+			return fmt.Errorf("Error extending TelefonicaOpenCloud volume: size can only be increased, from %d to %d requested", o.(int), n.(int))
+		}
+	}
+
+	return nil
+}
+
 func resourceBlockStorageVolumeV2Delete(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 	blockStorageClient, err := config.loadEVSV2Client(GetRegion(d, config))
@@ -334,6 +403,28 @@ func resourceBlockStorageVolumeV2Delete(d *schema.ResourceData, meta interface{}
 	return nil
 }
 
+func waitForVolumeV2Status(d *schema.ResourceData, client *golangsdk.ServiceClient, pending []string, target string) error {
+	log.Printf("[DEBUG] Waiting for volume (%s) to become %s", d.Id(), target)
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    pending,
+		Target:     []string{target},
+		Refresh:    VolumeV2StateRefreshFunc(client, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutUpdate),
+		Delay:      10 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err := stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf(
+			"Error waiting for volume (%s) to become %s: %s",
+			d.Id(), target, err)
+	}
+
+	return nil
+}
+
 func resourceVolumeMetadataV2(d *schema.ResourceData) map[string]string {
 	m := make(map[string]string)
 	for key, val := range d.Get("metadata").(map[string]interface{}) {
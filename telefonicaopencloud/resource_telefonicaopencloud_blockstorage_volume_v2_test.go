@@ -2,6 +2,7 @@ package telefonicaopencloud
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
@@ -26,6 +27,10 @@ func TestAccBlockStorageV2Volume_basic(t *testing.T) {
 					testAccCheckBlockStorageV2VolumeMetadata(&volume, "foo", "bar"),
 					resource.TestCheckResourceAttr(
 						"telefonicaopencloud_blockstorage_volume_v2.volume_1", "name", "volume_1"),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_blockstorage_volume_v2.volume_1", "tags.%", "2"),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_blockstorage_volume_v2.volume_1", "tags.foo", "bar"),
 				),
 			},
 			{
@@ -35,6 +40,10 @@ func TestAccBlockStorageV2Volume_basic(t *testing.T) {
 					testAccCheckBlockStorageV2VolumeMetadata(&volume, "foo", "bar"),
 					resource.TestCheckResourceAttr(
 						"telefonicaopencloud_blockstorage_volume_v2.volume_1", "name", "volume_1-updated"),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_blockstorage_volume_v2.volume_1", "tags.%", "1"),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_blockstorage_volume_v2.volume_1", "tags.foo", "bar2"),
 				),
 			},
 		},
@@ -79,6 +88,101 @@ func TestAccBlockStorageV2Volume_timeout(t *testing.T) {
 	})
 }
 
+func TestAccBlockStorageV2Volume_extend(t *testing.T) {
+	var volume1_1 volumes.Volume
+	var volume1_2 volumes.Volume
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBlockStorageV2VolumeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBlockStorageV2Volume_size(1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBlockStorageV2VolumeExists("telefonicaopencloud_blockstorage_volume_v2.volume_1", &volume1_1),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_blockstorage_volume_v2.volume_1", "size", "1"),
+				),
+			},
+			{
+				Config: testAccBlockStorageV2Volume_size(2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBlockStorageV2VolumeExists("telefonicaopencloud_blockstorage_volume_v2.volume_1", &volume1_2),
+					testAccCheckBlockStorageV2VolumeIDsMatch(&volume1_1, &volume1_2),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_blockstorage_volume_v2.volume_1", "size", "2"),
+				),
+			},
+			{
+				Config:      testAccBlockStorageV2Volume_size(1),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`size can only be increased`),
+			},
+		},
+	})
+}
+
+func TestAccBlockStorageV2Volume_extendInUse(t *testing.T) {
+	var volume1_1 volumes.Volume
+	var volume1_2 volumes.Volume
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBlockStorageV2VolumeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBlockStorageV2Volume_attachedSize(1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBlockStorageV2VolumeExists("telefonicaopencloud_blockstorage_volume_v2.volume_1", &volume1_1),
+				),
+			},
+			{
+				Config: testAccBlockStorageV2Volume_attachedSize(2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBlockStorageV2VolumeExists("telefonicaopencloud_blockstorage_volume_v2.volume_1", &volume1_2),
+					testAccCheckBlockStorageV2VolumeIDsMatch(&volume1_1, &volume1_2),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_blockstorage_volume_v2.volume_1", "size", "2"),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_blockstorage_volume_v2.volume_1", "attachment.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBlockStorageV2Volume_retype(t *testing.T) {
+	var volume1_1 volumes.Volume
+	var volume1_2 volumes.Volume
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBlockStorageV2VolumeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBlockStorageV2Volume_volumeType("SATA"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBlockStorageV2VolumeExists("telefonicaopencloud_blockstorage_volume_v2.volume_1", &volume1_1),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_blockstorage_volume_v2.volume_1", "volume_type", "SATA"),
+				),
+			},
+			{
+				Config: testAccBlockStorageV2Volume_volumeType("SAS"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBlockStorageV2VolumeExists("telefonicaopencloud_blockstorage_volume_v2.volume_1", &volume1_2),
+					testAccCheckBlockStorageV2VolumeIDsMatch(&volume1_1, &volume1_2),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_blockstorage_volume_v2.volume_1", "volume_type", "SAS"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckBlockStorageV2VolumeDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*Config)
 	blockStorageClient, err := config.loadEVSV2Client(OS_REGION_NAME)
@@ -182,6 +286,10 @@ resource "telefonicaopencloud_blockstorage_volume_v2" "volume_1" {
   metadata {
     foo = "bar"
   }
+  tags {
+    foo = "bar"
+    key = "value"
+  }
   size = 1
 }
 `
@@ -193,6 +301,9 @@ resource "telefonicaopencloud_blockstorage_volume_v2" "volume_1" {
   metadata {
     foo = "bar"
   }
+  tags {
+    foo = "bar2"
+  }
   size = 1
 }
 `
@@ -217,3 +328,54 @@ resource "telefonicaopencloud_blockstorage_volume_v2" "volume_1" {
   }
 }
 `
+
+func testAccCheckBlockStorageV2VolumeIDsMatch(volume1, volume2 *volumes.Volume) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if volume1.ID != volume2.ID {
+			return fmt.Errorf("Volume was recreated.")
+		}
+
+		return nil
+	}
+}
+
+func testAccBlockStorageV2Volume_size(size int) string {
+	return fmt.Sprintf(`
+resource "telefonicaopencloud_blockstorage_volume_v2" "volume_1" {
+  name = "volume_1"
+  size = %d
+}
+`, size)
+}
+
+func testAccBlockStorageV2Volume_attachedSize(size int) string {
+	return fmt.Sprintf(`
+resource "telefonicaopencloud_blockstorage_volume_v2" "volume_1" {
+  name = "volume_1"
+  size = %d
+}
+
+resource "telefonicaopencloud_compute_instance_v2" "instance_1" {
+  name = "instance_1"
+  security_groups = ["default"]
+  network {
+    uuid = "%s"
+  }
+}
+
+resource "telefonicaopencloud_compute_volume_attach_v2" "va_1" {
+  instance_id = "${telefonicaopencloud_compute_instance_v2.instance_1.id}"
+  volume_id = "${telefonicaopencloud_blockstorage_volume_v2.volume_1.id}"
+}
+`, size, OS_NETWORK_ID)
+}
+
+func testAccBlockStorageV2Volume_volumeType(volumeType string) string {
+	return fmt.Sprintf(`
+resource "telefonicaopencloud_blockstorage_volume_v2" "volume_1" {
+  name = "volume_1"
+  size = 1
+  volume_type = "%s"
+}
+`, volumeType)
+}
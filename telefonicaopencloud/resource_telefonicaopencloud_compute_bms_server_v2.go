@@ -28,6 +28,9 @@ func resourceComputeBMSInstanceV2() *schema.Resource {
 		Read:   resourceComputeBMSInstanceV2Read,
 		Update: resourceComputeBMSInstanceV2Update,
 		Delete: resourceComputeBMSInstanceV2Delete,
+		Importer: &schema.ResourceImporter{
+			State: resourceComputeBMSInstanceV2ImportState,
+		},
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(30 * time.Minute),
@@ -186,6 +189,7 @@ func resourceComputeBMSInstanceV2() *schema.Resource {
 				Optional: true,
 				Default:  false,
 			},
+			"power_state": instancePowerStateSchema(),
 			"tenant_id": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -389,7 +393,15 @@ func resourceComputeBMSInstanceV2Create(d *schema.ResourceData, meta interface{}
 			server.ID, err)
 	}
 
-	return resourceComputeInstanceV2Read(d, meta)
+	// A new server is active, it only has to be stopped when asked to.
+	if d.Get("power_state").(string) == instancePowerStateShutoff {
+		if err := setInstancePowerState(computeClient, d,
+			BmsServerV2StateRefreshFunc(computeClient, d.Id()), d.Timeout(schema.TimeoutCreate)); err != nil {
+			return err
+		}
+	}
+
+	return resourceComputeBMSInstanceV2Read(d, meta)
 }
 
 func resourceComputeBMSInstanceV2Read(d *schema.ResourceData, meta interface{}) error {
@@ -449,6 +461,14 @@ func resourceComputeBMSInstanceV2Read(d *schema.ResourceData, meta interface{})
 	}
 
 	d.Set("metadata", server.Metadata)
+	d.Set("key_pair", server.KeyName)
+
+	secGroups := []string{}
+	for _, sg := range server.SecurityGroups {
+		secGroups = append(secGroups, sg.Name)
+	}
+	d.Set("security_groups", secGroups)
+
 	d.Set("flavor_id", server.Flavor.ID)
 
 	flavor, err := flavors.Get(computeClient, server.Flavor.ID).Extract()
@@ -464,6 +484,7 @@ func resourceComputeBMSInstanceV2Read(d *schema.ResourceData, meta interface{})
 
 	d.Set("availability_zone", server.AvailabilityZone)
 	d.Set("tenant_id", server.TenantID)
+	d.Set("power_state", flattenInstancePowerState(d, server.Status))
 	d.Set("host_status", server.HostStatus)
 	d.Set("host_id", server.HostID)
 	d.Set("kernel_id", server.KernelId)
@@ -620,7 +641,7 @@ func resourceComputeBMSInstanceV2Update(d *schema.ResourceData, meta interface{}
 
 		stateConf = &resource.StateChangeConf{
 			Pending:    []string{"VERIFY_RESIZE"},
-			Target:     []string{"ACTIVE"},
+			Target:     []string{"ACTIVE", "SHUTOFF"},
 			Refresh:    BmsServerV2StateRefreshFunc(computeClient, d.Id()),
 			Timeout:    d.Timeout(schema.TimeoutUpdate),
 			Delay:      10 * time.Second,
@@ -633,7 +654,15 @@ func resourceComputeBMSInstanceV2Update(d *schema.ResourceData, meta interface{}
 		}
 	}
 
-	return resourceComputeInstanceV2Read(d, meta)
+	if d.HasChange("power_state") {
+		err := setInstancePowerState(computeClient, d,
+			BmsServerV2StateRefreshFunc(computeClient, d.Id()), d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceComputeBMSInstanceV2Read(d, meta)
 }
 
 func resourceComputeBMSInstanceV2Delete(d *schema.ResourceData, meta interface{}) error {
@@ -693,6 +722,43 @@ func resourceComputeBMSInstanceV2Delete(d *schema.ResourceData, meta interface{}
 	return nil
 }
 
+// resourceComputeBMSInstanceV2ImportState sets the arguments which Read
+// only reads back through the configuration: the networks and the boot
+// volume.
+func resourceComputeBMSInstanceV2ImportState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	config := meta.(*Config)
+	computeClient, err := config.computeV2HWClient(GetRegion(d, config))
+	if err != nil {
+		return nil, fmt.Errorf("Error creating TelefonicaOpenCloud compute client: %s", err)
+	}
+	networkingClient, err := config.networkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return nil, fmt.Errorf("Error creating TelefonicaOpenCloud networking client: %s", err)
+	}
+	blockStorageClient, err := config.blockStorageV2Client(GetRegion(d, config))
+	if err != nil {
+		return nil, fmt.Errorf("Error creating TelefonicaOpenCloud block storage client: %s", err)
+	}
+
+	server, err := servers.Get(computeClient, d.Id()).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving TelefonicaOpenCloud server %s: %s", d.Id(), err)
+	}
+
+	blockDevices, err := importInstanceBlockDevices(computeClient, blockStorageClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("network", importInstanceNetworks(networkingClient, server))
+	if blockDevices != nil {
+		d.Set("block_device", blockDevices)
+	}
+	d.Set("stop_before_destroy", false)
+
+	return []*schema.ResourceData{d}, nil
+}
+
 // ServerV2StateRefreshFunc returns a resource.StateRefreshFunc that is used to watch
 // an TelefonicaOpenCloud instance.
 func BmsServerV2StateRefreshFunc(client *golangsdk.ServiceClient, instanceID string) resource.StateRefreshFunc {
@@ -54,6 +54,34 @@ func TestAccComputeV2BmsInstance_timeout(t *testing.T) {
 	})
 }
 
+func TestAccComputeV2BmsInstance_powerState(t *testing.T) {
+	var instance servers.Server
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccBmsFlavorPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeV2BmsInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccComputeV2BmsInstance_powerState("shutoff"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2BmsInstanceExists("telefonicaopencloud_compute_bms_server_v2.instance_1", &instance),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_compute_bms_server_v2.instance_1", "power_state", "shutoff"),
+				),
+			},
+			{
+				Config: testAccComputeV2BmsInstance_powerState("active"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2BmsInstanceExists("telefonicaopencloud_compute_bms_server_v2.instance_1", &instance),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_compute_bms_server_v2.instance_1", "power_state", "active"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckComputeV2BmsInstanceDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*Config)
 	computeClient, err := config.computeV2HWClient(OS_REGION_NAME)
@@ -157,3 +185,19 @@ resource "telefonicaopencloud_compute_bms_server_v2" "instance_1" {
   }
 }
 `, OS_AVAILABILITY_ZONE, OS_NETWORK_ID)
+
+func testAccComputeV2BmsInstance_powerState(powerState string) string {
+	return fmt.Sprintf(`
+resource "telefonicaopencloud_compute_bms_server_v2" "instance_1" {
+  name = "instance_1"
+  flavor_id = "physical.o2.medium"
+  flavor_name = "physical.o2.medium"
+  security_groups = ["default"]
+  availability_zone = "%s"
+  power_state = "%s"
+  network {
+    uuid = "%s"
+  }
+}
+`, OS_AVAILABILITY_ZONE, powerState, OS_NETWORK_ID)
+}
@@ -30,6 +30,9 @@ func resourceComputeInstanceV2() *schema.Resource {
 		Read:   resourceComputeInstanceV2Read,
 		Update: resourceComputeInstanceV2Update,
 		Delete: resourceComputeInstanceV2Delete,
+		Importer: &schema.ResourceImporter{
+			State: resourceComputeInstanceV2ImportState,
+		},
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(30 * time.Minute),
@@ -114,32 +117,27 @@ func resourceComputeInstanceV2() *schema.Resource {
 			"network": {
 				Type:     schema.TypeList,
 				Optional: true,
-				ForceNew: true,
 				Computed: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"uuid": {
 							Type:     schema.TypeString,
 							Optional: true,
-							ForceNew: true,
 							Computed: true,
 						},
 						"name": {
 							Type:     schema.TypeString,
 							Optional: true,
-							ForceNew: true,
 							Computed: true,
 						},
 						"port": {
 							Type:     schema.TypeString,
 							Optional: true,
-							ForceNew: true,
 							Computed: true,
 						},
 						"fixed_ip_v4": {
 							Type:     schema.TypeString,
 							Optional: true,
-							ForceNew: true,
 							Computed: true,
 						},
 						"fixed_ip_v6": {
@@ -330,10 +328,12 @@ func resourceComputeInstanceV2() *schema.Resource {
 				Optional: true,
 				Default:  false,
 			},
+			"power_state": instancePowerStateSchema(),
 			"all_metadata": {
 				Type:     schema.TypeMap,
 				Computed: true,
 			},
+			"tags": tagsSchema(),
 		},
 	}
 }
@@ -463,6 +463,18 @@ func resourceComputeInstanceV2Create(d *schema.ResourceData, meta interface{}) e
 			server.ID, err)
 	}
 
+	// A new server is active, it only has to be stopped when asked to.
+	if d.Get("power_state").(string) == instancePowerStateShutoff {
+		if err := setInstancePowerState(computeClient, d,
+			ServerV2StateRefreshFunc(computeClient, d.Id()), d.Timeout(schema.TimeoutCreate)); err != nil {
+			return err
+		}
+	}
+
+	if err := updateComputeInstanceV2Tags(d, config); err != nil {
+		return err
+	}
+
 	return resourceComputeInstanceV2Read(d, meta)
 }
 
@@ -523,6 +535,16 @@ func resourceComputeInstanceV2Read(d *schema.ResourceData, meta interface{}) err
 	}
 
 	d.Set("all_metadata", server.Metadata)
+	d.Set("power_state", flattenInstancePowerState(d, server.Status))
+	d.Set("key_pair", server.KeyName)
+
+	secGroups := []string{}
+	for _, sg := range server.SecurityGroups {
+		if name, ok := sg["name"].(string); ok {
+			secGroups = append(secGroups, name)
+		}
+	}
+	d.Set("security_groups", secGroups)
 
 	flavorId, ok := server.Flavor["id"].(string)
 	if !ok {
@@ -559,6 +581,15 @@ func resourceComputeInstanceV2Read(d *schema.ResourceData, meta interface{}) err
 	// Set the region
 	d.Set("region", GetRegion(d, config))
 
+	// Set the tags
+	ecsClient, err := config.computeV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud ECS client: %s", err)
+	}
+	if err := readResourceTags(d, config, ecsClient, "cloudservers", d.Id()); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -652,6 +683,12 @@ func resourceComputeInstanceV2Update(d *schema.ResourceData, meta interface{}) e
 		}
 	}
 
+	if d.HasChange("network") {
+		if err := updateInstanceNetworks(d, meta, computeClient); err != nil {
+			return err
+		}
+	}
+
 	if d.HasChange("admin_pass") {
 		if newPwd, ok := d.Get("admin_pass").(string); ok {
 			err := servers.ChangeAdminPassword(computeClient, d.Id(), newPwd).ExtractErr()
@@ -709,7 +746,7 @@ func resourceComputeInstanceV2Update(d *schema.ResourceData, meta interface{}) e
 
 		stateConf = &resource.StateChangeConf{
 			Pending:    []string{"VERIFY_RESIZE"},
-			Target:     []string{"ACTIVE"},
+			Target:     []string{"ACTIVE", "SHUTOFF"},
 			Refresh:    ServerV2StateRefreshFunc(computeClient, d.Id()),
 			Timeout:    d.Timeout(schema.TimeoutUpdate),
 			Delay:      10 * time.Second,
@@ -722,6 +759,18 @@ func resourceComputeInstanceV2Update(d *schema.ResourceData, meta interface{}) e
 		}
 	}
 
+	if d.HasChange("power_state") {
+		err := setInstancePowerState(computeClient, d,
+			ServerV2StateRefreshFunc(computeClient, d.Id()), d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := updateComputeInstanceV2Tags(d, config); err != nil {
+		return err
+	}
+
 	return resourceComputeInstanceV2Read(d, meta)
 }
 
@@ -782,6 +831,44 @@ func resourceComputeInstanceV2Delete(d *schema.ResourceData, meta interface{}) e
 	return nil
 }
 
+// resourceComputeInstanceV2ImportState sets the arguments which Read only
+// reads back through the configuration: the networks, the boot volume and
+// the metadata.
+func resourceComputeInstanceV2ImportState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	config := meta.(*Config)
+	computeClient, err := config.computeV2Client(GetRegion(d, config))
+	if err != nil {
+		return nil, fmt.Errorf("Error creating TelefonicaOpenCloud compute client: %s", err)
+	}
+	networkingClient, err := config.networkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return nil, fmt.Errorf("Error creating TelefonicaOpenCloud networking client: %s", err)
+	}
+	blockStorageClient, err := config.blockStorageV2Client(GetRegion(d, config))
+	if err != nil {
+		return nil, fmt.Errorf("Error creating TelefonicaOpenCloud block storage client: %s", err)
+	}
+
+	server, err := servers.Get(computeClient, d.Id()).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving TelefonicaOpenCloud server %s: %s", d.Id(), err)
+	}
+
+	blockDevices, err := importInstanceBlockDevices(computeClient, blockStorageClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("network", importInstanceNetworks(networkingClient, server))
+	if blockDevices != nil {
+		d.Set("block_device", blockDevices)
+	}
+	d.Set("metadata", server.Metadata)
+	d.Set("stop_before_destroy", false)
+
+	return []*schema.ResourceData{d}, nil
+}
+
 // ServerV2StateRefreshFunc returns a resource.StateRefreshFunc that is used to watch
 // an TelefonicaOpenCloud instance.
 func ServerV2StateRefreshFunc(client *golangsdk.ServiceClient, instanceID string) resource.StateRefreshFunc {
@@ -798,6 +885,15 @@ func ServerV2StateRefreshFunc(client *golangsdk.ServiceClient, instanceID string
 	}
 }
 
+func updateComputeInstanceV2Tags(d *schema.ResourceData, config *Config) error {
+	ecsClient, err := config.computeV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud ECS client: %s", err)
+	}
+
+	return updateResourceTags(d, config, ecsClient, "cloudservers", d.Id())
+}
+
 func resourceInstanceSecGroupsV2(d *schema.ResourceData) []string {
 	rawSecGroups := d.Get("security_groups").(*schema.Set).List()
 	secgroups := make([]string, len(rawSecGroups))
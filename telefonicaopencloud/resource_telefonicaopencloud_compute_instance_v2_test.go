@@ -31,6 +31,8 @@ func TestAccComputeV2Instance_basic(t *testing.T) {
 						"telefonicaopencloud_compute_instance_v2.instance_1", "all_metadata.foo", "bar"),
 					resource.TestCheckResourceAttr(
 						"telefonicaopencloud_compute_instance_v2.instance_1", "availability_zone", OS_AVAILABILITY_ZONE),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_compute_instance_v2.instance_1", "power_state", "active"),
 				),
 			},
 		},
@@ -200,7 +202,67 @@ func TestAccComputeV2Instance_changeFixedIP(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckComputeV2InstanceExists(
 						"telefonicaopencloud_compute_instance_v2.instance_1", &instance1_2),
-					testAccCheckComputeV2InstanceInstanceIDsDoNotMatch(&instance1_1, &instance1_2),
+					testAccCheckComputeV2InstanceInstanceIDsMatch(&instance1_1, &instance1_2),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_compute_instance_v2.instance_1", "network.0.fixed_ip_v4", "10.0.20.25"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccComputeV2Instance_networkHotplug(t *testing.T) {
+	var instance1_1 servers.Server
+	var instance1_2 servers.Server
+	var instance1_3 servers.Server
+	var port string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeV2InstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccComputeV2Instance_networkHotplug_1,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2InstanceExists(
+						"telefonicaopencloud_compute_instance_v2.instance_1", &instance1_1),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_compute_instance_v2.instance_1", "network.#", "1"),
+				),
+			},
+			{
+				Config: testAccComputeV2Instance_networkHotplug_2,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2InstanceExists(
+						"telefonicaopencloud_compute_instance_v2.instance_1", &instance1_2),
+					testAccCheckComputeV2InstanceInstanceIDsMatch(&instance1_1, &instance1_2),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_compute_instance_v2.instance_1", "network.#", "2"),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_compute_instance_v2.instance_1", "network.1.name", "network_1"),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_compute_instance_v2.instance_1", "network.1.fixed_ip_v4", "192.168.199.23"),
+					testAccCheckComputeV2InstanceNetworkPort(
+						"telefonicaopencloud_compute_instance_v2.instance_1", 1, &port),
+				),
+			},
+			{
+				Config: testAccComputeV2Instance_networkHotplug_3,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2InstanceExists(
+						"telefonicaopencloud_compute_instance_v2.instance_1", &instance1_3),
+					testAccCheckComputeV2InstanceInstanceIDsMatch(&instance1_1, &instance1_3),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_compute_instance_v2.instance_1", "network.#", "1"),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_compute_instance_v2.instance_1", "network.0.name", "network_1"),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_compute_instance_v2.instance_1", "network.0.fixed_ip_v4", "192.168.199.23"),
+					// The NIC of network_1 is kept when the first network
+					// is removed.
+					resource.TestCheckResourceAttrPtr(
+						"telefonicaopencloud_compute_instance_v2.instance_1", "network.0.port", &port),
 				),
 			},
 		},
@@ -224,6 +286,45 @@ func TestAccComputeV2Instance_stopBeforeDestroy(t *testing.T) {
 	})
 }
 
+func TestAccComputeV2Instance_powerState(t *testing.T) {
+	var instance servers.Server
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeV2InstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccComputeV2Instance_powerState("shutoff"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2InstanceExists("telefonicaopencloud_compute_instance_v2.instance_1", &instance),
+					testAccCheckComputeV2InstanceStatus(&instance, "SHUTOFF"),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_compute_instance_v2.instance_1", "power_state", "shutoff"),
+				),
+			},
+			{
+				Config: testAccComputeV2Instance_powerState("active"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2InstanceExists("telefonicaopencloud_compute_instance_v2.instance_1", &instance),
+					testAccCheckComputeV2InstanceStatus(&instance, "ACTIVE"),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_compute_instance_v2.instance_1", "power_state", "active"),
+				),
+			},
+			{
+				Config: testAccComputeV2Instance_powerState("reboot"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2InstanceExists("telefonicaopencloud_compute_instance_v2.instance_1", &instance),
+					testAccCheckComputeV2InstanceStatus(&instance, "ACTIVE"),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_compute_instance_v2.instance_1", "power_state", "reboot"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccComputeV2Instance_metadataRemove(t *testing.T) {
 	var instance servers.Server
 
@@ -393,6 +494,17 @@ func testAccCheckComputeV2InstanceNoMetadataKey(
 	}
 }
 
+func testAccCheckComputeV2InstanceStatus(
+	instance *servers.Server, status string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if instance.Status != status {
+			return fmt.Errorf("Bad status for instance %s: expected %s, got %s", instance.ID, status, instance.Status)
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckComputeV2InstanceBootVolumeAttachment(
 	instance *servers.Server) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
@@ -424,6 +536,35 @@ func testAccCheckComputeV2InstanceBootVolumeAttachment(
 	}
 }
 
+// testAccCheckComputeV2InstanceNetworkPort saves the port of a network of an
+// instance.
+func testAccCheckComputeV2InstanceNetworkPort(n string, index int, port *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		*port = rs.Primary.Attributes[fmt.Sprintf("network.%d.port", index)]
+		if *port == "" {
+			return fmt.Errorf("Network %d of %s has no port", index, n)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckComputeV2InstanceInstanceIDsMatch(
+	instance1, instance2 *servers.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if instance1.ID != instance2.ID {
+			return fmt.Errorf("Instance was recreated.")
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckComputeV2InstanceInstanceIDsDoNotMatch(
 	instance1, instance2 *servers.Server) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
@@ -651,6 +792,63 @@ resource "telefonicaopencloud_compute_instance_v2" "instance_1" {
 }
 `, OS_NETWORK_ID)
 
+const testAccComputeV2Instance_networkHotplug_network = `
+resource "telefonicaopencloud_networking_network_v2" "network_1" {
+  name = "network_1"
+  admin_state_up = "true"
+}
+
+resource "telefonicaopencloud_networking_subnet_v2" "subnet_1" {
+  name = "subnet_1"
+  cidr = "192.168.199.0/24"
+  ip_version = 4
+  network_id = "${telefonicaopencloud_networking_network_v2.network_1.id}"
+}
+`
+
+var testAccComputeV2Instance_networkHotplug_1 = fmt.Sprintf(`
+%s
+
+resource "telefonicaopencloud_compute_instance_v2" "instance_1" {
+  name = "instance_1"
+  security_groups = ["default"]
+  network {
+    uuid = "%s"
+  }
+}
+`, testAccComputeV2Instance_networkHotplug_network, OS_NETWORK_ID)
+
+var testAccComputeV2Instance_networkHotplug_2 = fmt.Sprintf(`
+%s
+
+resource "telefonicaopencloud_compute_instance_v2" "instance_1" {
+  name = "instance_1"
+  security_groups = ["default"]
+  network {
+    uuid = "%s"
+  }
+  network {
+    uuid = "${telefonicaopencloud_networking_network_v2.network_1.id}"
+    fixed_ip_v4 = "192.168.199.23"
+  }
+  depends_on = ["telefonicaopencloud_networking_subnet_v2.subnet_1"]
+}
+`, testAccComputeV2Instance_networkHotplug_network, OS_NETWORK_ID)
+
+var testAccComputeV2Instance_networkHotplug_3 = fmt.Sprintf(`
+%s
+
+resource "telefonicaopencloud_compute_instance_v2" "instance_1" {
+  name = "instance_1"
+  security_groups = ["default"]
+  network {
+    uuid = "${telefonicaopencloud_networking_network_v2.network_1.id}"
+    fixed_ip_v4 = "192.168.199.23"
+  }
+  depends_on = ["telefonicaopencloud_networking_subnet_v2.subnet_1"]
+}
+`, testAccComputeV2Instance_networkHotplug_network)
+
 var testAccComputeV2Instance_stopBeforeDestroy = fmt.Sprintf(`
 resource "telefonicaopencloud_compute_instance_v2" "instance_1" {
   name = "instance_1"
@@ -703,3 +901,16 @@ resource "telefonicaopencloud_compute_instance_v2" "instance_1" {
   }
 }
 `, OS_NETWORK_ID)
+
+func testAccComputeV2Instance_powerState(powerState string) string {
+	return fmt.Sprintf(`
+resource "telefonicaopencloud_compute_instance_v2" "instance_1" {
+  name = "instance_1"
+  security_groups = ["default"]
+  power_state = "%s"
+  network {
+    uuid = "%s"
+  }
+}
+`, powerState, OS_NETWORK_ID)
+}
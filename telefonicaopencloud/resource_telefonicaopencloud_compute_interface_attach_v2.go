@@ -0,0 +1,164 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceComputeInterfaceAttachV2() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceComputeInterfaceAttachV2Create,
+		Read:   resourceComputeInterfaceAttachV2Read,
+		Delete: resourceComputeInterfaceAttachV2Delete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"instance_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"port_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"network_id"},
+			},
+
+			"network_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"port_id"},
+			},
+
+			"fixed_ip": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"port_id"},
+			},
+
+			"mac": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceComputeInterfaceAttachV2Create(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	computeClient, err := config.computeV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud compute client: %s", err)
+	}
+
+	instanceId := d.Get("instance_id").(string)
+	attachOpts := computeInterfaceV2CreateOpts{
+		PortID: d.Get("port_id").(string),
+		NetID:  d.Get("network_id").(string),
+	}
+	if attachOpts.PortID == "" && attachOpts.NetID == "" {
+		return fmt.Errorf("One of port_id or network_id must be set")
+	}
+	if fixedIP := d.Get("fixed_ip").(string); fixedIP != "" {
+		if attachOpts.NetID == "" {
+			return fmt.Errorf("network_id must be set when fixed_ip is set")
+		}
+		attachOpts.FixedIPs = []computeInterfaceV2FixedIP{{IPAddress: fixedIP}}
+	}
+
+	attachment, err := attachComputeInterfaceV2(computeClient, instanceId, attachOpts, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Created interface attachment: %#v", attachment)
+
+	// Use the instance ID and port ID as the resource ID.
+	// This is because an interface cannot be retrieved without its instance.
+	d.SetId(fmt.Sprintf("%s/%s", instanceId, attachment.PortID))
+
+	return resourceComputeInterfaceAttachV2Read(d, meta)
+}
+
+func resourceComputeInterfaceAttachV2Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	computeClient, err := config.computeV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud compute client: %s", err)
+	}
+
+	instanceId, portId, err := parseComputeInterfaceAttachmentId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	attachment, err := getComputeInterfaceV2(computeClient, instanceId, portId)
+	if err != nil {
+		return CheckDeleted(d, err, "compute_interface_attach")
+	}
+
+	log.Printf("[DEBUG] Retrieved interface attachment: %#v", attachment)
+
+	d.Set("instance_id", instanceId)
+	d.Set("port_id", attachment.PortID)
+	d.Set("network_id", attachment.NetID)
+	d.Set("mac", attachment.MACAddr)
+	if len(attachment.FixedIPs) > 0 {
+		d.Set("fixed_ip", attachment.FixedIPs[0].IPAddress)
+	}
+	d.Set("region", GetRegion(d, config))
+
+	return nil
+}
+
+func resourceComputeInterfaceAttachV2Delete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	computeClient, err := config.computeV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud compute client: %s", err)
+	}
+
+	instanceId, portId, err := parseComputeInterfaceAttachmentId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	return detachComputeInterfaceV2(computeClient, instanceId, portId, d.Timeout(schema.TimeoutDelete))
+}
+
+func parseComputeInterfaceAttachmentId(id string) (string, string, error) {
+	idParts := strings.Split(id, "/")
+	if len(idParts) < 2 {
+		return "", "", fmt.Errorf("Unable to determine interface attachment ID")
+	}
+
+	instanceId := idParts[0]
+	portId := idParts[1]
+
+	return instanceId, portId, nil
+}
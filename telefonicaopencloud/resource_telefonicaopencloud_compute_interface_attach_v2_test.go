@@ -0,0 +1,219 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccComputeV2InterfaceAttach_basic(t *testing.T) {
+	var ai computeInterfaceV2
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeV2InterfaceAttachDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccComputeV2InterfaceAttach_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2InterfaceAttachExists("telefonicaopencloud_compute_interface_attach_v2.ai_1", &ai),
+				),
+			},
+		},
+	})
+}
+
+func TestAccComputeV2InterfaceAttach_ip(t *testing.T) {
+	var ai computeInterfaceV2
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeV2InterfaceAttachDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccComputeV2InterfaceAttach_ip,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2InterfaceAttachExists("telefonicaopencloud_compute_interface_attach_v2.ai_1", &ai),
+					testAccCheckComputeV2InterfaceAttachIP(&ai, "192.168.199.24"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccComputeV2InterfaceAttach_port(t *testing.T) {
+	var ai computeInterfaceV2
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeV2InterfaceAttachDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccComputeV2InterfaceAttach_port,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2InterfaceAttachExists("telefonicaopencloud_compute_interface_attach_v2.ai_1", &ai),
+					testAccCheckComputeV2InterfaceAttachIP(&ai, "192.168.199.25"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckComputeV2InterfaceAttachDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+	computeClient, err := config.computeV2Client(OS_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud compute client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "telefonicaopencloud_compute_interface_attach_v2" {
+			continue
+		}
+
+		instanceId, portId, err := parseComputeInterfaceAttachmentId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = getComputeInterfaceV2(computeClient, instanceId, portId)
+		if err == nil {
+			return fmt.Errorf("Interface attachment still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckComputeV2InterfaceAttachExists(n string, ai *computeInterfaceV2) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		computeClient, err := config.computeV2Client(OS_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating TelefonicaOpenCloud compute client: %s", err)
+		}
+
+		instanceId, portId, err := parseComputeInterfaceAttachmentId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		found, err := getComputeInterfaceV2(computeClient, instanceId, portId)
+		if err != nil {
+			return err
+		}
+
+		if found.PortID != portId {
+			return fmt.Errorf("InterfaceAttach not found")
+		}
+
+		*ai = *found
+
+		return nil
+	}
+}
+
+func testAccCheckComputeV2InterfaceAttachIP(
+	ai *computeInterfaceV2, ip string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, i := range ai.FixedIPs {
+			if i.IPAddress == ip {
+				return nil
+			}
+		}
+		return fmt.Errorf("Requested ip (%s) does not exist on port", ip)
+	}
+}
+
+const testAccComputeV2InterfaceAttach_network = `
+resource "telefonicaopencloud_networking_network_v2" "network_1" {
+  name = "network_1"
+  admin_state_up = "true"
+}
+
+resource "telefonicaopencloud_networking_subnet_v2" "subnet_1" {
+  name = "subnet_1"
+  cidr = "192.168.199.0/24"
+  ip_version = 4
+  network_id = "${telefonicaopencloud_networking_network_v2.network_1.id}"
+}
+`
+
+var testAccComputeV2InterfaceAttach_basic = fmt.Sprintf(`
+%s
+
+resource "telefonicaopencloud_compute_instance_v2" "instance_1" {
+  name = "instance_1"
+  security_groups = ["default"]
+  network {
+    uuid = "%s"
+  }
+}
+
+resource "telefonicaopencloud_compute_interface_attach_v2" "ai_1" {
+  instance_id = "${telefonicaopencloud_compute_instance_v2.instance_1.id}"
+  network_id = "${telefonicaopencloud_networking_network_v2.network_1.id}"
+  depends_on = ["telefonicaopencloud_networking_subnet_v2.subnet_1"]
+}
+`, testAccComputeV2InterfaceAttach_network, OS_NETWORK_ID)
+
+var testAccComputeV2InterfaceAttach_ip = fmt.Sprintf(`
+%s
+
+resource "telefonicaopencloud_compute_instance_v2" "instance_1" {
+  name = "instance_1"
+  security_groups = ["default"]
+  network {
+    uuid = "%s"
+  }
+}
+
+resource "telefonicaopencloud_compute_interface_attach_v2" "ai_1" {
+  instance_id = "${telefonicaopencloud_compute_instance_v2.instance_1.id}"
+  network_id = "${telefonicaopencloud_networking_network_v2.network_1.id}"
+  fixed_ip = "192.168.199.24"
+  depends_on = ["telefonicaopencloud_networking_subnet_v2.subnet_1"]
+}
+`, testAccComputeV2InterfaceAttach_network, OS_NETWORK_ID)
+
+var testAccComputeV2InterfaceAttach_port = fmt.Sprintf(`
+%s
+
+resource "telefonicaopencloud_networking_port_v2" "port_1" {
+  name = "port_1"
+  admin_state_up = "true"
+  network_id = "${telefonicaopencloud_networking_network_v2.network_1.id}"
+
+  fixed_ip {
+    subnet_id =  "${telefonicaopencloud_networking_subnet_v2.subnet_1.id}"
+    ip_address = "192.168.199.25"
+  }
+}
+
+resource "telefonicaopencloud_compute_instance_v2" "instance_1" {
+  name = "instance_1"
+  security_groups = ["default"]
+  network {
+    uuid = "%s"
+  }
+}
+
+resource "telefonicaopencloud_compute_interface_attach_v2" "ai_1" {
+  instance_id = "${telefonicaopencloud_compute_instance_v2.instance_1.id}"
+  port_id = "${telefonicaopencloud_networking_port_v2.port_1.id}"
+}
+`, testAccComputeV2InterfaceAttach_network, OS_NETWORK_ID)
@@ -21,6 +21,12 @@ func resourceDcsInstanceV1() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -147,6 +153,7 @@ func resourceDcsInstanceV1() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"tags": tagsSchema(),
 		},
 	}
 }
@@ -228,6 +235,10 @@ func resourceDcsInstancesV1Create(d *schema.ResourceData, meta interface{}) erro
 	// Store the instance ID now
 	d.SetId(v.InstanceID)
 
+	if err := updateResourceTags(d, config, dcsV1Client, "dcs", v.InstanceID); err != nil {
+		return err
+	}
+
 	return resourceDcsInstancesV1Read(d, meta)
 }
 
@@ -272,6 +283,12 @@ func resourceDcsInstancesV1Read(d *schema.ResourceData, meta interface{}) error
 	d.Set("maintain_end", v.MaintainEnd)
 	d.Set("access_user", v.AccessUser)
 
+	// DCS lists the tags of an instance below /instances, while they are
+	// changed below /dcs.
+	if err := readResourceTags(d, config, dcsV1Client, "instances", d.Id()); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -281,6 +298,19 @@ func resourceDcsInstancesV1Update(d *schema.ResourceData, meta interface{}) erro
 	if err != nil {
 		return fmt.Errorf("Error updating HuaweiCloud dcs instance client: %s", err)
 	}
+
+	// The capacity can only be increased, check it before anything is updated.
+	if d.HasChange("capacity") {
+		o, n := d.GetChange("capacity")
+		if n.(int) < o.(int) {
+			return fmt.Errorf("Error extending HuaweiCloud Dcs Instance: capacity can only be increased, from %d to %d requested", o.(int), n.(int))
+		}
+	}
+
+	// The changes are saved one by one, so that a failed extension or
+	// password update doesn't leave its new value in the state.
+	d.Partial(true)
+
 	var updateOpts instances.UpdateOpts
 	if d.HasChange("name") {
 		updateOpts.Name = d.Get("name").(string)
@@ -302,11 +332,59 @@ func resourceDcsInstancesV1Update(d *schema.ResourceData, meta interface{}) erro
 		updateOpts.SecurityGroupID = security_group_id
 	}
 
-	err = instances.Update(dcsV1Client, d.Id(), updateOpts).Err
-	if err != nil {
-		return fmt.Errorf("Error updating HuaweiCloud Dcs Instance: %s", err)
+	if updateOpts != (instances.UpdateOpts{}) {
+		err = instances.Update(dcsV1Client, d.Id(), updateOpts).Err
+		if err != nil {
+			return fmt.Errorf("Error updating HuaweiCloud Dcs Instance: %s", err)
+		}
+		d.SetPartial("name")
+		d.SetPartial("description")
+		d.SetPartial("maintain_begin")
+		d.SetPartial("maintain_end")
+		d.SetPartial("security_group_id")
+	}
+
+	if d.HasChange("capacity") {
+		extendOpts := instances.ExtendOpts{
+			NewCapacity: d.Get("capacity").(int),
+		}
+		log.Printf("[DEBUG] Extend Options: %#v", extendOpts)
+		err = instances.Extend(dcsV1Client, d.Id(), extendOpts).Err
+		if err != nil {
+			return fmt.Errorf("Error extending HuaweiCloud Dcs Instance: %s", err)
+		}
+
+		if err := waitForDcsInstanceV1Running(d, dcsV1Client, []string{"EXTENDING"}); err != nil {
+			return err
+		}
+		d.SetPartial("capacity")
 	}
 
+	if d.HasChange("password") {
+		o, n := d.GetChange("password")
+		passwordOpts := instances.UpdatePasswordOpts{
+			OldPassword: o.(string),
+			NewPassword: n.(string),
+		}
+		v, err := instances.UpdatePassword(dcsV1Client, d.Id(), passwordOpts).Extract()
+		if err != nil {
+			return fmt.Errorf("Error updating password of HuaweiCloud Dcs Instance: %s", err)
+		}
+		if v.Result != "Success" {
+			return fmt.Errorf("Error updating password of HuaweiCloud Dcs Instance: %s (%s)", v.Result, v.Message)
+		}
+
+		if err := waitForDcsInstanceV1Running(d, dcsV1Client, []string{"RESTARTING"}); err != nil {
+			return err
+		}
+		d.SetPartial("password")
+	}
+
+	if err := updateResourceTags(d, config, dcsV1Client, "dcs", d.Id()); err != nil {
+		return err
+	}
+
+	d.Partial(false)
 	return resourceDcsInstancesV1Read(d, meta)
 }
 
@@ -351,6 +429,28 @@ func resourceDcsInstancesV1Delete(d *schema.ResourceData, meta interface{}) erro
 	return nil
 }
 
+func waitForDcsInstanceV1Running(d *schema.ResourceData, client *golangsdk.ServiceClient, pending []string) error {
+	log.Printf("[DEBUG] Waiting for instance (%s) to become running", d.Id())
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    pending,
+		Target:     []string{"RUNNING"},
+		Refresh:    DcsInstancesV1StateRefreshFunc(client, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutUpdate),
+		Delay:      10 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err := stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf(
+			"Error waiting for instance (%s) to become running: %s",
+			d.Id(), err)
+	}
+
+	return nil
+}
+
 func DcsInstancesV1StateRefreshFunc(client *golangsdk.ServiceClient, instanceID string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		v, err := instances.Get(client, instanceID).Extract()
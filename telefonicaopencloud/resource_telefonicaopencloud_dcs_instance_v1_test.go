@@ -34,6 +34,37 @@ func TestAccDcsInstancesV1_basic(t *testing.T) {
 	})
 }
 
+func TestAccDcsInstancesV1_update(t *testing.T) {
+	var instance instances.Instance
+	var instanceName = fmt.Sprintf("dcs_instance_%s", acctest.RandString(5))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDcsV1InstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDcsV1Instance_basic(instanceName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDcsV1InstanceExists("telefonicaopencloud_dcs_instance_v1.instance_1", instance),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_dcs_instance_v1.instance_1", "capacity", "2"),
+				),
+			},
+			{
+				Config: testAccDcsV1Instance_update(instanceName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDcsV1InstanceExists("telefonicaopencloud_dcs_instance_v1.instance_1", instance),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_dcs_instance_v1.instance_1", "capacity", "4"),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_dcs_instance_v1.instance_1", "password", "Huawei_test2"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckDcsV1InstanceDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*Config)
 	dcsClient, err := config.dcsV1Client(OS_REGION_NAME)
@@ -118,3 +149,38 @@ func testAccDcsV1Instance_basic(instanceName string) string {
 		}
 	`, instanceName, OS_VPC_ID, OS_NETWORK_ID)
 }
+
+func testAccDcsV1Instance_update(instanceName string) string {
+	return fmt.Sprintf(`
+       resource "telefonicaopencloud_networking_secgroup_v2" "secgroup_1" {
+         name = "secgroup_1"
+         description = "secgroup_1"
+       }
+       data "telefonicaopencloud_dcs_az_v1" "az_1" {
+         name = "AZ1"
+         port = "8004"
+         code = "sa-chile-1a"
+		}
+       data "telefonicaopencloud_dcs_product_v1" "product_1" {
+          spec_code = "dcs.master_standby"
+		}
+		resource "telefonicaopencloud_dcs_instance_v1" "instance_1" {
+			name  = "%s"
+          engine_version = "3.0.7"
+          password = "Huawei_test2"
+          engine = "Redis"
+          capacity = 4
+          vpc_id = "%s"
+          security_group_id = "${telefonicaopencloud_networking_secgroup_v2.secgroup_1.id}"
+          subnet_id = "%s"
+          available_zones = ["${data.telefonicaopencloud_dcs_az_v1.az_1.id}"]
+          product_id = "${data.telefonicaopencloud_dcs_product_v1.product_1.id}"
+          save_days = 1
+          backup_type = "manual"
+          begin_at = "00:00-01:00"
+          period_type = "weekly"
+          backup_at = [1]
+          depends_on      = ["data.telefonicaopencloud_dcs_product_v1.product_1", "telefonicaopencloud_networking_secgroup_v2.secgroup_1"]
+		}
+	`, instanceName, OS_VPC_ID, OS_NETWORK_ID)
+}
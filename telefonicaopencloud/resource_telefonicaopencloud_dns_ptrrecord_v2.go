@@ -0,0 +1,244 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/huaweicloud/golangsdk"
+)
+
+func resourceDNSPtrRecordV2() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDNSPtrRecordV2Create,
+		Read:   resourceDNSPtrRecordV2Read,
+		Update: resourceDNSPtrRecordV2Update,
+		Delete: resourceDNSPtrRecordV2Delete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"floatingip_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"ttl": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"address": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceDNSPtrRecordV2Create(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	region := GetRegion(d, config)
+	dnsClient, err := config.dnsV2Client(region)
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud DNS client: %s", err)
+	}
+
+	setOpts := dnsPtrRecordV2SetOpts{
+		PtrDName:    d.Get("name").(string),
+		Description: d.Get("description").(string),
+		TTL:         d.Get("ttl").(int),
+	}
+
+	id := fmt.Sprintf("%s:%s", region, d.Get("floatingip_id").(string))
+
+	log.Printf("[DEBUG] Create Options: %#v", setOpts)
+	_, err = setDNSPtrRecordV2(dnsClient, id, setOpts)
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud DNS PTR record: %s", err)
+	}
+
+	log.Printf("[DEBUG] Waiting for DNS PTR record (%s) to become available", id)
+	stateConf := &resource.StateChangeConf{
+		Target:     []string{"ACTIVE"},
+		Pending:    []string{"PENDING_CREATE"},
+		Refresh:    waitForDNSPtrRecord(dnsClient, id),
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error waiting for TelefonicaOpenCloud DNS PTR record (%s) to become available: %s", id, err)
+	}
+
+	d.SetId(id)
+
+	log.Printf("[DEBUG] Created TelefonicaOpenCloud DNS PTR record %s", id)
+	return resourceDNSPtrRecordV2Read(d, meta)
+}
+
+func resourceDNSPtrRecordV2Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	region, floatingIPID, err := parseDNSV2PtrRecordID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	dnsClient, err := config.dnsV2Client(region)
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud DNS client: %s", err)
+	}
+
+	n, err := getDNSPtrRecordV2(dnsClient, d.Id())
+	if err != nil {
+		return CheckDeleted(d, err, "ptr_record")
+	}
+
+	if n.PtrDName == "" {
+		log.Printf("[WARN] DNS PTR record %s is no longer set", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	log.Printf("[DEBUG] Retrieved PTR record %s: %#v", d.Id(), n)
+
+	d.Set("name", n.PtrDName)
+	d.Set("description", n.Description)
+	d.Set("ttl", n.TTL)
+	d.Set("address", n.Address)
+	d.Set("floatingip_id", floatingIPID)
+	d.Set("region", region)
+
+	return nil
+}
+
+func resourceDNSPtrRecordV2Update(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	dnsClient, err := config.dnsV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud DNS client: %s", err)
+	}
+
+	if d.HasChange("name") || d.HasChange("description") || d.HasChange("ttl") {
+		setOpts := dnsPtrRecordV2SetOpts{
+			PtrDName:    d.Get("name").(string),
+			Description: d.Get("description").(string),
+			TTL:         d.Get("ttl").(int),
+		}
+
+		log.Printf("[DEBUG] Updating PTR record %s with options: %#v", d.Id(), setOpts)
+		_, err = setDNSPtrRecordV2(dnsClient, d.Id(), setOpts)
+		if err != nil {
+			return fmt.Errorf("Error updating TelefonicaOpenCloud DNS PTR record: %s", err)
+		}
+
+		log.Printf("[DEBUG] Waiting for DNS PTR record (%s) to update", d.Id())
+		stateConf := &resource.StateChangeConf{
+			Target:     []string{"ACTIVE"},
+			Pending:    []string{"PENDING_UPDATE"},
+			Refresh:    waitForDNSPtrRecord(dnsClient, d.Id()),
+			Timeout:    d.Timeout(schema.TimeoutUpdate),
+			Delay:      5 * time.Second,
+			MinTimeout: 3 * time.Second,
+		}
+
+		_, err = stateConf.WaitForState()
+		if err != nil {
+			return fmt.Errorf("Error waiting for TelefonicaOpenCloud DNS PTR record (%s) to update: %s", d.Id(), err)
+		}
+	}
+
+	return resourceDNSPtrRecordV2Read(d, meta)
+}
+
+func resourceDNSPtrRecordV2Delete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	dnsClient, err := config.dnsV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud DNS client: %s", err)
+	}
+
+	err = unsetDNSPtrRecordV2(dnsClient, d.Id())
+	if err != nil {
+		if _, ok := err.(golangsdk.ErrDefault404); ok {
+			log.Printf("[INFO] DNS PTR record %s is already unset", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error deleting TelefonicaOpenCloud DNS PTR record: %s", err)
+	}
+
+	log.Printf("[DEBUG] Waiting for DNS PTR record (%s) to be deleted", d.Id())
+	stateConf := &resource.StateChangeConf{
+		Target:     []string{"DELETED"},
+		Pending:    []string{"ACTIVE", "PENDING_DELETE"},
+		Refresh:    waitForDNSPtrRecord(dnsClient, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error waiting for TelefonicaOpenCloud DNS PTR record (%s) to be deleted: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func waitForDNSPtrRecord(dnsClient *golangsdk.ServiceClient, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		ptr, err := getDNSPtrRecordV2(dnsClient, id)
+		if err != nil {
+			if _, ok := err.(golangsdk.ErrDefault404); ok {
+				return ptr, "DELETED", nil
+			}
+
+			return nil, "", err
+		}
+
+		// An unset PTR record only keeps the address of the floating IP.
+		if ptr.PtrDName == "" {
+			return ptr, "DELETED", nil
+		}
+
+		log.Printf("[DEBUG] TelefonicaOpenCloud DNS PTR record (%s) current status: %s", id, ptr.Status)
+		return ptr, ptr.Status, nil
+	}
+}
+
+func parseDNSV2PtrRecordID(id string) (string, string, error) {
+	parts := strings.SplitN(id, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("Invalid format specified for DNS PTR record. Format must be <region>:<floatingip_id>")
+	}
+	return parts[0], parts[1], nil
+}
@@ -0,0 +1,148 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccDNSV2PtrRecord_basic(t *testing.T) {
+	var ptr dnsPtrRecordV2
+	ptrName := fmt.Sprintf("acpttest-%s.com.", acctest.RandString(5))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckDNS(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDNSV2PtrRecordDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDNSV2PtrRecord_basic(ptrName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDNSV2PtrRecordExists("telefonicaopencloud_dns_ptrrecord_v2.ptr_1", &ptr),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_dns_ptrrecord_v2.ptr_1", "name", ptrName),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_dns_ptrrecord_v2.ptr_1", "description", "a ptr record"),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_dns_ptrrecord_v2.ptr_1", "ttl", "6000"),
+					resource.TestCheckResourceAttrPair(
+						"telefonicaopencloud_dns_ptrrecord_v2.ptr_1", "address",
+						"telefonicaopencloud_vpc_eip_v1.eip_1", "publicip.0.ip_address"),
+				),
+			},
+			{
+				Config: testAccDNSV2PtrRecord_update(ptrName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_dns_ptrrecord_v2.ptr_1", "name", "mail."+ptrName),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_dns_ptrrecord_v2.ptr_1", "description", "an updated ptr record"),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_dns_ptrrecord_v2.ptr_1", "ttl", "3000"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDNSV2PtrRecordDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+	dnsClient, err := config.dnsV2Client(OS_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud DNS client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "telefonicaopencloud_dns_ptrrecord_v2" {
+			continue
+		}
+
+		ptr, err := getDNSPtrRecordV2(dnsClient, rs.Primary.ID)
+		if err == nil && ptr.PtrDName != "" {
+			return fmt.Errorf("PTR record still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckDNSV2PtrRecordExists(n string, ptr *dnsPtrRecordV2) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		dnsClient, err := config.dnsV2Client(OS_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating TelefonicaOpenCloud DNS client: %s", err)
+		}
+
+		found, err := getDNSPtrRecordV2(dnsClient, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if found.ID != rs.Primary.ID {
+			return fmt.Errorf("PTR record not found")
+		}
+
+		*ptr = *found
+
+		return nil
+	}
+}
+
+func testAccDNSV2PtrRecord_basic(ptrName string) string {
+	return fmt.Sprintf(`
+		resource "telefonicaopencloud_vpc_eip_v1" "eip_1" {
+			publicip {
+				type = "5_bgp"
+			}
+			bandwidth {
+				name = "test"
+				size = 8
+				share_type = "PER"
+				charge_mode = "traffic"
+			}
+		}
+
+		resource "telefonicaopencloud_dns_ptrrecord_v2" "ptr_1" {
+			name = "%s"
+			description = "a ptr record"
+			floatingip_id = "${telefonicaopencloud_vpc_eip_v1.eip_1.id}"
+			ttl = 6000
+		}
+	`, ptrName)
+}
+
+func testAccDNSV2PtrRecord_update(ptrName string) string {
+	return fmt.Sprintf(`
+		resource "telefonicaopencloud_vpc_eip_v1" "eip_1" {
+			publicip {
+				type = "5_bgp"
+			}
+			bandwidth {
+				name = "test"
+				size = 8
+				share_type = "PER"
+				charge_mode = "traffic"
+			}
+		}
+
+		resource "telefonicaopencloud_dns_ptrrecord_v2" "ptr_1" {
+			name = "mail.%s"
+			description = "an updated ptr record"
+			floatingip_id = "${telefonicaopencloud_vpc_eip_v1.eip_1.id}"
+			ttl = 3000
+		}
+	`, ptrName)
+}
@@ -0,0 +1,495 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/dns/v2/recordsets"
+	"github.com/huaweicloud/golangsdk/openstack/dns/v2/zones"
+)
+
+func resourceDNSZoneRecordsV2() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDNSZoneRecordsV2Create,
+		Read:   resourceDNSZoneRecordsV2Read,
+		Update: resourceDNSZoneRecordsV2Update,
+		Delete: resourceDNSZoneRecordsV2Delete,
+		Importer: &schema.ResourceImporter{
+			State: resourceDNSZoneRecordsV2Import,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+			"zone_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name_filter": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					if _, err := regexp.Compile(v.(string)); err != nil {
+						errors = append(errors, fmt.Errorf("%q is not a valid regular expression: %s", k, err))
+					}
+					return
+				},
+			},
+			"record": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				ConflictsWith: []string{"zone_file"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+								return ValidateStringList(v, k, []string{"A", "AAAA", "CAA", "CNAME", "MX", "NS", "PTR", "SRV", "TXT"})
+							},
+						},
+						"ttl": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  dnsZoneFileDefaultTTL,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"records": {
+							Type:     schema.TypeSet,
+							Required: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Set:      schema.HashString,
+						},
+					},
+				},
+			},
+			"zone_file": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ConflictsWith:    []string{"record"},
+				DiffSuppressFunc: suppressEquivalentDNSZoneFiles,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					if _, err := parseDNSZoneFile(v.(string)); err != nil {
+						errors = append(errors, fmt.Errorf("%q is not a valid zone file: %s", k, err))
+					}
+					return
+				},
+			},
+		},
+	}
+}
+
+func resourceDNSZoneRecordsV2Create(d *schema.ResourceData, meta interface{}) error {
+	d.SetId(d.Get("zone_id").(string))
+
+	if err := resourceDNSZoneRecordsV2Apply(d, meta, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return err
+	}
+
+	return resourceDNSZoneRecordsV2Read(d, meta)
+}
+
+func resourceDNSZoneRecordsV2Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	dnsClient, err := config.dnsV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud DNS client: %s", err)
+	}
+
+	zone, err := zones.Get(dnsClient, d.Id()).Extract()
+	if err != nil {
+		return CheckDeleted(d, err, "zone")
+	}
+
+	owned, err := listDNSZoneRecordsV2(dnsClient, zone, d.Get("name_filter").(string))
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Retrieved %d record sets of DNS zone %s", len(owned), zone.ID)
+
+	// Zone files are kept as they are read from the API, the diff between
+	// them and the configuration is suppressed when they hold the same records.
+	if d.Get("zone_file").(string) != "" {
+		rrsets := make([]dnsZoneRecordV2, len(owned))
+		for i, rs := range owned {
+			rrsets[i] = dnsZoneRecordV2{Name: rs.Name, Type: rs.Type, TTL: rs.TTL, Records: rs.Records}
+		}
+		d.Set("zone_file", renderDNSZoneFile(rrsets))
+		d.Set("record", nil)
+	} else {
+		records := make([]map[string]interface{}, len(owned))
+		for i, rs := range owned {
+			values := make([]interface{}, len(rs.Records))
+			for j, value := range rs.Records {
+				values[j] = value
+			}
+			records[i] = map[string]interface{}{
+				"name":        rs.Name,
+				"type":        rs.Type,
+				"ttl":         rs.TTL,
+				"description": rs.Description,
+				"records":     schema.NewSet(schema.HashString, values),
+			}
+		}
+		if err := d.Set("record", records); err != nil {
+			return fmt.Errorf("[DEBUG] Error saving record to state for TelefonicaOpenCloud DNS zone (%s): %s", zone.ID, err)
+		}
+	}
+
+	d.Set("zone_id", zone.ID)
+	d.Set("region", GetRegion(d, config))
+
+	return nil
+}
+
+func resourceDNSZoneRecordsV2Update(d *schema.ResourceData, meta interface{}) error {
+	if d.HasChange("record") || d.HasChange("zone_file") {
+		if err := resourceDNSZoneRecordsV2Apply(d, meta, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
+		}
+	}
+
+	return resourceDNSZoneRecordsV2Read(d, meta)
+}
+
+func resourceDNSZoneRecordsV2Delete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	dnsClient, err := config.dnsV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud DNS client: %s", err)
+	}
+
+	zone, err := zones.Get(dnsClient, d.Id()).Extract()
+	if err != nil {
+		if _, ok := err.(golangsdk.ErrDefault404); ok {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error retrieving TelefonicaOpenCloud DNS zone: %s", err)
+	}
+
+	owned, err := listDNSZoneRecordsV2(dnsClient, zone, d.Get("name_filter").(string))
+	if err != nil {
+		return err
+	}
+
+	var deleted []string
+	for _, rs := range owned {
+		log.Printf("[DEBUG] Deleting DNS record set %s (%s %s)", rs.ID, rs.Name, rs.Type)
+		err = recordsets.Delete(dnsClient, zone.ID, rs.ID).ExtractErr()
+		if err != nil {
+			if _, ok := err.(golangsdk.ErrDefault404); ok {
+				continue
+			}
+			return fmt.Errorf("Error deleting TelefonicaOpenCloud DNS record set %s: %s", rs.ID, err)
+		}
+		deleted = append(deleted, rs.ID)
+	}
+
+	if err := waitForDNSZoneRecordsV2(dnsClient, zone.ID, nil, deleted, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceDNSZoneRecordsV2Import(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if parts[0] == "" {
+		return nil, fmt.Errorf("Invalid format specified for DNS zone records. Format must be <zone_id>[/<name_filter>]")
+	}
+
+	d.SetId(parts[0])
+	d.Set("zone_id", parts[0])
+	if len(parts) == 2 {
+		if _, err := regexp.Compile(parts[1]); err != nil {
+			return nil, fmt.Errorf("Invalid name filter %q: %s", parts[1], err)
+		}
+		d.Set("name_filter", parts[1])
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceDNSZoneRecordsV2Apply makes the record sets of the zone match the
+// configuration: record sets missing from the configuration are deleted
+// first, so their names can be reused, then the others are updated or
+// created.
+func resourceDNSZoneRecordsV2Apply(d *schema.ResourceData, meta interface{}, timeout time.Duration) error {
+	config := meta.(*Config)
+	dnsClient, err := config.dnsV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud DNS client: %s", err)
+	}
+
+	zone, err := zones.Get(dnsClient, d.Id()).Extract()
+	if err != nil {
+		return fmt.Errorf("Error retrieving TelefonicaOpenCloud DNS zone: %s", err)
+	}
+
+	wanted, err := resourceDNSZoneRecordsV2Wanted(d)
+	if err != nil {
+		return err
+	}
+
+	nameFilter := d.Get("name_filter").(string)
+	filter := regexp.MustCompile(nameFilter)
+	for _, rrset := range wanted {
+		name := strings.ToLower(rrset.Name)
+		if name != strings.ToLower(zone.Name) && !strings.HasSuffix(name, "."+strings.ToLower(zone.Name)) {
+			return fmt.Errorf("Record set %s %s is not in DNS zone %s", rrset.Name, rrset.Type, zone.Name)
+		}
+		if !filter.MatchString(rrset.Name) {
+			return fmt.Errorf("Record set %s %s doesn't match the name filter %q", rrset.Name, rrset.Type, nameFilter)
+		}
+		if rrset.Type == "NS" && strings.EqualFold(rrset.Name, zone.Name) {
+			return fmt.Errorf("The NS records of DNS zone %s are managed by the DNS service", zone.Name)
+		}
+	}
+
+	owned, err := listDNSZoneRecordsV2(dnsClient, zone, nameFilter)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]recordsets.RecordSet, len(owned))
+	var deleted []string
+	for _, rs := range owned {
+		key := dnsZoneRecordsV2Key(rs.Name, rs.Type)
+		if _, ok := wanted[key]; ok {
+			existing[key] = rs
+			continue
+		}
+
+		log.Printf("[DEBUG] Deleting DNS record set %s (%s %s)", rs.ID, rs.Name, rs.Type)
+		err = recordsets.Delete(dnsClient, zone.ID, rs.ID).ExtractErr()
+		if err != nil {
+			return fmt.Errorf("Error deleting TelefonicaOpenCloud DNS record set %s: %s", rs.ID, err)
+		}
+		deleted = append(deleted, rs.ID)
+	}
+
+	if err := waitForDNSZoneRecordsV2(dnsClient, zone.ID, nil, deleted, timeout); err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(wanted))
+	for key := range wanted {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var changed []string
+	for _, key := range keys {
+		rrset := wanted[key]
+
+		rs, ok := existing[key]
+		if !ok {
+			createOpts := recordsets.CreateOpts{
+				Name:        rrset.Name,
+				Type:        rrset.Type,
+				TTL:         rrset.TTL,
+				Description: rrset.Description,
+				Records:     rrset.Records,
+			}
+
+			log.Printf("[DEBUG] Create Options: %#v", createOpts)
+			n, err := recordsets.Create(dnsClient, zone.ID, createOpts).Extract()
+			if err != nil {
+				return fmt.Errorf("Error creating TelefonicaOpenCloud DNS record set %s %s: %s", rrset.Name, rrset.Type, err)
+			}
+			changed = append(changed, n.ID)
+			continue
+		}
+
+		sortedRecords := func(records []string) []string {
+			s := make([]string, len(records))
+			copy(s, records)
+			sort.Strings(s)
+			return s
+		}
+		if rs.TTL == rrset.TTL && rs.Description == rrset.Description &&
+			reflect.DeepEqual(sortedRecords(rs.Records), sortedRecords(rrset.Records)) {
+			continue
+		}
+
+		updateOpts := recordsets.UpdateOpts{
+			TTL:         rrset.TTL,
+			Description: rrset.Description,
+			Records:     rrset.Records,
+		}
+
+		log.Printf("[DEBUG] Updating record set %s with options: %#v", rs.ID, updateOpts)
+		_, err = recordsets.Update(dnsClient, zone.ID, rs.ID, updateOpts).Extract()
+		if err != nil {
+			return fmt.Errorf("Error updating TelefonicaOpenCloud DNS record set %s: %s", rs.ID, err)
+		}
+		changed = append(changed, rs.ID)
+	}
+
+	return waitForDNSZoneRecordsV2(dnsClient, zone.ID, changed, nil, timeout)
+}
+
+// resourceDNSZoneRecordsV2Wanted returns the record sets of the
+// configuration, either from the record blocks or from the zone file, by
+// name and type.
+func resourceDNSZoneRecordsV2Wanted(d *schema.ResourceData) (map[string]dnsZoneRecordV2, error) {
+	var rrsets []dnsZoneRecordV2
+
+	if zoneFile := d.Get("zone_file").(string); zoneFile != "" {
+		var err error
+		rrsets, err = parseDNSZoneFile(zoneFile)
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing zone file: %s", err)
+		}
+	} else {
+		for _, raw := range d.Get("record").(*schema.Set).List() {
+			r := raw.(map[string]interface{})
+			var records []string
+			for _, value := range r["records"].(*schema.Set).List() {
+				records = append(records, value.(string))
+			}
+			rrsets = append(rrsets, dnsZoneRecordV2{
+				Name:        r["name"].(string),
+				Type:        r["type"].(string),
+				TTL:         r["ttl"].(int),
+				Description: r["description"].(string),
+				Records:     records,
+			})
+		}
+	}
+
+	wanted := make(map[string]dnsZoneRecordV2, len(rrsets))
+	for _, rrset := range rrsets {
+		key := dnsZoneRecordsV2Key(rrset.Name, rrset.Type)
+		if _, ok := wanted[key]; ok {
+			return nil, fmt.Errorf("Record set %s %s is defined more than once", rrset.Name, rrset.Type)
+		}
+		wanted[key] = rrset
+	}
+
+	return wanted, nil
+}
+
+func dnsZoneRecordsV2Key(name, rrType string) string {
+	return strings.ToLower(name) + " " + strings.ToUpper(rrType)
+}
+
+// listDNSZoneRecordsV2 lists the record sets of a zone matching the name
+// filter. The SOA and NS records of the zone itself belong to the DNS service
+// and are never returned.
+func listDNSZoneRecordsV2(dnsClient *golangsdk.ServiceClient, zone *zones.Zone, nameFilter string) ([]recordsets.RecordSet, error) {
+	allPages, err := recordsets.ListByZone(dnsClient, zone.ID, nil).AllPages()
+	if err != nil {
+		return nil, fmt.Errorf("Unable to retrieve TelefonicaOpenCloud DNS record sets: %s", err)
+	}
+
+	allRecordSets, err := recordsets.ExtractRecordSets(allPages)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to extract TelefonicaOpenCloud DNS record sets: %s", err)
+	}
+
+	filter := regexp.MustCompile(nameFilter)
+	var owned []recordsets.RecordSet
+	for _, rs := range allRecordSets {
+		if rs.Type == "SOA" || (rs.Type == "NS" && strings.EqualFold(rs.Name, zone.Name)) {
+			continue
+		}
+		if !filter.MatchString(rs.Name) {
+			continue
+		}
+		owned = append(owned, rs)
+	}
+
+	return owned, nil
+}
+
+// waitForDNSZoneRecordsV2 waits until the changed record sets of a zone are
+// active and the deleted ones are gone.
+func waitForDNSZoneRecordsV2(dnsClient *golangsdk.ServiceClient, zoneID string, changed, deleted []string, timeout time.Duration) error {
+	if len(changed) == 0 && len(deleted) == 0 {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Waiting for %d DNS record sets to become available and %d to be deleted", len(changed), len(deleted))
+	stateConf := &resource.StateChangeConf{
+		Target:     []string{"ACTIVE"},
+		Pending:    []string{"PENDING"},
+		Refresh:    dnsZoneRecordsV2StateRefreshFunc(dnsClient, zoneID, changed, deleted),
+		Timeout:    timeout,
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err := stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error waiting for TelefonicaOpenCloud DNS record sets of zone %s: %s", zoneID, err)
+	}
+	return nil
+}
+
+func dnsZoneRecordsV2StateRefreshFunc(dnsClient *golangsdk.ServiceClient, zoneID string, changed, deleted []string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		allPages, err := recordsets.ListByZone(dnsClient, zoneID, nil).AllPages()
+		if err != nil {
+			return nil, "", err
+		}
+		allRecordSets, err := recordsets.ExtractRecordSets(allPages)
+		if err != nil {
+			return nil, "", err
+		}
+
+		status := make(map[string]string, len(allRecordSets))
+		for _, rs := range allRecordSets {
+			status[rs.ID] = rs.Status
+		}
+
+		for _, id := range deleted {
+			if _, ok := status[id]; ok {
+				log.Printf("[DEBUG] TelefonicaOpenCloud DNS record set (%s) is not deleted yet", id)
+				return allRecordSets, "PENDING", nil
+			}
+		}
+		for _, id := range changed {
+			switch status[id] {
+			case "ACTIVE":
+			case "PENDING", "":
+				log.Printf("[DEBUG] TelefonicaOpenCloud DNS record set (%s) current status: %s", id, status[id])
+				return allRecordSets, "PENDING", nil
+			default:
+				return allRecordSets, status[id], fmt.Errorf("DNS record set %s went into status %s", id, status[id])
+			}
+		}
+
+		return allRecordSets, "ACTIVE", nil
+	}
+}
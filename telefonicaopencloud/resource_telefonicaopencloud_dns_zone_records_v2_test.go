@@ -0,0 +1,330 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+
+	"github.com/huaweicloud/golangsdk/openstack/dns/v2/recordsets"
+)
+
+func TestAccDNSV2ZoneRecords_basic(t *testing.T) {
+	zoneName := randomZoneName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckDNS(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDNSV2ZoneRecordsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDNSV2ZoneRecords_basic(zoneName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDNSV2ZoneRecordsMatch("telefonicaopencloud_dns_zone_records_v2.records_1",
+						"www A 3000 10.1.0.1,10.1.0.2", "mail A 300 10.1.0.3", "ftp CNAME 300 www"),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_dns_zone_records_v2.records_1", "record.#", "3"),
+				),
+			},
+			{
+				Config: testAccDNSV2ZoneRecords_update(zoneName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDNSV2ZoneRecordsMatch("telefonicaopencloud_dns_zone_records_v2.records_1",
+						"www A 6000 10.1.0.1", "ftp A 300 10.1.0.4", "txt TXT 300 \"hello\""),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_dns_zone_records_v2.records_1", "record.#", "3"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDNSV2ZoneRecords_nameFilter(t *testing.T) {
+	zoneName := randomZoneName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckDNS(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDNSV2ZoneRecordsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDNSV2ZoneRecords_nameFilter(zoneName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDNSV2ZoneRecordsMatch("telefonicaopencloud_dns_zone_records_v2.records_1",
+						"www A 300 10.1.0.1", "a.app A 300 10.1.1.1", "b.app A 300 10.1.1.2"),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_dns_zone_records_v2.records_1", "record.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDNSV2ZoneRecords_zoneFile(t *testing.T) {
+	zoneName := randomZoneName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckDNS(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDNSV2ZoneRecordsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDNSV2ZoneRecords_zoneFile(zoneName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDNSV2ZoneRecordsMatch("telefonicaopencloud_dns_zone_records_v2.records_1",
+						"www A 600 10.1.0.1,10.1.0.2", "mail A 3600 10.1.0.3", " MX 3600 10 mail"),
+				),
+			},
+			{
+				Config: testAccDNSV2ZoneRecords_zoneFileUpdate(zoneName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDNSV2ZoneRecordsMatch("telefonicaopencloud_dns_zone_records_v2.records_1",
+						"www A 600 10.1.0.2", " MX 3600 10 www"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDNSV2ZoneRecordsDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+	dnsClient, err := config.dnsV2Client(OS_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud DNS client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "telefonicaopencloud_dns_zone_records_v2" {
+			continue
+		}
+
+		allPages, err := recordsets.ListByZone(dnsClient, rs.Primary.ID, nil).AllPages()
+		if err != nil {
+			// The zone is deleted along with its record sets.
+			continue
+		}
+		allRecordSets, err := recordsets.ExtractRecordSets(allPages)
+		if err != nil {
+			return err
+		}
+		for _, recordset := range allRecordSets {
+			if recordset.Type != "SOA" && recordset.Type != "NS" {
+				return fmt.Errorf("Record set %s still exists", recordset.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+// testAccCheckDNSV2ZoneRecordsMatch checks the record sets of the zone. Each
+// one is given as "<name> <type> <ttl> <records>", with the name relative to
+// the zone and the records separated by commas.
+func testAccCheckDNSV2ZoneRecordsMatch(n string, expected ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		dnsClient, err := config.dnsV2Client(OS_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating TelefonicaOpenCloud DNS client: %s", err)
+		}
+
+		allPages, err := recordsets.ListByZone(dnsClient, rs.Primary.ID, nil).AllPages()
+		if err != nil {
+			return err
+		}
+		allRecordSets, err := recordsets.ExtractRecordSets(allPages)
+		if err != nil {
+			return err
+		}
+
+		var found []string
+		for _, recordset := range allRecordSets {
+			if recordset.Type == "SOA" || recordset.Type == "NS" {
+				continue
+			}
+			name := strings.TrimSuffix(strings.TrimSuffix(recordset.Name, recordset.ZoneName), ".")
+			records := make([]string, len(recordset.Records))
+			for i, record := range recordset.Records {
+				records[i] = strings.TrimSuffix(strings.TrimSuffix(record, recordset.ZoneName), ".")
+			}
+			sort.Strings(records)
+			found = append(found, fmt.Sprintf("%s %s %d %s", name, recordset.Type, recordset.TTL, strings.Join(records, ",")))
+		}
+
+		sort.Strings(found)
+		sort.Strings(expected)
+		if strings.Join(found, "\n") != strings.Join(expected, "\n") {
+			return fmt.Errorf("Expected record sets:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(found, "\n"))
+		}
+
+		return nil
+	}
+}
+
+func testAccDNSV2ZoneRecords_basic(zoneName string) string {
+	return fmt.Sprintf(`
+		resource "telefonicaopencloud_dns_zone_v2" "zone_1" {
+			name = "%s"
+			email = "email2@example.com"
+			description = "a zone"
+			ttl = 6000
+			type = "PRIMARY"
+		}
+
+		resource "telefonicaopencloud_dns_zone_records_v2" "records_1" {
+			zone_id = "${telefonicaopencloud_dns_zone_v2.zone_1.id}"
+
+			record {
+				name = "www.%s"
+				type = "A"
+				ttl = 3000
+				records = ["10.1.0.1", "10.1.0.2"]
+			}
+
+			record {
+				name = "mail.%s"
+				type = "A"
+				records = ["10.1.0.3"]
+			}
+
+			record {
+				name = "ftp.%s"
+				type = "CNAME"
+				description = "file transfer"
+				records = ["www.%s"]
+			}
+		}
+	`, zoneName, zoneName, zoneName, zoneName, zoneName)
+}
+
+func testAccDNSV2ZoneRecords_update(zoneName string) string {
+	return fmt.Sprintf(`
+		resource "telefonicaopencloud_dns_zone_v2" "zone_1" {
+			name = "%s"
+			email = "email2@example.com"
+			description = "a zone"
+			ttl = 6000
+			type = "PRIMARY"
+		}
+
+		resource "telefonicaopencloud_dns_zone_records_v2" "records_1" {
+			zone_id = "${telefonicaopencloud_dns_zone_v2.zone_1.id}"
+
+			record {
+				name = "www.%s"
+				type = "A"
+				ttl = 6000
+				records = ["10.1.0.1"]
+			}
+
+			record {
+				name = "ftp.%s"
+				type = "A"
+				records = ["10.1.0.4"]
+			}
+
+			record {
+				name = "txt.%s"
+				type = "TXT"
+				records = ["\"hello\""]
+			}
+		}
+	`, zoneName, zoneName, zoneName, zoneName)
+}
+
+func testAccDNSV2ZoneRecords_nameFilter(zoneName string) string {
+	return fmt.Sprintf(`
+		resource "telefonicaopencloud_dns_zone_v2" "zone_1" {
+			name = "%s"
+			email = "email2@example.com"
+			description = "a zone"
+			ttl = 6000
+			type = "PRIMARY"
+		}
+
+		resource "telefonicaopencloud_dns_recordset_v2" "recordset_1" {
+			zone_id = "${telefonicaopencloud_dns_zone_v2.zone_1.id}"
+			name = "www.%s"
+			type = "A"
+			ttl = 300
+			records = ["10.1.0.1"]
+		}
+
+		resource "telefonicaopencloud_dns_zone_records_v2" "records_1" {
+			zone_id = "${telefonicaopencloud_dns_recordset_v2.recordset_1.zone_id}"
+			name_filter = "\\.app\\.%s$"
+
+			record {
+				name = "a.app.%s"
+				type = "A"
+				records = ["10.1.1.1"]
+			}
+
+			record {
+				name = "b.app.%s"
+				type = "A"
+				records = ["10.1.1.2"]
+			}
+		}
+	`, zoneName, zoneName, strings.Replace(zoneName, ".", "\\\\.", -1), zoneName, zoneName)
+}
+
+func testAccDNSV2ZoneRecords_zoneFile(zoneName string) string {
+	return fmt.Sprintf(`
+		resource "telefonicaopencloud_dns_zone_v2" "zone_1" {
+			name = "%s"
+			email = "email2@example.com"
+			description = "a zone"
+			ttl = 6000
+			type = "PRIMARY"
+		}
+
+		resource "telefonicaopencloud_dns_zone_records_v2" "records_1" {
+			zone_id = "${telefonicaopencloud_dns_zone_v2.zone_1.id}"
+			zone_file = <<EOF
+$ORIGIN %s
+$TTL 1h
+@    IN SOA ns1.example.com. admin.example.com. ( 1 7200 3600 1209600 300 )
+     IN NS  ns1.example.com.
+     IN MX  10 mail
+www  600 IN A 10.1.0.1
+     600 IN A 10.1.0.2
+mail IN A   10.1.0.3 ; the mail server
+EOF
+		}
+	`, zoneName, zoneName)
+}
+
+func testAccDNSV2ZoneRecords_zoneFileUpdate(zoneName string) string {
+	return fmt.Sprintf(`
+		resource "telefonicaopencloud_dns_zone_v2" "zone_1" {
+			name = "%s"
+			email = "email2@example.com"
+			description = "a zone"
+			ttl = 6000
+			type = "PRIMARY"
+		}
+
+		resource "telefonicaopencloud_dns_zone_records_v2" "records_1" {
+			zone_id = "${telefonicaopencloud_dns_zone_v2.zone_1.id}"
+			zone_file = <<EOF
+$ORIGIN %s
+$TTL 1h
+@    IN MX  10 www
+www  600 IN A 10.1.0.2
+EOF
+		}
+	`, zoneName, zoneName)
+}
@@ -1,6 +1,7 @@
 package telefonicaopencloud
 
 import (
+	"bytes"
 	"fmt"
 	"log"
 	"time"
@@ -8,6 +9,7 @@ import (
 	"github.com/huaweicloud/golangsdk"
 	"github.com/huaweicloud/golangsdk/openstack/dns/v2/zones"
 
+	"github.com/hashicorp/terraform/helper/hashcode"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 )
@@ -79,6 +81,33 @@ func resourceDNSZoneV2() *schema.Resource {
 				Optional: true,
 				ForceNew: true,
 			},
+			"zone_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					return ValidateStringList(v, k, []string{"public", "private"})
+				},
+			},
+			"router": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"router_id": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"router_region": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+					},
+				},
+				Set: resourceDNSZoneV2RouterHash,
+			},
 		},
 	}
 }
@@ -102,8 +131,17 @@ func resourceDNSZoneV2Create(d *schema.ResourceData, meta interface{}) error {
 		attrs[k] = v.(string)
 	}
 
+	zoneType := d.Get("zone_type").(string)
+	routers := resourceDNSZoneV2Routers(d.Get("router").(*schema.Set), GetRegion(d, config))
+	if zoneType == "private" && len(routers) == 0 {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud DNS zone: a private zone needs at least one router")
+	}
+	if zoneType != "private" && len(routers) > 0 {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud DNS zone: only private zones can be associated with routers")
+	}
+
 	createOpts := ZoneCreateOpts{
-		zones.CreateOpts{
+		CreateOpts: zones.CreateOpts{
 			Name:        d.Get("name").(string),
 			Type:        d.Get("type").(string),
 			Attributes:  attrs,
@@ -112,7 +150,14 @@ func resourceDNSZoneV2Create(d *schema.ResourceData, meta interface{}) error {
 			Description: d.Get("description").(string),
 			Masters:     masters,
 		},
-		MapValueSpecs(d),
+		ZoneType:   zoneType,
+		ValueSpecs: MapValueSpecs(d),
+	}
+
+	// A private zone is created with its first router, the others are
+	// associated once the zone is available.
+	if len(routers) > 0 {
+		createOpts.Router = &routers[0]
 	}
 
 	log.Printf("[DEBUG] Create Options: %#v", createOpts)
@@ -134,6 +179,15 @@ func resourceDNSZoneV2Create(d *schema.ResourceData, meta interface{}) error {
 	_, err = stateConf.WaitForState()
 
 	d.SetId(n.ID)
+	if err != nil {
+		return fmt.Errorf("Error waiting for TelefonicaOpenCloud DNS Zone (%s) to become available: %s", n.ID, err)
+	}
+
+	if len(routers) > 1 {
+		if err := resourceDNSZoneV2UpdateRouters(dnsClient, n.ID, routers[1:], nil, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return err
+		}
+	}
 
 	log.Printf("[DEBUG] Created TelefonicaOpenCloud DNS Zone %s: %#v", n.ID, n)
 	return resourceDNSZoneV2Read(d, meta)
@@ -160,8 +214,19 @@ func resourceDNSZoneV2Read(d *schema.ResourceData, meta interface{}) error {
 	d.Set("type", n.Type)
 	d.Set("attributes", n.Attributes)
 	d.Set("masters", n.Masters)
+	d.Set("zone_type", n.ZoneType)
 	d.Set("region", GetRegion(d, config))
 
+	if n.ZoneType == "private" {
+		routers, err := getDNSZoneV2Routers(dnsClient, d.Id())
+		if err != nil {
+			return fmt.Errorf("Error retrieving routers of TelefonicaOpenCloud DNS Zone: %s", err)
+		}
+		if err := d.Set("router", flattenDNSZoneV2Routers(routers)); err != nil {
+			return fmt.Errorf("[DEBUG] Error saving router to state for TelefonicaOpenCloud DNS Zone (%s): %s", d.Id(), err)
+		}
+	}
+
 	return nil
 }
 
@@ -191,24 +256,49 @@ func resourceDNSZoneV2Update(d *schema.ResourceData, meta interface{}) error {
 		updateOpts.Description = d.Get("description").(string)
 	}
 
-	log.Printf("[DEBUG] Updating Zone %s with options: %#v", d.Id(), updateOpts)
+	if d.HasChange("email") || d.HasChange("ttl") || d.HasChange("masters") || d.HasChange("description") {
+		log.Printf("[DEBUG] Updating Zone %s with options: %#v", d.Id(), updateOpts)
 
-	_, err = zones.Update(dnsClient, d.Id(), updateOpts).Extract()
-	if err != nil {
-		return fmt.Errorf("Error updating TelefonicaOpenCloud DNS Zone: %s", err)
-	}
+		_, err = zones.Update(dnsClient, d.Id(), updateOpts).Extract()
+		if err != nil {
+			return fmt.Errorf("Error updating TelefonicaOpenCloud DNS Zone: %s", err)
+		}
 
-	log.Printf("[DEBUG] Waiting for DNS Zone (%s) to update", d.Id())
-	stateConf := &resource.StateChangeConf{
-		Target:     []string{"ACTIVE"},
-		Pending:    []string{"PENDING"},
-		Refresh:    waitForDNSZone(dnsClient, d.Id()),
-		Timeout:    d.Timeout(schema.TimeoutUpdate),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
+		log.Printf("[DEBUG] Waiting for DNS Zone (%s) to update", d.Id())
+		stateConf := &resource.StateChangeConf{
+			Target:     []string{"ACTIVE"},
+			Pending:    []string{"PENDING"},
+			Refresh:    waitForDNSZone(dnsClient, d.Id()),
+			Timeout:    d.Timeout(schema.TimeoutUpdate),
+			Delay:      5 * time.Second,
+			MinTimeout: 3 * time.Second,
+		}
+
+		_, err = stateConf.WaitForState()
+		if err != nil {
+			return fmt.Errorf("Error waiting for TelefonicaOpenCloud DNS Zone (%s) to update: %s", d.Id(), err)
+		}
 	}
 
-	_, err = stateConf.WaitForState()
+	if d.HasChange("router") {
+		if d.Get("zone_type").(string) != "private" {
+			return fmt.Errorf("Error updating TelefonicaOpenCloud DNS Zone: only private zones can be associated with routers")
+		}
+
+		o, n := d.GetChange("router")
+		oldRouters, newRouters := o.(*schema.Set), n.(*schema.Set)
+		if newRouters.Len() == 0 {
+			return fmt.Errorf("Error updating TelefonicaOpenCloud DNS Zone: a private zone needs at least one router")
+		}
+
+		// Routers are associated first, so the zone always keeps one.
+		region := GetRegion(d, config)
+		associate := resourceDNSZoneV2Routers(newRouters.Difference(oldRouters), region)
+		disassociate := resourceDNSZoneV2Routers(oldRouters.Difference(newRouters), region)
+		if err := resourceDNSZoneV2UpdateRouters(dnsClient, d.Id(), associate, disassociate, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
+		}
+	}
 
 	return resourceDNSZoneV2Read(d, meta)
 }
@@ -274,3 +364,105 @@ func waitForDNSZone(dnsClient *golangsdk.ServiceClient, zoneId string) resource.
 		return zone, zone.Status, nil
 	}
 }
+
+func resourceDNSZoneV2RouterHash(v interface{}) int {
+	var buf bytes.Buffer
+	m := v.(map[string]interface{})
+	buf.WriteString(fmt.Sprintf("%s-", m["router_id"].(string)))
+	return hashcode.String(buf.String())
+}
+
+// resourceDNSZoneV2Routers converts a set of routers of the configuration.
+// Routers without a region are in the region of the zone.
+func resourceDNSZoneV2Routers(set *schema.Set, region string) []dnsZoneV2Router {
+	routers := make([]dnsZoneV2Router, 0, set.Len())
+	for _, raw := range set.List() {
+		r := raw.(map[string]interface{})
+		router := dnsZoneV2Router{
+			RouterID:     r["router_id"].(string),
+			RouterRegion: r["router_region"].(string),
+		}
+		if router.RouterRegion == "" {
+			router.RouterRegion = region
+		}
+		routers = append(routers, router)
+	}
+	return routers
+}
+
+func flattenDNSZoneV2Routers(routers []dnsZoneV2Router) []map[string]interface{} {
+	result := make([]map[string]interface{}, len(routers))
+	for i, router := range routers {
+		result[i] = map[string]interface{}{
+			"router_id":     router.RouterID,
+			"router_region": router.RouterRegion,
+		}
+	}
+	return result
+}
+
+// resourceDNSZoneV2UpdateRouters associates and disassociates routers of a
+// private zone, and waits for the changes to be done.
+func resourceDNSZoneV2UpdateRouters(dnsClient *golangsdk.ServiceClient, zoneID string, associate, disassociate []dnsZoneV2Router, timeout time.Duration) error {
+	for _, router := range associate {
+		log.Printf("[DEBUG] Associating router %s with DNS Zone %s", router.RouterID, zoneID)
+		if err := associateDNSZoneV2Router(dnsClient, zoneID, router); err != nil {
+			return fmt.Errorf("Error associating router %s with TelefonicaOpenCloud DNS Zone: %s", router.RouterID, err)
+		}
+	}
+
+	for _, router := range disassociate {
+		log.Printf("[DEBUG] Disassociating router %s from DNS Zone %s", router.RouterID, zoneID)
+		if err := disassociateDNSZoneV2Router(dnsClient, zoneID, router); err != nil {
+			return fmt.Errorf("Error disassociating router %s from TelefonicaOpenCloud DNS Zone: %s", router.RouterID, err)
+		}
+	}
+
+	log.Printf("[DEBUG] Waiting for the routers of DNS Zone (%s) to update", zoneID)
+	stateConf := &resource.StateChangeConf{
+		Target:     []string{"ACTIVE"},
+		Pending:    []string{"PENDING"},
+		Refresh:    waitForDNSZoneRouters(dnsClient, zoneID, associate, disassociate),
+		Timeout:    timeout,
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err := stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error waiting for the routers of TelefonicaOpenCloud DNS Zone (%s) to update: %s", zoneID, err)
+	}
+	return nil
+}
+
+func waitForDNSZoneRouters(dnsClient *golangsdk.ServiceClient, zoneID string, associated, disassociated []dnsZoneV2Router) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		routers, err := getDNSZoneV2Routers(dnsClient, zoneID)
+		if err != nil {
+			return nil, "", err
+		}
+
+		status := make(map[string]string, len(routers))
+		for _, router := range routers {
+			status[router.RouterID] = router.Status
+		}
+
+		for _, router := range disassociated {
+			if _, ok := status[router.RouterID]; ok {
+				return routers, "PENDING", nil
+			}
+		}
+		for _, router := range associated {
+			switch status[router.RouterID] {
+			case "ACTIVE":
+			case "PENDING", "":
+				return routers, "PENDING", nil
+			default:
+				return routers, status[router.RouterID], fmt.Errorf("router %s went into status %s", router.RouterID, status[router.RouterID])
+			}
+		}
+
+		log.Printf("[DEBUG] TelefonicaOpenCloud DNS Zone (%s) routers are up to date", zoneID)
+		return routers, "ACTIVE", nil
+	}
+}
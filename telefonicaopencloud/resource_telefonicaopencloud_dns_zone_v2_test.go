@@ -85,6 +85,42 @@ func TestAccDNSV2Zone_timeout(t *testing.T) {
 	})
 }
 
+func TestAccDNSV2Zone_private(t *testing.T) {
+	var zone zones.Zone
+	var zoneName = fmt.Sprintf("ACPTTEST%s.com.", acctest.RandString(5))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckDNS(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDNSV2ZoneDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDNSV2Zone_private(zoneName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDNSV2ZoneExists("telefonicaopencloud_dns_zone_v2.zone_1", &zone),
+					resource.TestCheckResourceAttr("telefonicaopencloud_dns_zone_v2.zone_1", "zone_type", "private"),
+					testAccCheckDNSV2ZoneRouters("telefonicaopencloud_dns_zone_v2.zone_1",
+						"telefonicaopencloud_vpc_v1.vpc_1"),
+				),
+			},
+			{
+				Config: testAccDNSV2Zone_privateUpdate(zoneName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDNSV2ZoneRouters("telefonicaopencloud_dns_zone_v2.zone_1",
+						"telefonicaopencloud_vpc_v1.vpc_1", "telefonicaopencloud_vpc_v1.vpc_2"),
+				),
+			},
+			{
+				Config: testAccDNSV2Zone_privateMove(zoneName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDNSV2ZoneRouters("telefonicaopencloud_dns_zone_v2.zone_1",
+						"telefonicaopencloud_vpc_v1.vpc_2"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckDNSV2ZoneDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*Config)
 	dnsClient, err := config.dnsV2Client(OS_REGION_NAME)
@@ -186,3 +222,116 @@ func testAccDNSV2Zone_timeout(zoneName string) string {
 		}
 	`, zoneName)
 }
+
+// testAccCheckDNSV2ZoneRouters checks that the private zone is associated with
+// exactly the given VPCs.
+func testAccCheckDNSV2ZoneRouters(n string, vpcs ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		dnsClient, err := config.dnsV2Client(OS_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating TelefonicaOpenCloud DNS client: %s", err)
+		}
+
+		routers, err := getDNSZoneV2Routers(dnsClient, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if len(routers) != len(vpcs) {
+			return fmt.Errorf("Expected %d routers, got %d", len(vpcs), len(routers))
+		}
+
+		for _, vpc := range vpcs {
+			vpcRs, ok := s.RootModule().Resources[vpc]
+			if !ok {
+				return fmt.Errorf("Not found: %s", vpc)
+			}
+
+			found := false
+			for _, router := range routers {
+				if router.RouterID == vpcRs.Primary.ID {
+					found = true
+				}
+			}
+			if !found {
+				return fmt.Errorf("Router %s is not associated with zone %s", vpcRs.Primary.ID, rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
+
+const testAccDNSV2Zone_vpcs = `
+resource "telefonicaopencloud_vpc_v1" "vpc_1" {
+  name = "vpc_test"
+  cidr = "192.168.0.0/16"
+}
+
+resource "telefonicaopencloud_vpc_v1" "vpc_2" {
+  name = "vpc_test1"
+  cidr = "172.16.0.0/16"
+}
+`
+
+func testAccDNSV2Zone_private(zoneName string) string {
+	return fmt.Sprintf(`
+		%s
+
+		resource "telefonicaopencloud_dns_zone_v2" "zone_1" {
+			name = "%s"
+			email = "email1@example.com"
+			ttl = 3000
+			zone_type = "private"
+
+			router {
+				router_id = "${telefonicaopencloud_vpc_v1.vpc_1.id}"
+			}
+		}
+	`, testAccDNSV2Zone_vpcs, zoneName)
+}
+
+func testAccDNSV2Zone_privateUpdate(zoneName string) string {
+	return fmt.Sprintf(`
+		%s
+
+		resource "telefonicaopencloud_dns_zone_v2" "zone_1" {
+			name = "%s"
+			email = "email1@example.com"
+			ttl = 3000
+			zone_type = "private"
+
+			router {
+				router_id = "${telefonicaopencloud_vpc_v1.vpc_1.id}"
+			}
+
+			router {
+				router_id = "${telefonicaopencloud_vpc_v1.vpc_2.id}"
+				router_region = "%s"
+			}
+		}
+	`, testAccDNSV2Zone_vpcs, zoneName, OS_REGION_NAME)
+}
+
+func testAccDNSV2Zone_privateMove(zoneName string) string {
+	return fmt.Sprintf(`
+		%s
+
+		resource "telefonicaopencloud_dns_zone_v2" "zone_1" {
+			name = "%s"
+			email = "email1@example.com"
+			ttl = 3000
+			zone_type = "private"
+
+			router {
+				router_id = "${telefonicaopencloud_vpc_v1.vpc_2.id}"
+			}
+		}
+	`, testAccDNSV2Zone_vpcs, zoneName)
+}
@@ -3,6 +3,7 @@ package telefonicaopencloud
 import (
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform/helper/resource"
@@ -20,6 +21,10 @@ func resourceELBBackendECS() *schema.Resource {
 		Read:   resourceELBBackendECSRead,
 		Delete: resourceELBBackendECSDelete,
 
+		Importer: &schema.ResourceImporter{
+			State: resourceELBBackendECSImport,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Update: schema.DefaultTimeout(10 * time.Minute),
@@ -202,3 +207,37 @@ func resourceELBBackendECSDelete(d *schema.ResourceData, meta interface{}) error
 	}
 	return nil
 }
+
+// resourceELBBackendECSImport imports a backend member by its
+// "<listener_id>/<server_id>" identifier, since the member ID is only known
+// to the listener it belongs to.
+func resourceELBBackendECSImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("Invalid format specified for %s. Format must be <listener_id>/<server_id>", nameELBBackend)
+	}
+	lId, serverId := parts[0], parts[1]
+
+	config := meta.(*Config)
+	networkingClient, err := chooseELBClient(d, config)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating TelefonicaOpenCloud networking client: %s", err)
+	}
+
+	var backends []backendecs.Backend
+	url := networkingClient.ServiceURL(networkingClient.ProjectID, "elbaas", "listeners", lId, "members")
+	_, err = networkingClient.Get(url, &backends, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error listing the members of %s %s: %s", nameELBListener, lId, err)
+	}
+
+	for _, b := range backends {
+		if b.ServerID == serverId {
+			d.SetId(b.ID)
+			d.Set("listener_id", lId)
+			return []*schema.ResourceData{d}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("Server %s is not a member of %s %s", serverId, nameELBListener, lId)
+}
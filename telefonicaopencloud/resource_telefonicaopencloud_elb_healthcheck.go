@@ -20,6 +20,10 @@ func resourceELBHealthCheck() *schema.Resource {
 		Update: resourceELBHealthCheckUpdate,
 		Delete: resourceELBHealthCheckDelete,
 
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Update: schema.DefaultTimeout(10 * time.Minute),
@@ -20,6 +20,10 @@ func resourceELBListener() *schema.Resource {
 		Update: resourceELBListenerUpdate,
 		Delete: resourceELBListenerDelete,
 
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Update: schema.DefaultTimeout(10 * time.Minute),
@@ -218,9 +222,13 @@ func resourceELBListenerRead(d *schema.ResourceData, meta interface{}) error {
 	}
 	log.Printf("[DEBUG] Retrieved %s %s: %#v", nameELBListener, d.Id(), l)
 
-	sp := d.Get("ssl_protocols")
-	if l.SslProtocols == "" && sp != nil {
-		l.SslProtocols = sp.(string)
+	// The API may not return ssl_protocols, keep the configured value then,
+	// or the default one when nothing is known yet, e.g. on import.
+	if l.SslProtocols == "" {
+		l.SslProtocols = d.Get("ssl_protocols").(string)
+		if l.SslProtocols == "" {
+			l.SslProtocols = "TLSv1.2"
+		}
 	}
 	return refreshResourceData(l, d, nil)
 }
@@ -21,6 +21,10 @@ func resourceELBLoadBalancer() *schema.Resource {
 		Update: resourceELBLoadBalancerUpdate,
 		Delete: resourceELBLoadBalancerDelete,
 
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Update: schema.DefaultTimeout(10 * time.Minute),
@@ -72,9 +76,10 @@ func resourceELBLoadBalancer() *schema.Resource {
 			},
 
 			"charge_mode": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "bandwidth",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "bandwidth",
+				DiffSuppressFunc: suppressUnreadAfterImport,
 			},
 
 			"eip_type": {
@@ -0,0 +1,157 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/extensions/lbaas_v2/listeners"
+)
+
+func TestAccLBV2Listener_basic(t *testing.T) {
+	var listener listeners.Listener
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLBV2ListenerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: TestAccLBV2ListenerConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLBV2ListenerExists("telefonicaopencloud_lb_listener_v2.listener_1", &listener),
+				),
+			},
+			{
+				Config: TestAccLBV2ListenerConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_lb_listener_v2.listener_1", "name", "listener_1_updated"),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_lb_listener_v2.listener_1", "connection_limit", "100"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckLBV2ListenerDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+	lbClient, err := chooseLBV2AccTestClient(config, OS_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud load balancer client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "telefonicaopencloud_lb_listener_v2" {
+			continue
+		}
+
+		_, err := listeners.Get(lbClient, rs.Primary.ID).Extract()
+		if err == nil {
+			return fmt.Errorf("Listener still exists: %s", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckLBV2ListenerExists(n string, listener *listeners.Listener) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		lbClient, err := chooseLBV2AccTestClient(config, OS_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating TelefonicaOpenCloud load balancer client: %s", err)
+		}
+
+		found, err := listeners.Get(lbClient, rs.Primary.ID).Extract()
+		if err != nil {
+			return err
+		}
+
+		if found.ID != rs.Primary.ID {
+			return fmt.Errorf("Listener not found")
+		}
+
+		*listener = *found
+
+		return nil
+	}
+}
+
+const TestAccLBV2ListenerConfig_basic = `
+resource "telefonicaopencloud_networking_network_v2" "network_1" {
+  name = "network_1"
+  admin_state_up = "true"
+}
+
+resource "telefonicaopencloud_networking_subnet_v2" "subnet_1" {
+  name = "subnet_1"
+  cidr = "192.168.199.0/24"
+  ip_version = 4
+  network_id = "${telefonicaopencloud_networking_network_v2.network_1.id}"
+}
+
+resource "telefonicaopencloud_lb_loadbalancer_v2" "loadbalancer_1" {
+  name = "loadbalancer_1"
+  vip_subnet_id = "${telefonicaopencloud_networking_subnet_v2.subnet_1.id}"
+}
+
+resource "telefonicaopencloud_lb_listener_v2" "listener_1" {
+  name = "listener_1"
+  protocol = "HTTP"
+  protocol_port = 8080
+  loadbalancer_id = "${telefonicaopencloud_lb_loadbalancer_v2.loadbalancer_1.id}"
+
+  timeouts {
+    create = "5m"
+    update = "5m"
+    delete = "5m"
+  }
+}
+`
+
+const TestAccLBV2ListenerConfig_update = `
+resource "telefonicaopencloud_networking_network_v2" "network_1" {
+  name = "network_1"
+  admin_state_up = "true"
+}
+
+resource "telefonicaopencloud_networking_subnet_v2" "subnet_1" {
+  name = "subnet_1"
+  cidr = "192.168.199.0/24"
+  ip_version = 4
+  network_id = "${telefonicaopencloud_networking_network_v2.network_1.id}"
+}
+
+resource "telefonicaopencloud_lb_loadbalancer_v2" "loadbalancer_1" {
+  name = "loadbalancer_1"
+  vip_subnet_id = "${telefonicaopencloud_networking_subnet_v2.subnet_1.id}"
+}
+
+resource "telefonicaopencloud_lb_listener_v2" "listener_1" {
+  name = "listener_1_updated"
+  protocol = "HTTP"
+  protocol_port = 8080
+  connection_limit = 100
+  admin_state_up = "true"
+  loadbalancer_id = "${telefonicaopencloud_lb_loadbalancer_v2.loadbalancer_1.id}"
+
+  timeouts {
+    create = "5m"
+    update = "5m"
+    delete = "5m"
+  }
+}
+`
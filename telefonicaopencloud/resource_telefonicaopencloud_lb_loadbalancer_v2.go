@@ -0,0 +1,306 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/extensions/lbaas_v2/loadbalancers"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/ports"
+)
+
+func resourceLoadBalancerV2() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceLoadBalancerV2Create,
+		Read:   resourceLoadBalancerV2Read,
+		Update: resourceLoadBalancerV2Update,
+		Delete: resourceLoadBalancerV2Delete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"vip_subnet_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"vip_address": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"vip_port_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"admin_state_up": {
+				Type:     schema.TypeBool,
+				Default:  true,
+				Optional: true,
+			},
+
+			"flavor": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"loadbalancer_provider": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"security_group_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+		},
+	}
+}
+
+func resourceLoadBalancerV2Create(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	lbClient, err := chooseLBV2Client(d, config)
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud load balancer client: %s", err)
+	}
+
+	adminStateUp := d.Get("admin_state_up").(bool)
+	createOpts := loadbalancers.CreateOpts{
+		Name:         d.Get("name").(string),
+		Description:  d.Get("description").(string),
+		VipSubnetID:  d.Get("vip_subnet_id").(string),
+		TenantID:     d.Get("tenant_id").(string),
+		VipAddress:   d.Get("vip_address").(string),
+		AdminStateUp: &adminStateUp,
+		Flavor:       d.Get("flavor").(string),
+		Provider:     d.Get("loadbalancer_provider").(string),
+	}
+
+	log.Printf("[DEBUG] Create Options: %#v", createOpts)
+	lb, err := loadbalancers.Create(lbClient, createOpts).Extract()
+	if err != nil {
+		return fmt.Errorf("Error creating LoadBalancer: %s", err)
+	}
+
+	// Wait for LoadBalancer to become active before continuing
+	timeout := d.Timeout(schema.TimeoutCreate)
+	err = waitForLBV2LoadBalancer(lbClient, lb.ID, "ACTIVE", lbPendingStatuses, timeout)
+	if err != nil {
+		return err
+	}
+
+	// Once the loadbalancer has been created, apply any requested security groups
+	// to the port that was created behind the scenes.
+	networkingClient, err := config.networkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud networking client: %s", err)
+	}
+	if err := resourceLoadBalancerV2SecurityGroups(networkingClient, lb.VipPortID, d); err != nil {
+		return err
+	}
+
+	// If all has been successful, set the ID on the resource
+	d.SetId(lb.ID)
+
+	return resourceLoadBalancerV2Read(d, meta)
+}
+
+func resourceLoadBalancerV2Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	lbClient, err := chooseLBV2Client(d, config)
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud load balancer client: %s", err)
+	}
+
+	lb, err := loadbalancers.Get(lbClient, d.Id()).Extract()
+	if err != nil {
+		return CheckDeleted(d, err, "loadbalancer")
+	}
+
+	log.Printf("[DEBUG] Retrieved loadbalancer %s: %#v", d.Id(), lb)
+
+	d.Set("name", lb.Name)
+	d.Set("description", lb.Description)
+	d.Set("vip_subnet_id", lb.VipSubnetID)
+	d.Set("tenant_id", lb.TenantID)
+	d.Set("vip_address", lb.VipAddress)
+	d.Set("vip_port_id", lb.VipPortID)
+	d.Set("admin_state_up", lb.AdminStateUp)
+	d.Set("flavor", lb.Flavor)
+	d.Set("loadbalancer_provider", lb.Provider)
+	d.Set("region", GetRegion(d, config))
+
+	// Get any security groups on the VIP Port
+	if lb.VipPortID != "" {
+		networkingClient, err := config.networkingV2Client(GetRegion(d, config))
+		if err != nil {
+			return fmt.Errorf("Error creating TelefonicaOpenCloud networking client: %s", err)
+		}
+
+		port, err := ports.Get(networkingClient, lb.VipPortID).Extract()
+		if err != nil {
+			return err
+		}
+
+		d.Set("security_group_ids", port.SecurityGroups)
+	}
+
+	return nil
+}
+
+func resourceLoadBalancerV2Update(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	lbClient, err := chooseLBV2Client(d, config)
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud load balancer client: %s", err)
+	}
+
+	var updateOpts loadbalancers.UpdateOpts
+	if d.HasChange("name") {
+		updateOpts.Name = d.Get("name").(string)
+	}
+	if d.HasChange("description") {
+		updateOpts.Description = d.Get("description").(string)
+	}
+	if d.HasChange("admin_state_up") {
+		asu := d.Get("admin_state_up").(bool)
+		updateOpts.AdminStateUp = &asu
+	}
+
+	if updateOpts != (loadbalancers.UpdateOpts{}) {
+		// Wait for LoadBalancer to become active before continuing
+		timeout := d.Timeout(schema.TimeoutUpdate)
+		err = waitForLBV2LoadBalancer(lbClient, d.Id(), "ACTIVE", lbPendingStatuses, timeout)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("[DEBUG] Updating loadbalancer %s with options: %#v", d.Id(), updateOpts)
+		err = resource.Retry(timeout, func() *resource.RetryError {
+			_, err = loadbalancers.Update(lbClient, d.Id(), updateOpts).Extract()
+			if err != nil {
+				return checkForRetryableError(err)
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("Error updating loadbalancer %s: %s", d.Id(), err)
+		}
+
+		// Wait for LoadBalancer to become active before continuing
+		err = waitForLBV2LoadBalancer(lbClient, d.Id(), "ACTIVE", lbPendingStatuses, timeout)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Security Groups get updated separately
+	if d.HasChange("security_group_ids") {
+		networkingClient, err := config.networkingV2Client(GetRegion(d, config))
+		if err != nil {
+			return fmt.Errorf("Error creating TelefonicaOpenCloud networking client: %s", err)
+		}
+		vipPortID := d.Get("vip_port_id").(string)
+		if err := resourceLoadBalancerV2SecurityGroups(networkingClient, vipPortID, d); err != nil {
+			return err
+		}
+	}
+
+	return resourceLoadBalancerV2Read(d, meta)
+}
+
+func resourceLoadBalancerV2Delete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	lbClient, err := chooseLBV2Client(d, config)
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud load balancer client: %s", err)
+	}
+
+	log.Printf("[DEBUG] Deleting loadbalancer %s", d.Id())
+	timeout := d.Timeout(schema.TimeoutDelete)
+	err = resource.Retry(timeout, func() *resource.RetryError {
+		err = loadbalancers.Delete(lbClient, d.Id()).ExtractErr()
+		if err != nil {
+			return checkForRetryableError(err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return CheckDeleted(d, err, "Error deleting loadbalancer")
+	}
+
+	// Wait for LoadBalancer to become delete
+	err = waitForLBV2LoadBalancer(lbClient, d.Id(), "DELETED", lbPendingDeleteStatuses, timeout)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceLoadBalancerV2SecurityGroups(networkingClient *golangsdk.ServiceClient, vipPortID string, d *schema.ResourceData) error {
+	if vipPortID != "" {
+		if v, ok := d.GetOk("security_group_ids"); ok {
+			securityGroups := resourcePortSecurityGroupsV2(v.(*schema.Set))
+			updateOpts := ports.UpdateOpts{
+				SecurityGroups: &securityGroups,
+			}
+
+			log.Printf("[DEBUG] Adding security groups to loadbalancer "+
+				"VIP Port %s: %#v", vipPortID, updateOpts)
+
+			_, err := ports.Update(networkingClient, vipPortID, updateOpts).Extract()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,242 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/extensions/lbaas_v2/loadbalancers"
+)
+
+func TestAccLBV2LoadBalancer_basic(t *testing.T) {
+	var lb loadbalancers.LoadBalancer
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLBV2LoadBalancerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLBV2LoadBalancerConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLBV2LoadBalancerExists("telefonicaopencloud_lb_loadbalancer_v2.loadbalancer_1", &lb),
+				),
+			},
+			{
+				Config: testAccLBV2LoadBalancerConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_lb_loadbalancer_v2.loadbalancer_1", "name", "loadbalancer_1_updated"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccLBV2LoadBalancer_secGroup(t *testing.T) {
+	var lb loadbalancers.LoadBalancer
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLBV2LoadBalancerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLBV2LoadBalancer_secGroup,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLBV2LoadBalancerExists("telefonicaopencloud_lb_loadbalancer_v2.loadbalancer_1", &lb),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_lb_loadbalancer_v2.loadbalancer_1", "security_group_ids.#", "1"),
+				),
+			},
+			{
+				Config: testAccLBV2LoadBalancer_secGroup_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLBV2LoadBalancerExists("telefonicaopencloud_lb_loadbalancer_v2.loadbalancer_1", &lb),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_lb_loadbalancer_v2.loadbalancer_1", "security_group_ids.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckLBV2LoadBalancerDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+	lbClient, err := chooseLBV2AccTestClient(config, OS_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud load balancer client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "telefonicaopencloud_lb_loadbalancer_v2" {
+			continue
+		}
+
+		_, err := loadbalancers.Get(lbClient, rs.Primary.ID).Extract()
+		if err == nil {
+			return fmt.Errorf("LoadBalancer still exists: %s", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckLBV2LoadBalancerExists(n string, lb *loadbalancers.LoadBalancer) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		lbClient, err := chooseLBV2AccTestClient(config, OS_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating TelefonicaOpenCloud load balancer client: %s", err)
+		}
+
+		found, err := loadbalancers.Get(lbClient, rs.Primary.ID).Extract()
+		if err != nil {
+			return err
+		}
+
+		if found.ID != rs.Primary.ID {
+			return fmt.Errorf("LoadBalancer not found")
+		}
+
+		*lb = *found
+
+		return nil
+	}
+}
+
+// chooseLBV2AccTestClient mirrors chooseLBV2Client for test checks, which
+// have no ResourceData to read the region from.
+func chooseLBV2AccTestClient(config *Config, region string) (*golangsdk.ServiceClient, error) {
+	if config.useOctavia {
+		return config.loadBalancerV2Client(region)
+	}
+	return config.networkingV2Client(region)
+}
+
+const testAccLBV2LoadBalancerConfig_basic = `
+resource "telefonicaopencloud_networking_network_v2" "network_1" {
+  name = "network_1"
+  admin_state_up = "true"
+}
+
+resource "telefonicaopencloud_networking_subnet_v2" "subnet_1" {
+  name = "subnet_1"
+  cidr = "192.168.199.0/24"
+  ip_version = 4
+  network_id = "${telefonicaopencloud_networking_network_v2.network_1.id}"
+}
+
+resource "telefonicaopencloud_lb_loadbalancer_v2" "loadbalancer_1" {
+  name = "loadbalancer_1"
+  vip_subnet_id = "${telefonicaopencloud_networking_subnet_v2.subnet_1.id}"
+
+  timeouts {
+    create = "5m"
+    update = "5m"
+    delete = "5m"
+  }
+}
+`
+
+const testAccLBV2LoadBalancerConfig_update = `
+resource "telefonicaopencloud_networking_network_v2" "network_1" {
+  name = "network_1"
+  admin_state_up = "true"
+}
+
+resource "telefonicaopencloud_networking_subnet_v2" "subnet_1" {
+  name = "subnet_1"
+  cidr = "192.168.199.0/24"
+  ip_version = 4
+  network_id = "${telefonicaopencloud_networking_network_v2.network_1.id}"
+}
+
+resource "telefonicaopencloud_lb_loadbalancer_v2" "loadbalancer_1" {
+  name = "loadbalancer_1_updated"
+  admin_state_up = "true"
+  vip_subnet_id = "${telefonicaopencloud_networking_subnet_v2.subnet_1.id}"
+
+  timeouts {
+    create = "5m"
+    update = "5m"
+    delete = "5m"
+  }
+}
+`
+
+const testAccLBV2LoadBalancer_secGroup = `
+resource "telefonicaopencloud_networking_secgroup_v2" "secgroup_1" {
+  name = "secgroup_1"
+  description = "secgroup_1"
+}
+
+resource "telefonicaopencloud_networking_secgroup_v2" "secgroup_2" {
+  name = "secgroup_2"
+  description = "secgroup_2"
+}
+
+resource "telefonicaopencloud_networking_network_v2" "network_1" {
+  name = "network_1"
+  admin_state_up = "true"
+}
+
+resource "telefonicaopencloud_networking_subnet_v2" "subnet_1" {
+  name = "subnet_1"
+  network_id = "${telefonicaopencloud_networking_network_v2.network_1.id}"
+  cidr = "192.168.199.0/24"
+}
+
+resource "telefonicaopencloud_lb_loadbalancer_v2" "loadbalancer_1" {
+  name = "loadbalancer_1"
+  vip_subnet_id = "${telefonicaopencloud_networking_subnet_v2.subnet_1.id}"
+  security_group_ids = [
+    "${telefonicaopencloud_networking_secgroup_v2.secgroup_1.id}"
+  ]
+}
+`
+
+const testAccLBV2LoadBalancer_secGroup_update = `
+resource "telefonicaopencloud_networking_secgroup_v2" "secgroup_1" {
+  name = "secgroup_1"
+  description = "secgroup_1"
+}
+
+resource "telefonicaopencloud_networking_secgroup_v2" "secgroup_2" {
+  name = "secgroup_2"
+  description = "secgroup_2"
+}
+
+resource "telefonicaopencloud_networking_network_v2" "network_1" {
+  name = "network_1"
+  admin_state_up = "true"
+}
+
+resource "telefonicaopencloud_networking_subnet_v2" "subnet_1" {
+  name = "subnet_1"
+  network_id = "${telefonicaopencloud_networking_network_v2.network_1.id}"
+  cidr = "192.168.199.0/24"
+}
+
+resource "telefonicaopencloud_lb_loadbalancer_v2" "loadbalancer_1" {
+  name = "loadbalancer_1"
+  vip_subnet_id = "${telefonicaopencloud_networking_subnet_v2.subnet_1.id}"
+  security_group_ids = [
+    "${telefonicaopencloud_networking_secgroup_v2.secgroup_1.id}",
+    "${telefonicaopencloud_networking_secgroup_v2.secgroup_2.id}"
+  ]
+  depends_on = ["telefonicaopencloud_networking_secgroup_v2.secgroup_1"]
+}
+`
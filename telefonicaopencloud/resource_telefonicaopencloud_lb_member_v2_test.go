@@ -0,0 +1,215 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/extensions/lbaas_v2/pools"
+)
+
+func TestAccLBV2Member_basic(t *testing.T) {
+	var member_1 pools.Member
+	var member_2 pools.Member
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLBV2MemberDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: TestAccLBV2MemberConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLBV2MemberExists("telefonicaopencloud_lb_member_v2.member_1", &member_1),
+					testAccCheckLBV2MemberExists("telefonicaopencloud_lb_member_v2.member_2", &member_2),
+				),
+			},
+			{
+				Config: TestAccLBV2MemberConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("telefonicaopencloud_lb_member_v2.member_1", "weight", "10"),
+					resource.TestCheckResourceAttr("telefonicaopencloud_lb_member_v2.member_2", "weight", "15"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckLBV2MemberDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+	lbClient, err := chooseLBV2AccTestClient(config, OS_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud load balancer client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "telefonicaopencloud_lb_member_v2" {
+			continue
+		}
+
+		poolId := rs.Primary.Attributes["pool_id"]
+		_, err := pools.GetMember(lbClient, poolId, rs.Primary.ID).Extract()
+		if err == nil {
+			return fmt.Errorf("Member still exists: %s", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckLBV2MemberExists(n string, member *pools.Member) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		lbClient, err := chooseLBV2AccTestClient(config, OS_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating TelefonicaOpenCloud load balancer client: %s", err)
+		}
+
+		poolId := rs.Primary.Attributes["pool_id"]
+		found, err := pools.GetMember(lbClient, poolId, rs.Primary.ID).Extract()
+		if err != nil {
+			return err
+		}
+
+		if found.ID != rs.Primary.ID {
+			return fmt.Errorf("Member not found")
+		}
+
+		*member = *found
+
+		return nil
+	}
+}
+
+const TestAccLBV2MemberConfig_basic = `
+resource "telefonicaopencloud_networking_network_v2" "network_1" {
+  name = "network_1"
+  admin_state_up = "true"
+}
+
+resource "telefonicaopencloud_networking_subnet_v2" "subnet_1" {
+  name = "subnet_1"
+  network_id = "${telefonicaopencloud_networking_network_v2.network_1.id}"
+  cidr = "192.168.199.0/24"
+  ip_version = 4
+}
+
+resource "telefonicaopencloud_lb_loadbalancer_v2" "loadbalancer_1" {
+  name = "loadbalancer_1"
+  vip_subnet_id = "${telefonicaopencloud_networking_subnet_v2.subnet_1.id}"
+}
+
+resource "telefonicaopencloud_lb_listener_v2" "listener_1" {
+  name = "listener_1"
+  protocol = "HTTP"
+  protocol_port = 8080
+  loadbalancer_id = "${telefonicaopencloud_lb_loadbalancer_v2.loadbalancer_1.id}"
+}
+
+resource "telefonicaopencloud_lb_pool_v2" "pool_1" {
+  name = "pool_1"
+  protocol = "HTTP"
+  lb_method = "ROUND_ROBIN"
+  listener_id = "${telefonicaopencloud_lb_listener_v2.listener_1.id}"
+}
+
+resource "telefonicaopencloud_lb_member_v2" "member_1" {
+  address = "192.168.199.110"
+  protocol_port = 8080
+  pool_id = "${telefonicaopencloud_lb_pool_v2.pool_1.id}"
+  subnet_id = "${telefonicaopencloud_networking_subnet_v2.subnet_1.id}"
+
+  timeouts {
+    create = "5m"
+    update = "5m"
+    delete = "5m"
+  }
+}
+
+resource "telefonicaopencloud_lb_member_v2" "member_2" {
+  address = "192.168.199.111"
+  protocol_port = 8080
+  pool_id = "${telefonicaopencloud_lb_pool_v2.pool_1.id}"
+  subnet_id = "${telefonicaopencloud_networking_subnet_v2.subnet_1.id}"
+
+  timeouts {
+    create = "5m"
+    update = "5m"
+    delete = "5m"
+  }
+}
+`
+
+const TestAccLBV2MemberConfig_update = `
+resource "telefonicaopencloud_networking_network_v2" "network_1" {
+  name = "network_1"
+  admin_state_up = "true"
+}
+
+resource "telefonicaopencloud_networking_subnet_v2" "subnet_1" {
+  name = "subnet_1"
+  cidr = "192.168.199.0/24"
+  ip_version = 4
+  network_id = "${telefonicaopencloud_networking_network_v2.network_1.id}"
+}
+
+resource "telefonicaopencloud_lb_loadbalancer_v2" "loadbalancer_1" {
+  name = "loadbalancer_1"
+  vip_subnet_id = "${telefonicaopencloud_networking_subnet_v2.subnet_1.id}"
+}
+
+resource "telefonicaopencloud_lb_listener_v2" "listener_1" {
+  name = "listener_1"
+  protocol = "HTTP"
+  protocol_port = 8080
+  loadbalancer_id = "${telefonicaopencloud_lb_loadbalancer_v2.loadbalancer_1.id}"
+}
+
+resource "telefonicaopencloud_lb_pool_v2" "pool_1" {
+  name = "pool_1"
+  protocol = "HTTP"
+  lb_method = "ROUND_ROBIN"
+  listener_id = "${telefonicaopencloud_lb_listener_v2.listener_1.id}"
+}
+
+resource "telefonicaopencloud_lb_member_v2" "member_1" {
+  address = "192.168.199.110"
+  protocol_port = 8080
+  weight = 10
+  admin_state_up = "true"
+  pool_id = "${telefonicaopencloud_lb_pool_v2.pool_1.id}"
+  subnet_id = "${telefonicaopencloud_networking_subnet_v2.subnet_1.id}"
+
+  timeouts {
+    create = "5m"
+    update = "5m"
+    delete = "5m"
+  }
+}
+
+resource "telefonicaopencloud_lb_member_v2" "member_2" {
+  address = "192.168.199.111"
+  protocol_port = 8080
+  weight = 15
+  admin_state_up = "true"
+  pool_id = "${telefonicaopencloud_lb_pool_v2.pool_1.id}"
+  subnet_id = "${telefonicaopencloud_networking_subnet_v2.subnet_1.id}"
+
+  timeouts {
+    create = "5m"
+    update = "5m"
+    delete = "5m"
+  }
+}
+`
@@ -0,0 +1,291 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/extensions/lbaas_v2/monitors"
+)
+
+func resourceMonitorV2() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceMonitorV2Create,
+		Read:   resourceMonitorV2Read,
+		Update: resourceMonitorV2Update,
+		Delete: resourceMonitorV2Delete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"pool_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"delay": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+
+			"timeout": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+
+			"max_retries": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+
+			"url_path": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"http_method": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"expected_codes": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"admin_state_up": {
+				Type:     schema.TypeBool,
+				Default:  true,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func resourceMonitorV2Create(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	lbClient, err := chooseLBV2Client(d, config)
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud load balancer client: %s", err)
+	}
+
+	adminStateUp := d.Get("admin_state_up").(bool)
+	createOpts := monitors.CreateOpts{
+		PoolID:        d.Get("pool_id").(string),
+		TenantID:      d.Get("tenant_id").(string),
+		Type:          d.Get("type").(string),
+		Delay:         d.Get("delay").(int),
+		Timeout:       d.Get("timeout").(int),
+		MaxRetries:    d.Get("max_retries").(int),
+		URLPath:       d.Get("url_path").(string),
+		HTTPMethod:    d.Get("http_method").(string),
+		ExpectedCodes: d.Get("expected_codes").(string),
+		Name:          d.Get("name").(string),
+		AdminStateUp:  &adminStateUp,
+	}
+
+	// Wait for LoadBalancer to become active before continuing
+	poolID := createOpts.PoolID
+	timeout := d.Timeout(schema.TimeoutCreate)
+	err = waitForLBV2viaPool(lbClient, poolID, "ACTIVE", timeout)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Create Options: %#v", createOpts)
+	var monitor *monitors.Monitor
+	err = resource.Retry(timeout, func() *resource.RetryError {
+		monitor, err = monitors.Create(lbClient, createOpts).Extract()
+		if err != nil {
+			return checkForRetryableError(err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("Unable to create monitor: %s", err)
+	}
+
+	// Wait for LoadBalancer to become active before continuing
+	err = waitForLBV2viaPool(lbClient, poolID, "ACTIVE", timeout)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(monitor.ID)
+
+	return resourceMonitorV2Read(d, meta)
+}
+
+func resourceMonitorV2Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	lbClient, err := chooseLBV2Client(d, config)
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud load balancer client: %s", err)
+	}
+
+	monitor, err := monitors.Get(lbClient, d.Id()).Extract()
+	if err != nil {
+		return CheckDeleted(d, err, "monitor")
+	}
+
+	log.Printf("[DEBUG] Retrieved monitor %s: %#v", d.Id(), monitor)
+
+	d.Set("tenant_id", monitor.TenantID)
+	d.Set("type", monitor.Type)
+	d.Set("delay", monitor.Delay)
+	d.Set("timeout", monitor.Timeout)
+	d.Set("max_retries", monitor.MaxRetries)
+	d.Set("url_path", monitor.URLPath)
+	d.Set("http_method", monitor.HTTPMethod)
+	d.Set("expected_codes", monitor.ExpectedCodes)
+	d.Set("admin_state_up", monitor.AdminStateUp)
+	d.Set("name", monitor.Name)
+	d.Set("region", GetRegion(d, config))
+
+	if len(monitor.Pools) > 0 {
+		d.Set("pool_id", monitor.Pools[0].ID)
+	}
+
+	return nil
+}
+
+func resourceMonitorV2Update(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	lbClient, err := chooseLBV2Client(d, config)
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud load balancer client: %s", err)
+	}
+
+	var updateOpts monitors.UpdateOpts
+	if d.HasChange("url_path") {
+		updateOpts.URLPath = d.Get("url_path").(string)
+	}
+	if d.HasChange("expected_codes") {
+		updateOpts.ExpectedCodes = d.Get("expected_codes").(string)
+	}
+	if d.HasChange("delay") {
+		updateOpts.Delay = d.Get("delay").(int)
+	}
+	if d.HasChange("timeout") {
+		updateOpts.Timeout = d.Get("timeout").(int)
+	}
+	if d.HasChange("max_retries") {
+		updateOpts.MaxRetries = d.Get("max_retries").(int)
+	}
+	if d.HasChange("admin_state_up") {
+		asu := d.Get("admin_state_up").(bool)
+		updateOpts.AdminStateUp = &asu
+	}
+	if d.HasChange("name") {
+		updateOpts.Name = d.Get("name").(string)
+	}
+	if d.HasChange("http_method") {
+		updateOpts.HTTPMethod = d.Get("http_method").(string)
+	}
+
+	// Wait for LoadBalancer to become active before continuing
+	poolID := d.Get("pool_id").(string)
+	timeout := d.Timeout(schema.TimeoutUpdate)
+	err = waitForLBV2viaPool(lbClient, poolID, "ACTIVE", timeout)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Updating monitor %s with options: %#v", d.Id(), updateOpts)
+	err = resource.Retry(timeout, func() *resource.RetryError {
+		_, err = monitors.Update(lbClient, d.Id(), updateOpts).Extract()
+		if err != nil {
+			return checkForRetryableError(err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("Unable to update monitor %s: %s", d.Id(), err)
+	}
+
+	// Wait for LoadBalancer to become active before continuing
+	err = waitForLBV2viaPool(lbClient, poolID, "ACTIVE", timeout)
+	if err != nil {
+		return err
+	}
+
+	return resourceMonitorV2Read(d, meta)
+}
+
+func resourceMonitorV2Delete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	lbClient, err := chooseLBV2Client(d, config)
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud load balancer client: %s", err)
+	}
+
+	// Wait for LoadBalancer to become active before continuing
+	poolID := d.Get("pool_id").(string)
+	timeout := d.Timeout(schema.TimeoutDelete)
+	err = waitForLBV2viaPool(lbClient, poolID, "ACTIVE", timeout)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Deleting monitor %s", d.Id())
+	err = resource.Retry(timeout, func() *resource.RetryError {
+		err = monitors.Delete(lbClient, d.Id()).ExtractErr()
+		if err != nil {
+			return checkForRetryableError(err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return CheckDeleted(d, err, "Error deleting monitor")
+	}
+
+	// Wait for the monitor to become DELETED
+	err = waitForLBV2Monitor(lbClient, d.Id(), "DELETED", lbPendingDeleteStatuses, timeout)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
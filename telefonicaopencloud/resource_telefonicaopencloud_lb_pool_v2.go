@@ -0,0 +1,354 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/extensions/lbaas_v2/pools"
+)
+
+func resourcePoolV2() *schema.Resource {
+	return &schema.Resource{
+		Create: resourcePoolV2Create,
+		Read:   resourcePoolV2Read,
+		Update: resourcePoolV2Update,
+		Delete: resourcePoolV2Delete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"protocol": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					value := v.(string)
+					if value != "TCP" && value != "HTTP" && value != "HTTPS" {
+						errors = append(errors, fmt.Errorf(
+							"Only 'TCP', 'HTTP', and 'HTTPS' are supported values for 'protocol'"))
+					}
+					return
+				},
+			},
+
+			// One of loadbalancer_id or listener_id must be provided
+			"loadbalancer_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			// One of loadbalancer_id or listener_id must be provided
+			"listener_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"lb_method": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					value := v.(string)
+					if value != "ROUND_ROBIN" && value != "LEAST_CONNECTIONS" && value != "SOURCE_IP" {
+						errors = append(errors, fmt.Errorf(
+							"Only 'ROUND_ROBIN', 'LEAST_CONNECTIONS', and 'SOURCE_IP' are supported values for 'lb_method'"))
+					}
+					return
+				},
+			},
+
+			"persistence": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+							ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+								value := v.(string)
+								if value != "SOURCE_IP" && value != "HTTP_COOKIE" && value != "APP_COOKIE" {
+									errors = append(errors, fmt.Errorf(
+										"Only 'SOURCE_IP', 'HTTP_COOKIE', and 'APP_COOKIE' are supported values for 'persistence'"))
+								}
+								return
+							},
+						},
+
+						"cookie_name": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+
+			"admin_state_up": {
+				Type:     schema.TypeBool,
+				Default:  true,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func resourcePoolV2Create(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	lbClient, err := chooseLBV2Client(d, config)
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud load balancer client: %s", err)
+	}
+
+	adminStateUp := d.Get("admin_state_up").(bool)
+	var persistence pools.SessionPersistence
+	if p, ok := d.GetOk("persistence"); ok {
+		pV := (p.([]interface{}))[0].(map[string]interface{})
+
+		persistence = pools.SessionPersistence{
+			Type: pV["type"].(string),
+		}
+
+		if persistence.Type == "APP_COOKIE" {
+			if pV["cookie_name"].(string) == "" {
+				return fmt.Errorf(
+					"Persistence cookie_name needs to be set if using 'APP_COOKIE' persistence type.")
+			}
+			persistence.CookieName = pV["cookie_name"].(string)
+		} else if pV["cookie_name"].(string) != "" {
+			return fmt.Errorf(
+				"Persistence cookie_name can only be set if using 'APP_COOKIE' persistence type.")
+		}
+	}
+
+	createOpts := pools.CreateOpts{
+		TenantID:       d.Get("tenant_id").(string),
+		Name:           d.Get("name").(string),
+		Description:    d.Get("description").(string),
+		Protocol:       pools.Protocol(d.Get("protocol").(string)),
+		LoadbalancerID: d.Get("loadbalancer_id").(string),
+		ListenerID:     d.Get("listener_id").(string),
+		LBMethod:       pools.LBMethod(d.Get("lb_method").(string)),
+		AdminStateUp:   &adminStateUp,
+	}
+
+	// Must omit if not set
+	if persistence != (pools.SessionPersistence{}) {
+		createOpts.Persistence = &persistence
+	}
+
+	log.Printf("[DEBUG] Create Options: %#v", createOpts)
+
+	// Wait for LoadBalancer to become active before continuing
+	timeout := d.Timeout(schema.TimeoutCreate)
+	lbID := createOpts.LoadbalancerID
+	listenerID := createOpts.ListenerID
+	if lbID != "" {
+		err = waitForLBV2LoadBalancer(lbClient, lbID, "ACTIVE", lbPendingStatuses, timeout)
+		if err != nil {
+			return err
+		}
+	} else if listenerID != "" {
+		// Wait for Listener to become active before continuing
+		err = waitForLBV2Listener(lbClient, listenerID, "ACTIVE", nil, timeout)
+		if err != nil {
+			return err
+		}
+	} else {
+		return fmt.Errorf("Either 'loadbalancer_id' or 'listener_id' must be specified")
+	}
+
+	log.Printf("[DEBUG] Attempting to create pool")
+	var pool *pools.Pool
+	err = resource.Retry(timeout, func() *resource.RetryError {
+		pool, err = pools.Create(lbClient, createOpts).Extract()
+		if err != nil {
+			return checkForRetryableError(err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("Error creating pool: %s", err)
+	}
+
+	// Wait for LoadBalancer to become active before continuing
+	err = waitForLBV2viaPool(lbClient, pool.ID, "ACTIVE", timeout)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(pool.ID)
+
+	return resourcePoolV2Read(d, meta)
+}
+
+func resourcePoolV2Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	lbClient, err := chooseLBV2Client(d, config)
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud load balancer client: %s", err)
+	}
+
+	pool, err := pools.Get(lbClient, d.Id()).Extract()
+	if err != nil {
+		return CheckDeleted(d, err, "pool")
+	}
+
+	log.Printf("[DEBUG] Retrieved pool %s: %#v", d.Id(), pool)
+
+	d.Set("lb_method", pool.LBMethod)
+	d.Set("protocol", pool.Protocol)
+	d.Set("description", pool.Description)
+	d.Set("tenant_id", pool.TenantID)
+	d.Set("admin_state_up", pool.AdminStateUp)
+	d.Set("name", pool.Name)
+	d.Set("region", GetRegion(d, config))
+
+	if len(pool.Loadbalancers) > 0 {
+		d.Set("loadbalancer_id", pool.Loadbalancers[0].ID)
+	}
+	if len(pool.Listeners) > 0 {
+		d.Set("listener_id", pool.Listeners[0].ID)
+	}
+
+	if pool.Persistence.Type != "" {
+		persistence := []map[string]interface{}{
+			{
+				"type":        pool.Persistence.Type,
+				"cookie_name": pool.Persistence.CookieName,
+			},
+		}
+		d.Set("persistence", persistence)
+	}
+
+	return nil
+}
+
+func resourcePoolV2Update(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	lbClient, err := chooseLBV2Client(d, config)
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud load balancer client: %s", err)
+	}
+
+	var updateOpts pools.UpdateOpts
+	if d.HasChange("lb_method") {
+		updateOpts.LBMethod = pools.LBMethod(d.Get("lb_method").(string))
+	}
+	if d.HasChange("name") {
+		updateOpts.Name = d.Get("name").(string)
+	}
+	if d.HasChange("description") {
+		updateOpts.Description = d.Get("description").(string)
+	}
+	if d.HasChange("admin_state_up") {
+		asu := d.Get("admin_state_up").(bool)
+		updateOpts.AdminStateUp = &asu
+	}
+
+	// Wait for LoadBalancer to become active before continuing
+	timeout := d.Timeout(schema.TimeoutUpdate)
+	err = waitForLBV2viaPool(lbClient, d.Id(), "ACTIVE", timeout)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Updating pool %s with options: %#v", d.Id(), updateOpts)
+	err = resource.Retry(timeout, func() *resource.RetryError {
+		_, err = pools.Update(lbClient, d.Id(), updateOpts).Extract()
+		if err != nil {
+			return checkForRetryableError(err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("Error updating pool %s: %s", d.Id(), err)
+	}
+
+	// Wait for LoadBalancer to become active before continuing
+	err = waitForLBV2viaPool(lbClient, d.Id(), "ACTIVE", timeout)
+	if err != nil {
+		return err
+	}
+
+	return resourcePoolV2Read(d, meta)
+}
+
+func resourcePoolV2Delete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	lbClient, err := chooseLBV2Client(d, config)
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud load balancer client: %s", err)
+	}
+
+	// Wait for LoadBalancer to become active before continuing
+	timeout := d.Timeout(schema.TimeoutDelete)
+	err = waitForLBV2viaPool(lbClient, d.Id(), "ACTIVE", timeout)
+	if err != nil {
+		return CheckDeleted(d, err, "Error retrieving pool")
+	}
+
+	log.Printf("[DEBUG] Attempting to delete pool %s", d.Id())
+	err = resource.Retry(timeout, func() *resource.RetryError {
+		err = pools.Delete(lbClient, d.Id()).ExtractErr()
+		if err != nil {
+			return checkForRetryableError(err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return CheckDeleted(d, err, "Error deleting pool")
+	}
+
+	// Wait for Pool to delete
+	err = waitForLBV2Pool(lbClient, d.Id(), "DELETED", lbPendingDeleteStatuses, timeout)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -1,10 +1,13 @@
 package telefonicaopencloud
 
 import (
+	"bytes"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform/helper/hashcode"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 
@@ -54,6 +57,62 @@ func resourceNetworkingSecGroupV2() *schema.Resource {
 				Optional: true,
 				ForceNew: true,
 			},
+			"rule": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"direction": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+								return ValidateStringList(v, k, []string{"ingress", "egress"})
+							},
+						},
+						"ethertype": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+								return ValidateStringList(v, k, []string{"IPv4", "IPv6"})
+							},
+						},
+						"protocol": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"port_range_min": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"port_range_max": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"remote_ip_prefix": {
+							Type:     schema.TypeString,
+							Optional: true,
+							StateFunc: func(v interface{}) string {
+								return strings.ToLower(v.(string))
+							},
+						},
+						"remote_group_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"self": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+					},
+				},
+				Set: networkingSecGroupRuleV2Hash,
+			},
 		},
 	}
 }
@@ -66,6 +125,11 @@ func resourceNetworkingSecGroupV2Create(d *schema.ResourceData, meta interface{}
 		return fmt.Errorf("Error creating TelefonicaOpenCloud networking client: %s", err)
 	}
 
+	// Before creating the security group, make sure all rules are valid.
+	if err := checkNetworkingSecGroupV2RulesForErrors(d); err != nil {
+		return err
+	}
+
 	opts := groups.CreateOpts{
 		Name:        d.Get("name").(string),
 		Description: d.Get("description").(string),
@@ -79,9 +143,11 @@ func resourceNetworkingSecGroupV2Create(d *schema.ResourceData, meta interface{}
 		return err
 	}
 
-	// Delete the default security group rules if it has been requested.
+	// Delete the default security group rules if it has been requested, or
+	// if the rules are managed inline.
 	deleteDefaultRules := d.Get("delete_default_rules").(bool)
-	if deleteDefaultRules {
+	inlineRules := d.Get("rule").(*schema.Set).Len() > 0
+	if deleteDefaultRules || inlineRules {
 		security_group, err := groups.Get(networkingClient, security_group.ID).Extract()
 		if err != nil {
 			return err
@@ -98,6 +164,16 @@ func resourceNetworkingSecGroupV2Create(d *schema.ResourceData, meta interface{}
 
 	d.SetId(security_group.ID)
 
+	// Now that the security group has been created, create each inline rule.
+	for _, rawRule := range d.Get("rule").(*schema.Set).List() {
+		ruleOpts := resourceNetworkingSecGroupV2RuleCreateOpts(d, rawRule)
+		rule, err := rules.Create(networkingClient, ruleOpts).Extract()
+		if err != nil {
+			return fmt.Errorf("Error creating TelefonicaOpenCloud Neutron Security Group Rule: %s", err)
+		}
+		log.Printf("[DEBUG] Added rule (%s) to TelefonicaOpenCloud Neutron Security Group (%s)", rule.ID, d.Id())
+	}
+
 	return resourceNetworkingSecGroupV2Read(d, meta)
 }
 
@@ -121,6 +197,12 @@ func resourceNetworkingSecGroupV2Read(d *schema.ResourceData, meta interface{})
 	d.Set("name", security_group.Name)
 	d.Set("region", GetRegion(d, config))
 
+	// All rules of the group are read, so rules added out of band show up
+	// as a diff when the rules are managed inline.
+	if err := d.Set("rule", networkingSecGroupV2RulesToMap(d, security_group.Rules)); err != nil {
+		return fmt.Errorf("Error setting rules of TelefonicaOpenCloud Neutron Security Group: %s", err)
+	}
+
 	return nil
 }
 
@@ -131,6 +213,10 @@ func resourceNetworkingSecGroupV2Update(d *schema.ResourceData, meta interface{}
 		return fmt.Errorf("Error creating TelefonicaOpenCloud networking client: %s", err)
 	}
 
+	if err := checkNetworkingSecGroupV2RulesForErrors(d); err != nil {
+		return err
+	}
+
 	var update bool
 	var updateOpts groups.UpdateOpts
 
@@ -152,6 +238,37 @@ func resourceNetworkingSecGroupV2Update(d *schema.ResourceData, meta interface{}
 		}
 	}
 
+	if d.HasChange("rule") {
+		oldRulesRaw, newRulesRaw := d.GetChange("rule")
+		oldRules, newRules := oldRulesRaw.(*schema.Set), newRulesRaw.(*schema.Set)
+		rulesToRemove := oldRules.Difference(newRules)
+		rulesToAdd := newRules.Difference(oldRules)
+
+		log.Printf("[DEBUG] Security group rules to add: %v", rulesToAdd)
+		log.Printf("[DEBUG] Security group rules to remove: %v", rulesToRemove)
+
+		for _, rawRule := range rulesToRemove.List() {
+			ruleID := rawRule.(map[string]interface{})["id"].(string)
+			err := rules.Delete(networkingClient, ruleID).ExtractErr()
+			if err != nil {
+				if _, ok := err.(golangsdk.ErrDefault404); ok {
+					continue
+				}
+				return fmt.Errorf("Error removing rule (%s) from TelefonicaOpenCloud SecGroup (%s): %s", ruleID, d.Id(), err)
+			}
+			log.Printf("[DEBUG] Removed rule (%s) from TelefonicaOpenCloud SecGroup (%s)", ruleID, d.Id())
+		}
+
+		for _, rawRule := range rulesToAdd.List() {
+			ruleOpts := resourceNetworkingSecGroupV2RuleCreateOpts(d, rawRule)
+			rule, err := rules.Create(networkingClient, ruleOpts).Extract()
+			if err != nil {
+				return fmt.Errorf("Error adding rule to TelefonicaOpenCloud SecGroup (%s): %s", d.Id(), err)
+			}
+			log.Printf("[DEBUG] Added rule (%s) to TelefonicaOpenCloud SecGroup (%s)", rule.ID, d.Id())
+		}
+	}
+
 	return resourceNetworkingSecGroupV2Read(d, meta)
 }
 
@@ -182,6 +299,97 @@ func resourceNetworkingSecGroupV2Delete(d *schema.ResourceData, meta interface{}
 	return err
 }
 
+func resourceNetworkingSecGroupV2RuleCreateOpts(d *schema.ResourceData, rawRule interface{}) rules.CreateOpts {
+	rawRuleMap := rawRule.(map[string]interface{})
+	remoteGroupID := rawRuleMap["remote_group_id"].(string)
+	if rawRuleMap["self"].(bool) {
+		remoteGroupID = d.Id()
+	}
+
+	return rules.CreateOpts{
+		Direction:      resourceNetworkingSecGroupRuleV2DetermineDirection(rawRuleMap["direction"].(string)),
+		EtherType:      resourceNetworkingSecGroupRuleV2DetermineEtherType(rawRuleMap["ethertype"].(string)),
+		SecGroupID:     d.Id(),
+		PortRangeMin:   rawRuleMap["port_range_min"].(int),
+		PortRangeMax:   rawRuleMap["port_range_max"].(int),
+		Protocol:       resourceNetworkingSecGroupRuleV2DetermineProtocol(rawRuleMap["protocol"].(string)),
+		RemoteGroupID:  remoteGroupID,
+		RemoteIPPrefix: rawRuleMap["remote_ip_prefix"].(string),
+	}
+}
+
+func checkNetworkingSecGroupV2RulesForErrors(d *schema.ResourceData) error {
+	for _, rawRule := range d.Get("rule").(*schema.Set).List() {
+		rawRuleMap := rawRule.(map[string]interface{})
+
+		protocol := rawRuleMap["protocol"].(string)
+		if protocol == "" {
+			if rawRuleMap["port_range_min"].(int) != 0 || rawRuleMap["port_range_max"].(int) != 0 {
+				return fmt.Errorf("A protocol must be specified when using port_range_min and port_range_max")
+			}
+		} else if resourceNetworkingSecGroupRuleV2DetermineProtocol(protocol) == "" {
+			return fmt.Errorf("Unsupported protocol %q in security group rule", protocol)
+		}
+
+		// only one of remote_ip_prefix, remote_group_id, or self can be set
+		set := 0
+		if rawRuleMap["remote_ip_prefix"].(string) != "" {
+			set++
+		}
+		if rawRuleMap["remote_group_id"].(string) != "" {
+			set++
+		}
+		if rawRuleMap["self"].(bool) {
+			set++
+		}
+		if set > 1 {
+			return fmt.Errorf("Only one of remote_ip_prefix, remote_group_id, or self can be set.")
+		}
+	}
+
+	return nil
+}
+
+func networkingSecGroupV2RulesToMap(d *schema.ResourceData, sgrs []rules.SecGroupRule) []map[string]interface{} {
+	sgrMap := make([]map[string]interface{}, len(sgrs))
+	for i, sgr := range sgrs {
+		remoteGroupID := sgr.RemoteGroupID
+		self := false
+		if remoteGroupID == d.Id() {
+			remoteGroupID = ""
+			self = true
+		}
+
+		sgrMap[i] = map[string]interface{}{
+			"id":               sgr.ID,
+			"direction":        sgr.Direction,
+			"ethertype":        sgr.EtherType,
+			"protocol":         sgr.Protocol,
+			"port_range_min":   sgr.PortRangeMin,
+			"port_range_max":   sgr.PortRangeMax,
+			"remote_ip_prefix": strings.ToLower(sgr.RemoteIPPrefix),
+			"remote_group_id":  remoteGroupID,
+			"self":             self,
+		}
+	}
+	return sgrMap
+}
+
+func networkingSecGroupRuleV2Hash(v interface{}) int {
+	var buf bytes.Buffer
+	m := v.(map[string]interface{})
+	buf.WriteString(fmt.Sprintf("%s-", m["direction"].(string)))
+	buf.WriteString(fmt.Sprintf("%s-", m["ethertype"].(string)))
+	buf.WriteString(fmt.Sprintf("%s-", m["protocol"].(string)))
+	buf.WriteString(fmt.Sprintf("%d-", m["port_range_min"].(int)))
+	buf.WriteString(fmt.Sprintf("%d-", m["port_range_max"].(int)))
+	buf.WriteString(fmt.Sprintf("%s-", strings.ToLower(m["remote_ip_prefix"].(string))))
+	buf.WriteString(fmt.Sprintf("%s-", m["remote_group_id"].(string)))
+	buf.WriteString(fmt.Sprintf("%t-", m["self"].(bool)))
+
+	return hashcode.String(buf.String())
+}
+
 func waitForSecGroupDelete(networkingClient *golangsdk.ServiceClient, secGroupId string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		log.Printf("[DEBUG] Attempting to delete TelefonicaOpenCloud Security Group %s.\n", secGroupId)
@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform/terraform"
 
 	"github.com/huaweicloud/golangsdk/openstack/networking/v2/extensions/security/groups"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/extensions/security/rules"
 )
 
 func TestAccNetworkingV2SecGroup_basic(t *testing.T) {
@@ -59,6 +60,48 @@ func TestAccNetworkingV2SecGroup_noDefaultRules(t *testing.T) {
 	})
 }
 
+func TestAccNetworkingV2SecGroup_rules(t *testing.T) {
+	var security_group groups.SecGroup
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNetworkingV2SecGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetworkingV2SecGroup_rules,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkingV2SecGroupExists(
+						"telefonicaopencloud_networking_secgroup_v2.secgroup_1", &security_group),
+					testAccCheckNetworkingV2SecGroupRuleCount(&security_group, 2),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_networking_secgroup_v2.secgroup_1", "rule.#", "2"),
+				),
+			},
+			{
+				// A rule added out of band is removed again.
+				PreConfig: testAccNetworkingV2SecGroupAddRule(t, &security_group),
+				Config:    testAccNetworkingV2SecGroup_rules,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkingV2SecGroupExists(
+						"telefonicaopencloud_networking_secgroup_v2.secgroup_1", &security_group),
+					testAccCheckNetworkingV2SecGroupRuleCount(&security_group, 2),
+				),
+			},
+			{
+				Config: testAccNetworkingV2SecGroup_rulesUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkingV2SecGroupExists(
+						"telefonicaopencloud_networking_secgroup_v2.secgroup_1", &security_group),
+					testAccCheckNetworkingV2SecGroupRuleCount(&security_group, 3),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_networking_secgroup_v2.secgroup_1", "rule.#", "3"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccNetworkingV2SecGroup_timeout(t *testing.T) {
 	var security_group groups.SecGroup
 
@@ -142,6 +185,28 @@ func testAccCheckNetworkingV2SecGroupRuleCount(
 			sg.ID, count, len(sg.Rules))
 	}
 }
+func testAccNetworkingV2SecGroupAddRule(t *testing.T, sg *groups.SecGroup) func() {
+	return func() {
+		config := testAccProvider.Meta().(*Config)
+		networkingClient, err := config.networkingV2Client(OS_REGION_NAME)
+		if err != nil {
+			t.Fatalf("Error creating TelefonicaOpenCloud networking client: %s", err)
+		}
+
+		opts := rules.CreateOpts{
+			Direction:      rules.DirIngress,
+			EtherType:      rules.EtherType4,
+			SecGroupID:     sg.ID,
+			Protocol:       rules.ProtocolTCP,
+			PortRangeMin:   3389,
+			PortRangeMax:   3389,
+			RemoteIPPrefix: "0.0.0.0/0",
+		}
+		if _, err := rules.Create(networkingClient, opts).Extract(); err != nil {
+			t.Fatalf("Error creating TelefonicaOpenCloud Neutron Security Group Rule: %s", err)
+		}
+	}
+}
 
 const testAccNetworkingV2SecGroup_basic = `
 resource "telefonicaopencloud_networking_secgroup_v2" "secgroup_1" {
@@ -175,3 +240,54 @@ resource "telefonicaopencloud_networking_secgroup_v2" "secgroup_1" {
   }
 }
 `
+
+const testAccNetworkingV2SecGroup_rules = `
+resource "telefonicaopencloud_networking_secgroup_v2" "secgroup_1" {
+  name = "security_group"
+  description = "terraform security group acceptance test"
+
+  rule {
+    direction = "ingress"
+    ethertype = "IPv4"
+    protocol = "tcp"
+    port_range_min = 22
+    port_range_max = 22
+    remote_ip_prefix = "0.0.0.0/0"
+  }
+
+  rule {
+    direction = "ingress"
+    ethertype = "IPv4"
+    protocol = "icmp"
+    self = true
+  }
+}
+`
+
+const testAccNetworkingV2SecGroup_rulesUpdate = `
+resource "telefonicaopencloud_networking_secgroup_v2" "secgroup_1" {
+  name = "security_group"
+  description = "terraform security group acceptance test"
+
+  rule {
+    direction = "ingress"
+    ethertype = "IPv4"
+    protocol = "tcp"
+    port_range_min = 443
+    port_range_max = 443
+    remote_ip_prefix = "0.0.0.0/0"
+  }
+
+  rule {
+    direction = "ingress"
+    ethertype = "IPv4"
+    protocol = "icmp"
+    self = true
+  }
+
+  rule {
+    direction = "egress"
+    ethertype = "IPv4"
+  }
+}
+`
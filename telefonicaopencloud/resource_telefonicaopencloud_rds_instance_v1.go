@@ -213,6 +213,8 @@ func resourceRdsInstance() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+
+			"tags": tagsSchema(),
 		},
 	}
 }
@@ -356,6 +358,10 @@ func resourceInstanceCreate(d *schema.ResourceData, meta interface{}) error {
 			instance.ID, err)
 	}
 
+	if err := updateRdsInstanceTags(d, config); err != nil {
+		return err
+	}
+
 	if instance.ID != "" {
 		return resourceInstanceRead(d, meta)
 	}
@@ -440,6 +446,15 @@ func resourceInstanceRead(d *schema.ResourceData, meta interface{}) error {
 
 	d.Set("updated", instance.Updated)
 	d.Set("created", instance.Created)
+
+	tagsClient, err := config.RdsTagsV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating telefonicaopencloud rds tags client: %s", err)
+	}
+	if err := readResourceTags(d, config, tagsClient, "rds", d.Id()); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -598,7 +613,20 @@ func resourceInstanceUpdate(d *schema.ResourceData, meta interface{}) error {
 		log.Printf("[DEBUG] Successfully updated instance %s policy: %+v", id, updatepolicyOpts)
 	}
 
+	if err := updateRdsInstanceTags(d, config); err != nil {
+		return err
+	}
+
 	log.Printf("[DEBUG] Successfully updated instance %s", id)
 	d.SetId(id)
 	return resourceInstanceRead(d, meta)
 }
+
+func updateRdsInstanceTags(d *schema.ResourceData, config *Config) error {
+	tagsClient, err := config.RdsTagsV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating telefonicaopencloud rds tags client: %s", err)
+	}
+
+	return updateResourceTags(d, config, tagsClient, "rds", d.Id())
+}
@@ -394,6 +394,12 @@ func resourceS3BucketUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
+	if d.HasChange("tags") || (d.IsNewResource() && len(config.DefaultTags) > 0) {
+		if err := resourceS3BucketTagsUpdate(s3conn, config, d); err != nil {
+			return err
+		}
+	}
+
 	return resourceS3BucketRead(d, meta)
 }
 
@@ -728,6 +734,27 @@ func resourceS3BucketRead(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
+	// Read the tags
+	taggingResponse, err := retryOnAwsCode("NoSuchBucket", func() (interface{}, error) {
+		return s3conn.GetBucketTagging(&s3.GetBucketTaggingInput{
+			Bucket: aws.String(d.Id()),
+		})
+	})
+	tagmap := make(map[string]string)
+	if err != nil {
+		// An S3 Bucket might not have any tag set.
+		if awsErr, ok := err.(awserr.Error); !ok || awsErr.Code() != "NoSuchTagSet" {
+			return fmt.Errorf("Error reading S3 bucket tags: %s", err)
+		}
+	} else {
+		for _, t := range taggingResponse.(*s3.GetBucketTaggingOutput).TagSet {
+			tagmap[*t.Key] = *t.Value
+		}
+	}
+	if err := d.Set("tags", filterDefaultTags(d, config, tagmap)); err != nil {
+		return err
+	}
+
 	// Add the region as an attribute
 
 	locationResponse, err := retryOnAwsCode("NoSuchBucket", func() (interface{}, error) {
@@ -1194,6 +1221,51 @@ func resourceS3BucketLoggingUpdate(s3conn *s3.S3, d *schema.ResourceData) error
 	return nil
 }
 
+// resourceS3BucketTagsUpdate replaces the tag set of the bucket with the tags
+// attribute merged with the provider default_tags.
+func resourceS3BucketTagsUpdate(s3conn *s3.S3, config *Config, d *schema.ResourceData) error {
+	bucket := d.Get("bucket").(string)
+	tags := mergeDefaultTags(config.DefaultTags, d.Get("tags").(map[string]interface{}))
+
+	if len(tags) == 0 {
+		log.Printf("[DEBUG] S3 delete bucket tags: %s", bucket)
+		_, err := retryOnAwsCode("NoSuchBucket", func() (interface{}, error) {
+			return s3conn.DeleteBucketTagging(&s3.DeleteBucketTaggingInput{
+				Bucket: aws.String(bucket),
+			})
+		})
+		if err != nil {
+			return fmt.Errorf("Error deleting S3 tags: %s", err)
+		}
+		return nil
+	}
+
+	tagSet := make([]*s3.Tag, 0, len(tags))
+	for k, v := range tags {
+		tagSet = append(tagSet, &s3.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(v.(string)),
+		})
+	}
+
+	i := &s3.PutBucketTaggingInput{
+		Bucket: aws.String(bucket),
+		Tagging: &s3.Tagging{
+			TagSet: tagSet,
+		},
+	}
+	log.Printf("[DEBUG] S3 put bucket tags: %#v", i)
+
+	_, err := retryOnAwsCode("NoSuchBucket", func() (interface{}, error) {
+		return s3conn.PutBucketTagging(i)
+	})
+	if err != nil {
+		return fmt.Errorf("Error putting S3 tags: %s", err)
+	}
+
+	return nil
+}
+
 func resourceAwsS3BucketLifecycleUpdate(s3conn *s3.S3, d *schema.ResourceData) error {
 	bucket := d.Get("bucket").(string)
 
@@ -24,6 +24,10 @@ func resourceS3BucketObject() *schema.Resource {
 		Update: resourceS3BucketObjectPut,
 		Delete: resourceS3BucketObjectDelete,
 
+		Importer: &schema.ResourceImporter{
+			State: resourceS3BucketObjectImport,
+		},
+
 		Schema: map[string]*schema.Schema{
 			"bucket": {
 				Type:     schema.TypeString,
@@ -358,3 +362,18 @@ func validateS3BucketObjectServerSideEncryption(v interface{}, k string) (ws []s
 	}
 	return
 }
+
+// resourceS3BucketObjectImport imports an object by its "<bucket>/<key>"
+// identifier. The key may itself contain slashes.
+func resourceS3BucketObjectImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("Invalid format specified for S3 bucket object. Format must be <bucket>/<key>")
+	}
+
+	d.SetId(parts[1])
+	d.Set("bucket", parts[0])
+	d.Set("key", parts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -19,6 +19,10 @@ func resourceS3BucketPolicy() *schema.Resource {
 		Update: resourceS3BucketPolicyPut,
 		Delete: resourceS3BucketPolicyDelete,
 
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
 		Schema: map[string]*schema.Schema{
 			"bucket": {
 				Type:     schema.TypeString,
@@ -86,6 +90,12 @@ func resourceS3BucketPolicyRead(d *schema.ResourceData, meta interface{}) error
 		Bucket: aws.String(d.Id()),
 	})
 
+	if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "NoSuchBucket" {
+		log.Printf("[WARN] S3 bucket %s not found, removing policy from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
 	v := ""
 	if err == nil && pol.Policy != nil {
 		v = *pol.Policy
@@ -93,6 +103,7 @@ func resourceS3BucketPolicyRead(d *schema.ResourceData, meta interface{}) error
 	if err := d.Set("policy", v); err != nil {
 		return err
 	}
+	d.Set("bucket", d.Id())
 
 	return nil
 }
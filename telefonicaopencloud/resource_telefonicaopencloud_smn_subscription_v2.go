@@ -15,6 +15,10 @@ func resourceSubscription() *schema.Resource {
 		Read:   resourceSubscriptionRead,
 		Delete: resourceSubscriptionDelete,
 
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
 		Schema: map[string]*schema.Schema{
 			"topic_urn": {
 				Type:     schema.TypeString,
@@ -123,6 +127,7 @@ func resourceSubscriptionRead(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("Error Get subscriptionslist: %s", err)
 	}
 	log.Printf("[DEBUG] list : subscriptionslist %#v", subscriptionslist)
+	found := false
 	for _, subscription := range subscriptionslist {
 		if subscription.SubscriptionUrn == id {
 			log.Printf("[DEBUG] subscription: %#v", subscription)
@@ -133,8 +138,14 @@ func resourceSubscriptionRead(d *schema.ResourceData, meta interface{}) error {
 			d.Set("owner", subscription.Owner)
 			d.Set("remark", subscription.Remark)
 			d.Set("status", subscription.Status)
+			found = true
 		}
 	}
+	if !found {
+		log.Printf("[WARN] subscription %s not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
 
 	log.Printf("[DEBUG] Successfully get subscription %s", id)
 	return nil
@@ -0,0 +1,230 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v1/bandwidths"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v1/eips"
+)
+
+func resourceVpcBandWidthAssociateV2() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVpcBandWidthAssociateV2Create,
+		Read:   resourceVpcBandWidthAssociateV2Read,
+		Update: resourceVpcBandWidthAssociateV2Update,
+		Delete: resourceVpcBandWidthAssociateV2Delete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"bandwidth_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"eip_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"dedicated_name": {
+				Description: "The name of the dedicated bandwidth the EIP gets when it is removed from the shared bandwidth.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"dedicated_size": {
+				Description: "The size of the dedicated bandwidth the EIP gets when it is removed from the shared bandwidth.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+			},
+			"dedicated_charge_mode": {
+				Description: "The charge mode of the dedicated bandwidth the EIP gets when it is removed from the shared bandwidth.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					return ValidateStringList(v, k, []string{"bandwidth", "traffic"})
+				},
+			},
+		},
+	}
+}
+
+func parseVpcBandWidthAssociateV2ID(id string) (string, string, error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("Invalid format specified for bandwidth association. Format must be <bandwidth_id>/<eip_id>")
+	}
+	return parts[0], parts[1], nil
+}
+
+func resourceVpcBandWidthAssociateV2Create(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	networkingClient, err := config.networkingV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating networking client: %s", err)
+	}
+	bandwidthClient, err := config.networkingV2TagsClient(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating bandwidth client: %s", err)
+	}
+
+	bandwidthID := d.Get("bandwidth_id").(string)
+	eipID := d.Get("eip_id").(string)
+
+	eIP, err := eips.Get(networkingClient, eipID).Extract()
+	if err != nil {
+		return fmt.Errorf("Error retrieving EIP %s: %s", eipID, err)
+	}
+
+	if eIP.BandwidthID != bandwidthID {
+		if eIP.BandwidthShareType == "WHOLE" {
+			return fmt.Errorf("EIP %s is already in shared bandwidth %s", eipID, eIP.BandwidthID)
+		}
+
+		// Remember the dedicated bandwidth of the EIP, so it gets the same one
+		// back when it is removed from the shared bandwidth.
+		dedicated, err := bandwidths.Get(networkingClient, eIP.BandwidthID).Extract()
+		if err != nil {
+			return fmt.Errorf("Error fetching bandwidth: %s", err)
+		}
+		if _, ok := d.GetOk("dedicated_name"); !ok {
+			d.Set("dedicated_name", dedicated.Name)
+		}
+		if _, ok := d.GetOk("dedicated_size"); !ok {
+			d.Set("dedicated_size", dedicated.Size)
+		}
+		if _, ok := d.GetOk("dedicated_charge_mode"); !ok {
+			d.Set("dedicated_charge_mode", dedicated.ChargeMode)
+		}
+
+		insertOpts := vpcBandwidthV2InsertOpts{
+			PublicIPs: []vpcBandwidthV2PublicIP{{PublicIPID: eipID}},
+		}
+
+		log.Printf("[DEBUG] Adding EIP %s to shared bandwidth %s", eipID, bandwidthID)
+		err = insertVpcBandwidthV2(bandwidthClient, bandwidthID, insertOpts)
+		if err != nil {
+			return fmt.Errorf("Error adding EIP %s to shared bandwidth %s: %s", eipID, bandwidthID, err)
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", bandwidthID, eipID))
+
+	return resourceVpcBandWidthAssociateV2Read(d, meta)
+}
+
+func resourceVpcBandWidthAssociateV2Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	networkingClient, err := config.networkingV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating networking client: %s", err)
+	}
+
+	bandwidthID, eipID, err := parseVpcBandWidthAssociateV2ID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	eIP, err := eips.Get(networkingClient, eipID).Extract()
+	if err != nil {
+		return CheckDeleted(d, err, "eIP")
+	}
+
+	if eIP.BandwidthID != bandwidthID {
+		log.Printf("[WARN] EIP %s is no longer in shared bandwidth %s", eipID, bandwidthID)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("bandwidth_id", bandwidthID)
+	d.Set("eip_id", eipID)
+	d.Set("region", GetRegion(d, config))
+
+	return nil
+}
+
+func resourceVpcBandWidthAssociateV2Update(d *schema.ResourceData, meta interface{}) error {
+	// The dedicated bandwidth settings are only used when the EIP is removed
+	// from the shared bandwidth, so there is nothing to update.
+	return resourceVpcBandWidthAssociateV2Read(d, meta)
+}
+
+func resourceVpcBandWidthAssociateV2Delete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	networkingClient, err := config.networkingV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating networking client: %s", err)
+	}
+	bandwidthClient, err := config.networkingV2TagsClient(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating bandwidth client: %s", err)
+	}
+
+	bandwidthID, eipID, err := parseVpcBandWidthAssociateV2ID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	eIP, err := eips.Get(networkingClient, eipID).Extract()
+	if err != nil {
+		if _, ok := err.(golangsdk.ErrDefault404); ok {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error retrieving EIP %s: %s", eipID, err)
+	}
+	if eIP.BandwidthID != bandwidthID {
+		d.SetId("")
+		return nil
+	}
+
+	// Associations which were imported don't know the dedicated bandwidth of
+	// the EIP, so fall back to the smallest one.
+	removeOpts := vpcBandwidthV2RemoveOpts{
+		PublicIPs:  []vpcBandwidthV2PublicIP{{PublicIPID: eipID}},
+		ChargeMode: d.Get("dedicated_charge_mode").(string),
+		Size:       d.Get("dedicated_size").(int),
+	}
+	if removeOpts.ChargeMode == "" {
+		removeOpts.ChargeMode = "bandwidth"
+	}
+	if removeOpts.Size == 0 {
+		removeOpts.Size = 1
+	}
+
+	log.Printf("[DEBUG] Removing EIP %s from shared bandwidth %s: %#v", eipID, bandwidthID, removeOpts)
+	err = removeVpcBandwidthV2(bandwidthClient, bandwidthID, removeOpts)
+	if err != nil {
+		return fmt.Errorf("Error removing EIP %s from shared bandwidth %s: %s", eipID, bandwidthID, err)
+	}
+
+	// The API names the new dedicated bandwidth after the EIP, give it back
+	// its former name.
+	if name := d.Get("dedicated_name").(string); name != "" {
+		eIP, err = eips.Get(networkingClient, eipID).Extract()
+		if err != nil {
+			return fmt.Errorf("Error retrieving EIP %s: %s", eipID, err)
+		}
+		_, err = bandwidths.Update(networkingClient, eIP.BandwidthID, bandwidths.UpdateOpts{Name: name}).Extract()
+		if err != nil {
+			return fmt.Errorf("Error updating bandwidth: %s", err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,141 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v1/bandwidths"
+)
+
+func resourceVpcBandWidthV2() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVpcBandWidthV2Create,
+		Read:   resourceVpcBandWidthV2Read,
+		Update: resourceVpcBandWidthV2Update,
+		Delete: resourceVpcBandWidthV2Delete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateName,
+			},
+			"size": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"share_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"bandwidth_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"charge_mode": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceVpcBandWidthV2Create(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	bandwidthClient, err := config.networkingV2TagsClient(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating bandwidth client: %s", err)
+	}
+
+	createOpts := vpcBandwidthV2CreateOpts{
+		Name: d.Get("name").(string),
+		Size: d.Get("size").(int),
+	}
+
+	log.Printf("[DEBUG] Create Options: %#v", createOpts)
+	b, err := createVpcBandwidthV2(bandwidthClient, createOpts)
+	if err != nil {
+		return fmt.Errorf("Error creating shared bandwidth: %s", err)
+	}
+
+	log.Printf("[INFO] Shared bandwidth ID: %s", b.ID)
+	d.SetId(b.ID)
+
+	return resourceVpcBandWidthV2Read(d, meta)
+}
+
+func resourceVpcBandWidthV2Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	networkingClient, err := config.networkingV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating networking client: %s", err)
+	}
+
+	b, err := bandwidths.Get(networkingClient, d.Id()).Extract()
+	if err != nil {
+		return CheckDeleted(d, err, "bandwidth")
+	}
+
+	d.Set("name", b.Name)
+	d.Set("size", b.Size)
+	d.Set("share_type", b.ShareType)
+	d.Set("bandwidth_type", b.BandwidthType)
+	d.Set("charge_mode", b.ChargeMode)
+	d.Set("region", GetRegion(d, config))
+
+	return nil
+}
+
+func resourceVpcBandWidthV2Update(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	networkingClient, err := config.networkingV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating networking client: %s", err)
+	}
+
+	if d.HasChange("name") || d.HasChange("size") {
+		updateOpts := bandwidths.UpdateOpts{
+			Name: d.Get("name").(string),
+			Size: d.Get("size").(int),
+		}
+
+		log.Printf("[DEBUG] Bandwidth Update Options: %#v", updateOpts)
+		_, err = bandwidths.Update(networkingClient, d.Id(), updateOpts).Extract()
+		if err != nil {
+			return fmt.Errorf("Error updating bandwidth: %s", err)
+		}
+	}
+
+	return resourceVpcBandWidthV2Read(d, meta)
+}
+
+func resourceVpcBandWidthV2Delete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	bandwidthClient, err := config.networkingV2TagsClient(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating bandwidth client: %s", err)
+	}
+
+	err = deleteVpcBandwidthV2(bandwidthClient, d.Id())
+	if err != nil {
+		if _, ok := err.(golangsdk.ErrDefault404); ok {
+			log.Printf("[INFO] Shared bandwidth %s is already deleted", d.Id())
+		} else {
+			return fmt.Errorf("Error deleting shared bandwidth: %s", err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
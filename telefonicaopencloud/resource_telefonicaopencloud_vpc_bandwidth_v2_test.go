@@ -0,0 +1,212 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v1/bandwidths"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v1/eips"
+)
+
+func TestAccVpcBandWidthV2_basic(t *testing.T) {
+	var bandwidth bandwidths.BandWidth
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpcBandWidthV2Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpcBandWidthV2_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcBandWidthV2Exists("telefonicaopencloud_vpc_bandwidth_v2.bandwidth_1", &bandwidth),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_vpc_bandwidth_v2.bandwidth_1", "name", "bandwidth_1"),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_vpc_bandwidth_v2.bandwidth_1", "size", "5"),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_vpc_bandwidth_v2.bandwidth_1", "share_type", "WHOLE"),
+				),
+			},
+			{
+				Config: testAccVpcBandWidthV2_update,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_vpc_bandwidth_v2.bandwidth_1", "name", "bandwidth_1_updated"),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_vpc_bandwidth_v2.bandwidth_1", "size", "6"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccVpcBandWidthV2Associate_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpcBandWidthV2Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpcBandWidthV2Associate_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcBandWidthV2AssociateExists("telefonicaopencloud_vpc_bandwidth_associate_v2.associate_1"),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_vpc_bandwidth_associate_v2.associate_1", "dedicated_size", "8"),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_vpc_bandwidth_associate_v2.associate_1", "dedicated_charge_mode", "traffic"),
+				),
+			},
+			{
+				// The EIP is refreshed without a diff, and shows the shared bandwidth.
+				Config: testAccVpcBandWidthV2Associate_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_vpc_eip_v1.eip_1", "bandwidth.0.share_type", "WHOLE"),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_vpc_eip_v1.eip_1", "bandwidth.0.size", "5"),
+				),
+			},
+			{
+				Config: testAccVpcBandWidthV2Associate_removed,
+			},
+			{
+				// The EIP is back in a dedicated bandwidth of its former size.
+				Config: testAccVpcBandWidthV2Associate_removed,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_vpc_eip_v1.eip_1", "bandwidth.0.share_type", "PER"),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_vpc_eip_v1.eip_1", "bandwidth.0.size", "8"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckVpcBandWidthV2Destroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+	networkingClient, err := config.networkingV1Client(OS_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating networking client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "telefonicaopencloud_vpc_bandwidth_v2" {
+			continue
+		}
+
+		_, err := bandwidths.Get(networkingClient, rs.Primary.ID).Extract()
+		if err == nil {
+			return fmt.Errorf("Bandwidth still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckVpcBandWidthV2Exists(n string, bandwidth *bandwidths.BandWidth) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		networkingClient, err := config.networkingV1Client(OS_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating networking client: %s", err)
+		}
+
+		found, err := bandwidths.Get(networkingClient, rs.Primary.ID).Extract()
+		if err != nil {
+			return err
+		}
+
+		if found.ID != rs.Primary.ID {
+			return fmt.Errorf("Bandwidth not found")
+		}
+
+		*bandwidth = found
+
+		return nil
+	}
+}
+
+func testAccCheckVpcBandWidthV2AssociateExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		networkingClient, err := config.networkingV1Client(OS_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating networking client: %s", err)
+		}
+
+		eIP, err := eips.Get(networkingClient, rs.Primary.Attributes["eip_id"]).Extract()
+		if err != nil {
+			return err
+		}
+
+		if eIP.BandwidthID != rs.Primary.Attributes["bandwidth_id"] || eIP.BandwidthShareType != "WHOLE" {
+			return fmt.Errorf("EIP is not in the shared bandwidth")
+		}
+
+		return nil
+	}
+}
+
+const testAccVpcBandWidthV2_basic = `
+resource "telefonicaopencloud_vpc_bandwidth_v2" "bandwidth_1" {
+  name = "bandwidth_1"
+  size = 5
+}
+`
+
+const testAccVpcBandWidthV2_update = `
+resource "telefonicaopencloud_vpc_bandwidth_v2" "bandwidth_1" {
+  name = "bandwidth_1_updated"
+  size = 6
+}
+`
+
+const testAccVpcBandWidthV2Associate_removed = `
+resource "telefonicaopencloud_vpc_bandwidth_v2" "bandwidth_1" {
+  name = "bandwidth_1"
+  size = 5
+}
+
+resource "telefonicaopencloud_vpc_eip_v1" "eip_1" {
+  publicip {
+    type = "5_bgp"
+  }
+  bandwidth {
+    name = "test"
+    size = 8
+    share_type = "PER"
+    charge_mode = "traffic"
+  }
+}
+`
+
+var testAccVpcBandWidthV2Associate_basic = fmt.Sprintf(`
+%s
+
+resource "telefonicaopencloud_vpc_bandwidth_associate_v2" "associate_1" {
+  bandwidth_id = "${telefonicaopencloud_vpc_bandwidth_v2.bandwidth_1.id}"
+  eip_id = "${telefonicaopencloud_vpc_eip_v1.eip_1.id}"
+}
+`, testAccVpcBandWidthV2Associate_removed)
@@ -68,25 +68,29 @@ func resourceVpcEIPV1() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"name": {
-							Type:     schema.TypeString,
-							Required: true,
-							ForceNew: false,
+							Type:             schema.TypeString,
+							Required:         true,
+							ForceNew:         false,
+							DiffSuppressFunc: suppressSharedBandwidthDiffs,
 						},
 						"size": {
-							Type:     schema.TypeInt,
-							Required: true,
-							ForceNew: false,
+							Type:             schema.TypeInt,
+							Required:         true,
+							ForceNew:         false,
+							DiffSuppressFunc: suppressSharedBandwidthDiffs,
 						},
 						"share_type": {
-							Type:     schema.TypeString,
-							Required: true,
-							ForceNew: true,
+							Type:             schema.TypeString,
+							Required:         true,
+							ForceNew:         true,
+							DiffSuppressFunc: suppressSharedBandwidthDiffs,
 						},
 						"charge_mode": {
-							Type:     schema.TypeString,
-							Optional: true,
-							ForceNew: true,
-							Computed: true,
+							Type:             schema.TypeString,
+							Optional:         true,
+							ForceNew:         true,
+							Computed:         true,
+							DiffSuppressFunc: suppressSharedBandwidthDiffs,
 						},
 					},
 				},
@@ -96,6 +100,7 @@ func resourceVpcEIPV1() *schema.Resource {
 				Optional: true,
 				ForceNew: false,
 			},
+			"tags": tagsSchema(),
 		},
 	}
 }
@@ -138,6 +143,10 @@ func resourceVpcEIPV1Create(d *schema.ResourceData, meta interface{}) error {
 
 	d.SetId(eIP.ID)
 
+	if err := updateVpcEIPV1Tags(d, config); err != nil {
+		return err
+	}
+
 	return resourceVpcEIPV1Read(d, meta)
 }
 
@@ -179,6 +188,14 @@ func resourceVpcEIPV1Read(d *schema.ResourceData, meta interface{}) error {
 	d.Set("bandwidth", bW)
 	d.Set("region", GetRegion(d, config))
 
+	eipTagsClient, err := config.networkingV2TagsClient(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating EIP tags client: %s", err)
+	}
+	if err := readResourceTags(d, config, eipTagsClient, "publicips", d.Id()); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -204,9 +221,15 @@ func resourceVpcEIPV1Update(d *schema.ResourceData, meta interface{}) error {
 		if err != nil {
 			return CheckDeleted(d, err, "eIP")
 		}
-		_, err = bandwidths.Update(networkingClient, eIP.BandwidthID, updateOpts).Extract()
-		if err != nil {
-			return fmt.Errorf("Error updating bandwidth: %s", err)
+		// The bandwidth of an EIP in a shared bandwidth is managed by
+		// telefonicaopencloud_vpc_bandwidth_v2, don't resize it from here.
+		if eIP.BandwidthShareType == "WHOLE" {
+			log.Printf("[DEBUG] EIP %s is in shared bandwidth %s, not updating it", d.Id(), eIP.BandwidthID)
+		} else {
+			_, err = bandwidths.Update(networkingClient, eIP.BandwidthID, updateOpts).Extract()
+			if err != nil {
+				return fmt.Errorf("Error updating bandwidth: %s", err)
+			}
 		}
 
 	}
@@ -227,9 +250,22 @@ func resourceVpcEIPV1Update(d *schema.ResourceData, meta interface{}) error {
 
 	}
 
+	if err := updateVpcEIPV1Tags(d, config); err != nil {
+		return err
+	}
+
 	return resourceVpcEIPV1Read(d, meta)
 }
 
+func updateVpcEIPV1Tags(d *schema.ResourceData, config *Config) error {
+	eipTagsClient, err := config.networkingV2TagsClient(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating EIP tags client: %s", err)
+	}
+
+	return updateResourceTags(d, config, eipTagsClient, "publicips", d.Id())
+}
+
 func resourceVpcEIPV1Delete(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*Config)
 	networkingClient, err := config.networkingV1Client(GetRegion(d, config))
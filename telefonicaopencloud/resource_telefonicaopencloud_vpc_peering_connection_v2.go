@@ -31,10 +31,6 @@ func resourceVpcPeeringConnectionV2() *schema.Resource {
 				Computed: true,
 				ForceNew: true,
 			},
-			"id": {
-				Type:     schema.TypeString,
-				Computed: true,
-			},
 			"name": {
 				Type:         schema.TypeString,
 				Required:     true,
@@ -131,7 +127,6 @@ func resourceVPCPeeringV2Read(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("Error retrieving TelefonicaOpenCloud Vpc Peering Connection: %s", err)
 	}
 
-	d.Set("id", n.ID)
 	d.Set("name", n.Name)
 	d.Set("status", n.Status)
 	d.Set("vpc_id", n.RequestVpcInfo.VpcId)
@@ -0,0 +1,141 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/huaweicloud/golangsdk"
+)
+
+func resourceVPCRouteV2() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVPCRouteV2Create,
+		Read:   resourceVPCRouteV2Read,
+		Delete: resourceVPCRouteV2Delete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					return ValidateStringList(v, k, []string{"peering"})
+				},
+			},
+			"nexthop": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"destination": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCIDR,
+			},
+			"vpc_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceVPCRouteV2Create(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	routeClient, err := config.hwNetworkV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud Vpc Route Client: %s", err)
+	}
+	vpcClient, err := config.networkingV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud Vpc Client: %s", err)
+	}
+
+	createOpts := vpcRouteV2CreateOpts{
+		Type:        d.Get("type").(string),
+		NextHop:     d.Get("nexthop").(string),
+		Destination: d.Get("destination").(string),
+		VpcID:       d.Get("vpc_id").(string),
+		TenantID:    d.Get("tenant_id").(string),
+	}
+
+	if err := checkVpcRouteV2NextHop(vpcClient, routeClient, createOpts); err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud Vpc Route: %s", err)
+	}
+
+	log.Printf("[DEBUG] Create Options: %#v", createOpts)
+	n, err := createVpcRouteV2(routeClient, createOpts)
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud Vpc Route: %s", err)
+	}
+
+	log.Printf("[INFO] Vpc Route ID: %s", n.ID)
+	d.SetId(n.ID)
+
+	return resourceVPCRouteV2Read(d, meta)
+}
+
+func resourceVPCRouteV2Read(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	routeClient, err := config.hwNetworkV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud Vpc Route Client: %s", err)
+	}
+
+	n, err := getVpcRouteV2(routeClient, d.Id())
+	if err != nil {
+		if _, ok := err.(golangsdk.ErrDefault404); ok {
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error retrieving TelefonicaOpenCloud Vpc Route: %s", err)
+	}
+
+	d.Set("type", n.Type)
+	d.Set("nexthop", n.NextHop)
+	d.Set("destination", n.Destination)
+	d.Set("vpc_id", n.VpcID)
+	d.Set("tenant_id", n.TenantID)
+	d.Set("region", GetRegion(d, config))
+
+	return nil
+}
+
+func resourceVPCRouteV2Delete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+	routeClient, err := config.hwNetworkV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud Vpc Route Client: %s", err)
+	}
+
+	err = deleteVpcRouteV2(routeClient, d.Id())
+	if err != nil {
+		if _, ok := err.(golangsdk.ErrDefault404); ok {
+			log.Printf("[INFO] Vpc Route %s is already deleted", d.Id())
+		} else {
+			return fmt.Errorf("Error deleting TelefonicaOpenCloud Vpc Route: %s", err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,112 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccVpcRouteV2_basic(t *testing.T) {
+	var route vpcRouteV2
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpcRouteV2Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpcRouteV2_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcRouteV2Exists("telefonicaopencloud_vpc_route_v2.route_1", &route),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_vpc_route_v2.route_1", "type", "peering"),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_vpc_route_v2.route_1", "destination", "172.16.0.0/24"),
+					resource.TestCheckResourceAttrPair(
+						"telefonicaopencloud_vpc_route_v2.route_1", "nexthop",
+						"telefonicaopencloud_vpc_peering_connection_v2.peering_1", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckVpcRouteV2Destroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+	routeClient, err := config.hwNetworkV2Client(OS_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud route client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "telefonicaopencloud_vpc_route_v2" {
+			continue
+		}
+
+		_, err := getVpcRouteV2(routeClient, rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Vpc Route still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckVpcRouteV2Exists(n string, route *vpcRouteV2) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		routeClient, err := config.hwNetworkV2Client(OS_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating TelefonicaOpenCloud route client: %s", err)
+		}
+
+		found, err := getVpcRouteV2(routeClient, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if found.ID != rs.Primary.ID {
+			return fmt.Errorf("Vpc Route not found")
+		}
+
+		*route = *found
+
+		return nil
+	}
+}
+
+const testAccVpcRouteV2_basic = `
+resource "telefonicaopencloud_vpc_v1" "vpc_1" {
+  name = "vpc_test"
+  cidr = "192.168.0.0/16"
+}
+
+resource "telefonicaopencloud_vpc_v1" "vpc_2" {
+  name = "vpc_test1"
+  cidr = "172.16.0.0/16"
+}
+
+resource "telefonicaopencloud_vpc_peering_connection_v2" "peering_1" {
+  name = "telefonicaopencloud_peering"
+  vpc_id = "${telefonicaopencloud_vpc_v1.vpc_1.id}"
+  peer_vpc_id = "${telefonicaopencloud_vpc_v1.vpc_2.id}"
+}
+
+resource "telefonicaopencloud_vpc_route_v2" "route_1" {
+  type = "peering"
+  nexthop = "${telefonicaopencloud_vpc_peering_connection_v2.peering_1.id}"
+  destination = "172.16.0.0/24"
+  vpc_id = "${telefonicaopencloud_vpc_v1.vpc_1.id}"
+}
+`
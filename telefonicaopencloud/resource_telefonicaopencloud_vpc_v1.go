@@ -54,6 +54,7 @@ func resourceVirtualPrivateCloudV1() *schema.Resource {
 				Type:     schema.TypeBool,
 				Computed: true,
 			},
+			"tags": tagsSchema(),
 		},
 	}
 }
@@ -96,6 +97,10 @@ func resourceVirtualPrivateCloudV1Create(d *schema.ResourceData, meta interface{
 			n.ID, stateErr)
 	}
 
+	if err := updateVirtualPrivateCloudV1Tags(d, config); err != nil {
+		return err
+	}
+
 	return resourceVirtualPrivateCloudV1Read(d, meta)
 
 }
@@ -124,6 +129,14 @@ func resourceVirtualPrivateCloudV1Read(d *schema.ResourceData, meta interface{})
 	d.Set("shared", n.EnableSharedSnat)
 	d.Set("region", GetRegion(d, config))
 
+	vpcTagsClient, err := config.networkingV2TagsClient(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating TelefonicaOpenCloud Vpc tags client: %s", err)
+	}
+	if err := readResourceTags(d, config, vpcTagsClient, "vpcs", d.Id()); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -134,21 +147,36 @@ func resourceVirtualPrivateCloudV1Update(d *schema.ResourceData, meta interface{
 		return fmt.Errorf("Error creating TelefonicaOpenCloud Vpc: %s", err)
 	}
 
-	var updateOpts vpcs.UpdateOpts
+	if d.HasChange("name") || d.HasChange("cidr") {
+		var updateOpts vpcs.UpdateOpts
+
+		if d.HasChange("name") {
+			updateOpts.Name = d.Get("name").(string)
+		}
+		if d.HasChange("cidr") {
+			updateOpts.CIDR = d.Get("cidr").(string)
+		}
 
-	if d.HasChange("name") {
-		updateOpts.Name = d.Get("name").(string)
+		_, err = vpcs.Update(vpcClient, d.Id(), updateOpts).Extract()
+		if err != nil {
+			return fmt.Errorf("Error updating TelefonicaOpenCloud Vpc: %s", err)
+		}
 	}
-	if d.HasChange("cidr") {
-		updateOpts.CIDR = d.Get("cidr").(string)
+
+	if err := updateVirtualPrivateCloudV1Tags(d, config); err != nil {
+		return err
 	}
 
-	_, err = vpcs.Update(vpcClient, d.Id(), updateOpts).Extract()
+	return resourceVirtualPrivateCloudV1Read(d, meta)
+}
+
+func updateVirtualPrivateCloudV1Tags(d *schema.ResourceData, config *Config) error {
+	vpcTagsClient, err := config.networkingV2TagsClient(GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error updating TelefonicaOpenCloud Vpc: %s", err)
+		return fmt.Errorf("Error creating TelefonicaOpenCloud Vpc tags client: %s", err)
 	}
 
-	return resourceVirtualPrivateCloudV1Read(d, meta)
+	return updateResourceTags(d, config, vpcTagsClient, "vpcs", d.Id())
 }
 
 func resourceVirtualPrivateCloudV1Delete(d *schema.ResourceData, meta interface{}) error {
@@ -30,6 +30,10 @@ func TestAccVpcV1_basic(t *testing.T) {
 						"telefonicaopencloud_vpc_v1.vpc_1", "status", "OK"),
 					resource.TestCheckResourceAttr(
 						"telefonicaopencloud_vpc_v1.vpc_1", "shared", "false"),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_vpc_v1.vpc_1", "tags.%", "2"),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_vpc_v1.vpc_1", "tags.foo", "bar"),
 				),
 			},
 		},
@@ -58,6 +62,10 @@ func TestAccVpcV1_update(t *testing.T) {
 					testAccCheckVpcV1Exists("telefonicaopencloud_vpc_v1.vpc_1", &vpc),
 					resource.TestCheckResourceAttr(
 						"telefonicaopencloud_vpc_v1.vpc_1", "name", "terraform_provider_test1"),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_vpc_v1.vpc_1", "tags.%", "1"),
+					resource.TestCheckResourceAttr(
+						"telefonicaopencloud_vpc_v1.vpc_1", "tags.foo", "bar2"),
 				),
 			},
 		},
@@ -139,6 +147,11 @@ const testAccVpcV1_basic = `
 resource "telefonicaopencloud_vpc_v1" "vpc_1" {
 	name = "terraform_provider_test"
 	cidr="192.168.0.0/16"
+
+  tags {
+    foo = "bar"
+    key = "value"
+  }
 }
 `
 
@@ -146,6 +159,10 @@ const testAccVpcV1_update = `
 resource "telefonicaopencloud_vpc_v1" "vpc_1" {
     name = "terraform_provider_test1"
 	cidr="192.168.0.0/16"
+
+  tags {
+    foo = "bar2"
+  }
 }
 `
 const testAccVpcV1_timeout = `
@@ -0,0 +1,217 @@
+package telefonicaopencloud
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"log"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	defaultMaxRetries = 5
+	defaultMaxBackoff = 60 * time.Second
+
+	// retryBaseDelay is the delay before the first retry. It doubles with
+	// every attempt until it reaches the maximum backoff.
+	retryBaseDelay = 500 * time.Millisecond
+)
+
+// retryableErrorCodes are the error codes that API Gateway returns in the
+// body of a request which was throttled.
+var retryableErrorCodes = []string{"APIGW.0308", "APIG.0308"}
+
+// RetryRoundTripper satisfies the http.RoundTripper interface and retries
+// requests which failed because of throttling or a transient error.
+//
+// Requests rejected with 429 or 503, or with an API Gateway throttling code,
+// are always retried since the API did not act on them. Other 5xx responses
+// and connection errors are only retried for idempotent methods, so a POST
+// is never sent twice.
+type RetryRoundTripper struct {
+	Rt         http.RoundTripper
+	MaxRetries int
+	MaxBackoff time.Duration
+}
+
+// RoundTrip performs a round-trip HTTP request, retrying it with exponential
+// backoff and jitter. A Retry-After header sent with the response takes
+// precedence over the computed delay.
+func (rrt *RetryRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	// The body is read again on every attempt, so keep a copy of it.
+	var body []byte
+	if request.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(request.Body)
+		request.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		if body != nil {
+			request.Body = ioutil.NopCloser(bytes.NewReader(body))
+		}
+
+		response, err := rrt.Rt.RoundTrip(request)
+		if attempt >= rrt.MaxRetries || !rrt.shouldRetry(request, response, err) {
+			return response, err
+		}
+
+		delay := rrt.backoff(attempt, response)
+		if response != nil {
+			log.Printf("[DEBUG] Retrying %s %s in %s after response code %d (attempt %d of %d)",
+				request.Method, request.URL, delay, response.StatusCode, attempt+1, rrt.MaxRetries)
+			io.Copy(ioutil.Discard, response.Body)
+			response.Body.Close()
+		} else {
+			log.Printf("[DEBUG] Retrying %s %s in %s after error: %s (attempt %d of %d)",
+				request.Method, request.URL, delay, err, attempt+1, rrt.MaxRetries)
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-request.Context().Done():
+			return nil, request.Context().Err()
+		}
+	}
+}
+
+// shouldRetry reports whether the outcome of a request is worth retrying.
+func (rrt *RetryRoundTripper) shouldRetry(request *http.Request, response *http.Response, err error) bool {
+	if response == nil {
+		return isIdempotent(request.Method) && isTransientError(err)
+	}
+
+	switch code := response.StatusCode; {
+	case code == http.StatusTooManyRequests, code == http.StatusServiceUnavailable:
+		return true
+	case code >= 500:
+		return isIdempotent(request.Method)
+	case code == http.StatusBadRequest, code == http.StatusForbidden:
+		return isThrottled(response)
+	}
+
+	return false
+}
+
+// backoff returns how long to wait before the next attempt.
+func (rrt *RetryRoundTripper) backoff(attempt int, response *http.Response) time.Duration {
+	maxBackoff := rrt.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = defaultMaxBackoff
+	}
+
+	if response != nil {
+		if delay, ok := parseRetryAfter(response.Header.Get("Retry-After")); ok {
+			if delay > maxBackoff {
+				return maxBackoff
+			}
+			return delay
+		}
+	}
+
+	ceiling := float64(retryBaseDelay) * math.Pow(2, float64(attempt))
+	if ceiling > float64(maxBackoff) {
+		ceiling = float64(maxBackoff)
+	}
+
+	// Use "full jitter" so that concurrent requests throttled at the same time
+	// spread their retries out.
+	return time.Duration(rand.Int63n(int64(ceiling)) + 1)
+}
+
+// parseRetryAfter parses a Retry-After header, which is either a number of
+// seconds or an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+
+	return 0, false
+}
+
+// isThrottled checks the body of a response for an API Gateway throttling
+// code. The body is restored so it can still be read by the caller.
+func isThrottled(response *http.Response) bool {
+	if !strings.HasPrefix(response.Header.Get("Content-Type"), "application/json") {
+		return false
+	}
+
+	raw, err := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	response.Body = ioutil.NopCloser(bytes.NewReader(raw))
+	if err != nil {
+		return false
+	}
+
+	var body struct {
+		ErrorCode string `json:"error_code"`
+		Code      string `json:"code"`
+	}
+	if err := json.Unmarshal(raw, &body); err != nil {
+		return false
+	}
+
+	for _, code := range retryableErrorCodes {
+		if body.ErrorCode == code || body.Code == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isTransientError reports whether a transport error is likely to go away on
+// its own, such as a connection reset by a load balancer.
+func isTransientError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return true
+	}
+
+	if opErr, ok := err.(*net.OpError); ok {
+		err = opErr.Err
+	}
+	if netErr, ok := err.(net.Error); ok && (netErr.Timeout() || netErr.Temporary()) {
+		return true
+	}
+
+	if sysErr, ok := err.(*os.SyscallError); ok {
+		err = sysErr.Err
+	}
+	return err == syscall.ECONNRESET || err == syscall.EPIPE
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+		return true
+	}
+	return false
+}
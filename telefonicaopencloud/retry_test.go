@@ -0,0 +1,166 @@
+package telefonicaopencloud
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+type testRoundTripper struct {
+	responses []func() (*http.Response, error)
+	bodies    []string
+}
+
+func (t *testRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	body := ""
+	if request.Body != nil {
+		b, _ := ioutil.ReadAll(request.Body)
+		body = string(b)
+	}
+	t.bodies = append(t.bodies, body)
+
+	next := t.responses[0]
+	if len(t.responses) > 1 {
+		t.responses = t.responses[1:]
+	}
+	return next()
+}
+
+func testResponse(code int, header map[string]string, body string) func() (*http.Response, error) {
+	return func() (*http.Response, error) {
+		r := &http.Response{
+			StatusCode: code,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+		}
+		for k, v := range header {
+			r.Header.Set(k, v)
+		}
+		return r, nil
+	}
+}
+
+func TestRetryRoundTripper(t *testing.T) {
+	jsonHeader := map[string]string{"Content-Type": "application/json"}
+	throttled := `{"error_code": "APIGW.0308", "error_msg": "The request is throttled."}`
+
+	cases := []struct {
+		name      string
+		method    string
+		responses []func() (*http.Response, error)
+		attempts  int
+		code      int
+	}{
+		{"success", "GET", []func() (*http.Response, error){
+			testResponse(200, nil, ""),
+		}, 1, 200},
+		{"too many requests", "POST", []func() (*http.Response, error){
+			testResponse(429, map[string]string{"Retry-After": "0"}, ""),
+			testResponse(202, nil, ""),
+		}, 2, 202},
+		{"throttled by API gateway", "POST", []func() (*http.Response, error){
+			testResponse(403, jsonHeader, throttled),
+			testResponse(201, nil, ""),
+		}, 2, 201},
+		{"forbidden", "GET", []func() (*http.Response, error){
+			testResponse(403, jsonHeader, `{"error_code": "APIGW.0306"}`),
+		}, 1, 403},
+		{"server error", "GET", []func() (*http.Response, error){
+			testResponse(502, nil, ""),
+			testResponse(500, nil, ""),
+			testResponse(200, nil, ""),
+		}, 3, 200},
+		{"server error on create", "POST", []func() (*http.Response, error){
+			testResponse(500, nil, ""),
+		}, 1, 500},
+		{"connection reset", "DELETE", []func() (*http.Response, error){
+			func() (*http.Response, error) { return nil, syscall.ECONNRESET },
+			testResponse(204, nil, ""),
+		}, 2, 204},
+		{"gives up", "GET", []func() (*http.Response, error){
+			testResponse(503, nil, ""),
+		}, 4, 503},
+	}
+
+	for _, c := range cases {
+		rt := &testRoundTripper{responses: c.responses}
+		rrt := &RetryRoundTripper{Rt: rt, MaxRetries: 3, MaxBackoff: time.Millisecond}
+
+		request, _ := http.NewRequest(c.method, "https://ecs.example.com/v2/servers", strings.NewReader("body"))
+		response, err := rrt.RoundTrip(request)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", c.name, err)
+		}
+
+		if response.StatusCode != c.code {
+			t.Fatalf("%s: expected response code %d, got %d", c.name, c.code, response.StatusCode)
+		}
+		if len(rt.bodies) != c.attempts {
+			t.Fatalf("%s: expected %d attempts, got %d", c.name, c.attempts, len(rt.bodies))
+		}
+		for _, body := range rt.bodies {
+			if body != "body" {
+				t.Fatalf("%s: expected the request body on every attempt, got %q", c.name, body)
+			}
+		}
+	}
+}
+
+func TestRetryRoundTripper_keepsThrottledBody(t *testing.T) {
+	rt := &testRoundTripper{responses: []func() (*http.Response, error){
+		testResponse(400, map[string]string{"Content-Type": "application/json"}, `{"error_code": "VPC.0101"}`),
+	}}
+	rrt := &RetryRoundTripper{Rt: rt, MaxRetries: 3}
+
+	request, _ := http.NewRequest("GET", "https://vpc.example.com/v1/vpcs", nil)
+	response, err := rrt.RoundTrip(request)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	body, _ := ioutil.ReadAll(response.Body)
+	if string(body) != `{"error_code": "VPC.0101"}` {
+		t.Fatalf("Expected the response body to be readable, got %q", body)
+	}
+}
+
+func TestRetryRoundTripper_backoff(t *testing.T) {
+	rrt := &RetryRoundTripper{MaxBackoff: 10 * time.Second}
+
+	for attempt := 0; attempt < 10; attempt++ {
+		if delay := rrt.backoff(attempt, nil); delay <= 0 || delay > 10*time.Second {
+			t.Fatalf("Expected a delay up to 10s for attempt %d, got %s", attempt, delay)
+		}
+	}
+
+	response, _ := testResponse(429, map[string]string{"Retry-After": "120"}, "")()
+	if delay := rrt.backoff(0, response); delay != 10*time.Second {
+		t.Fatalf("Expected Retry-After to be capped at 10s, got %s", delay)
+	}
+
+	response, _ = testResponse(429, map[string]string{"Retry-After": "3"}, "")()
+	if delay := rrt.backoff(0, response); delay != 3*time.Second {
+		t.Fatalf("Expected a delay of 3s from Retry-After, got %s", delay)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if _, ok := parseRetryAfter(""); ok {
+		t.Fatalf("Expected an empty Retry-After to be ignored")
+	}
+	if _, ok := parseRetryAfter("soon"); ok {
+		t.Fatalf("Expected an invalid Retry-After to be ignored")
+	}
+
+	if delay, ok := parseRetryAfter("7"); !ok || delay != 7*time.Second {
+		t.Fatalf("Expected a delay of 7s, got %s", delay)
+	}
+
+	date := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	if delay, ok := parseRetryAfter(date); !ok || delay < 59*time.Minute || delay > time.Hour {
+		t.Fatalf("Expected a delay of about an hour, got %s", delay)
+	}
+}
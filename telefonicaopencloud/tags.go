@@ -1,6 +1,12 @@
 package telefonicaopencloud
 
-import "github.com/hashicorp/terraform/helper/schema"
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/huaweicloud/golangsdk"
+)
 
 // tagsSchema returns the schema to use for tags.
 //
@@ -10,3 +16,209 @@ func tagsSchema() *schema.Schema {
 		Optional: true,
 	}
 }
+
+// resourceTag is a single key/value pair as used by the tag APIs of the
+// ECS, EVS, VPC, EIP, RDS and DCS services.
+type resourceTag struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// resourceTags is the body returned when the tags of a resource are listed.
+type resourceTags struct {
+	Tags []resourceTag `json:"tags"`
+}
+
+// tagsActionOpts contains the values needed to add or remove tags of a
+// resource in a single batch.
+type tagsActionOpts struct {
+	// Action is either "create" or "delete".
+	Action string `json:"action" required:"true"`
+
+	// Tags are the tags to add or remove.
+	Tags []resourceTag `json:"tags" required:"true"`
+}
+
+// ToTagsActionMap builds a request body from tagsActionOpts.
+func (opts tagsActionOpts) ToTagsActionMap() (map[string]interface{}, error) {
+	return golangsdk.BuildRequestBody(opts, "")
+}
+
+func resourceTagsURL(client *golangsdk.ServiceClient, resourceType, id string) string {
+	return client.ServiceURL(resourceType, id, "tags")
+}
+
+func resourceTagsActionURL(client *golangsdk.ServiceClient, resourceType, id string) string {
+	return client.ServiceURL(resourceType, id, "tags", "action")
+}
+
+// getResourceTags retrieves the tags of the resource with the given type and ID.
+func getResourceTags(client *golangsdk.ServiceClient, resourceType, id string) ([]resourceTag, error) {
+	var r resourceTags
+	_, err := client.Get(resourceTagsURL(client, resourceType, id), &r, nil)
+	if err != nil {
+		return nil, err
+	}
+	return r.Tags, nil
+}
+
+// resourceTagsAction adds or removes the given tags of a resource.
+func resourceTagsAction(client *golangsdk.ServiceClient, resourceType, id, action string, tags []resourceTag) error {
+	b, err := tagsActionOpts{Action: action, Tags: tags}.ToTagsActionMap()
+	if err != nil {
+		return err
+	}
+	_, err = client.Post(resourceTagsActionURL(client, resourceType, id), b, nil, &golangsdk.RequestOpts{
+		OkCodes: []int{204},
+	})
+	return err
+}
+
+// expandResourceTags converts a tags map from the configuration into the
+// list form used by the tag APIs.
+func expandResourceTags(tagmap map[string]interface{}) []resourceTag {
+	tags := make([]resourceTag, 0, len(tagmap))
+	for k, v := range tagmap {
+		tags = append(tags, resourceTag{
+			Key:   k,
+			Value: v.(string),
+		})
+	}
+	return tags
+}
+
+// tagsToMap converts the tags returned by the tag APIs into a map
+// suitable for the tags attribute.
+func tagsToMap(tags []resourceTag) map[string]string {
+	result := make(map[string]string, len(tags))
+	for _, t := range tags {
+		result[t.Key] = t.Value
+	}
+	return result
+}
+
+// diffResourceTags returns the tags that have to be removed and the tags that
+// have to be added to go from the old to the new tags map. Tags whose value
+// changed are only re-added, since adding a tag overwrites its value.
+func diffResourceTags(oldTags, newTags map[string]interface{}) ([]resourceTag, []resourceTag) {
+	remove := make(map[string]interface{})
+	for k, v := range oldTags {
+		if _, ok := newTags[k]; !ok {
+			remove[k] = v
+		}
+	}
+
+	add := make(map[string]interface{})
+	for k, v := range newTags {
+		if old, ok := oldTags[k]; !ok || old != v {
+			add[k] = v
+		}
+	}
+
+	return expandResourceTags(remove), expandResourceTags(add)
+}
+
+// mergeDefaultTags returns the provider default tags overlaid with the
+// resource tags, so that keys set on the resource win.
+func mergeDefaultTags(defaultTags map[string]string, tags map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(defaultTags)+len(tags))
+	for k, v := range defaultTags {
+		merged[k] = v
+	}
+	for k, v := range tags {
+		merged[k] = v
+	}
+	return merged
+}
+
+// readResourceTags sets the tags attribute from the tags of the resource.
+func readResourceTags(d *schema.ResourceData, config *Config, client *golangsdk.ServiceClient, resourceType, id string) error {
+	tags, err := getResourceTags(client, resourceType, id)
+	if err != nil {
+		if !isResourceTagsUnsupported(err) {
+			return fmt.Errorf("Error fetching tags of %s %s: %s", resourceType, id, err)
+		}
+		log.Printf("[WARN] Tags of %s %s are not supported, reading them as empty: %s", resourceType, id, err)
+		if err := d.Set("tags", map[string]string{}); err != nil {
+			return fmt.Errorf("Error saving tags of %s %s: %s", resourceType, id, err)
+		}
+		return nil
+	}
+
+	if err := d.Set("tags", filterDefaultTags(d, config, tagsToMap(tags))); err != nil {
+		return fmt.Errorf("Error saving tags of %s %s: %s", resourceType, id, err)
+	}
+
+	return nil
+}
+
+// isResourceTagsUnsupported reports whether an error returned by the tag API
+// means that the service doesn't support tags in the region, rather than
+// that they couldn't be fetched.
+func isResourceTagsUnsupported(err error) bool {
+	switch e := err.(type) {
+	case golangsdk.ErrDefault404, golangsdk.ErrDefault405:
+		return true
+	case golangsdk.ErrUnexpectedResponseCode:
+		return e.Actual == 501
+	}
+	return false
+}
+
+// filterDefaultTags returns the tags of a resource as they are kept in the
+// tags attribute. Tags inherited from the provider default_tags are left out
+// unless the resource sets them itself, so that they don't show up as a diff.
+// A default tag missing from the resource, for instance because it was added
+// to default_tags after the resource was created, is kept with an empty value
+// so that the diff of the tags attribute adds it back.
+func filterDefaultTags(d *schema.ResourceData, config *Config, tagmap map[string]string) map[string]string {
+	configured := d.Get("tags").(map[string]interface{})
+	for k, v := range config.DefaultTags {
+		if _, ok := configured[k]; ok {
+			continue
+		}
+		actual, ok := tagmap[k]
+		switch {
+		case !ok:
+			tagmap[k] = ""
+		case actual == v:
+			delete(tagmap, k)
+		}
+	}
+	return tagmap
+}
+
+// updateResourceTags brings the tags of the resource in line with the tags
+// attribute merged with the provider default_tags. Only the tags that changed
+// are sent, so it is used both after a resource is created and when its tags
+// are updated in place. A change of default_tags reaches it as a change of
+// the tags attribute, see filterDefaultTags.
+func updateResourceTags(d *schema.ResourceData, config *Config, client *golangsdk.ServiceClient, resourceType, id string) error {
+	if !d.HasChange("tags") && !(d.IsNewResource() && len(config.DefaultTags) > 0) {
+		return nil
+	}
+
+	o, n := d.GetChange("tags")
+	oldTags := make(map[string]interface{})
+	if !d.IsNewResource() {
+		oldTags = mergeDefaultTags(config.DefaultTags, o.(map[string]interface{}))
+	}
+	newTags := mergeDefaultTags(config.DefaultTags, n.(map[string]interface{}))
+	remove, add := diffResourceTags(oldTags, newTags)
+
+	if len(remove) > 0 {
+		log.Printf("[DEBUG] Removing tags of %s %s: %#v", resourceType, id, remove)
+		if err := resourceTagsAction(client, resourceType, id, "delete", remove); err != nil {
+			return fmt.Errorf("Error removing tags of %s %s: %s", resourceType, id, err)
+		}
+	}
+
+	if len(add) > 0 {
+		log.Printf("[DEBUG] Adding tags to %s %s: %#v", resourceType, id, add)
+		if err := resourceTagsAction(client, resourceType, id, "create", add); err != nil {
+			return fmt.Errorf("Error adding tags to %s %s: %s", resourceType, id, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/huaweicloud/golangsdk"
+)
+
+func TestDiffResourceTags(t *testing.T) {
+	oldTags := map[string]interface{}{
+		"foo":  "bar",
+		"key":  "value",
+		"same": "same",
+	}
+	newTags := map[string]interface{}{
+		"foo":  "bar2",
+		"same": "same",
+		"new":  "new",
+	}
+
+	remove, add := diffResourceTags(oldTags, newTags)
+
+	expectedRemove := map[string]string{
+		"key": "value",
+	}
+	if actual := tagsToMap(remove); !reflect.DeepEqual(actual, expectedRemove) {
+		t.Fatalf("Expected tags to remove %#v, got %#v", expectedRemove, actual)
+	}
+
+	expectedAdd := map[string]string{
+		"foo": "bar2",
+		"new": "new",
+	}
+	if actual := tagsToMap(add); !reflect.DeepEqual(actual, expectedAdd) {
+		t.Fatalf("Expected tags to add %#v, got %#v", expectedAdd, actual)
+	}
+}
+
+func TestTagsActionOpts_ToTagsActionMap(t *testing.T) {
+	opts := tagsActionOpts{
+		Action: "create",
+		Tags: []resourceTag{
+			{Key: "foo", Value: "bar"},
+		},
+	}
+
+	b, err := opts.ToTagsActionMap()
+	if err != nil {
+		t.Fatalf("Error building tags action body: %s", err)
+	}
+
+	if b["action"] != "create" {
+		t.Fatalf("Expected action create, got %#v", b["action"])
+	}
+
+	tags, ok := b["tags"].([]interface{})
+	if !ok || len(tags) != 1 {
+		t.Fatalf("Expected one tag, got %#v", b["tags"])
+	}
+}
+
+func TestMergeDefaultTags(t *testing.T) {
+	defaultTags := map[string]string{
+		"owner":       "team",
+		"cost_center": "1234",
+	}
+	tags := map[string]interface{}{
+		"owner": "other-team",
+		"foo":   "bar",
+	}
+
+	expected := map[string]interface{}{
+		"owner":       "other-team",
+		"cost_center": "1234",
+		"foo":         "bar",
+	}
+	if actual := mergeDefaultTags(defaultTags, tags); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("Expected merged tags %#v, got %#v", expected, actual)
+	}
+}
+
+func TestReadResourceTags(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/vpcs/vpc-id/tags" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"tags": [
+			{"key": "owner", "value": "team"},
+			{"key": "env", "value": "test"},
+			{"key": "cost_center", "value": "9999"},
+			{"key": "foo", "value": "bar"}
+		]}`)
+	}))
+	defer server.Close()
+
+	client := &golangsdk.ServiceClient{
+		ProviderClient: &golangsdk.ProviderClient{},
+		Endpoint:       server.URL + "/",
+	}
+	config := &Config{
+		DefaultTags: map[string]string{
+			"owner":       "team",
+			"env":         "prod",
+			"cost_center": "1234",
+			"project":     "demo",
+		},
+	}
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{"tags": tagsSchema()},
+		map[string]interface{}{
+			"tags": map[string]interface{}{
+				"env": "test",
+				"foo": "bar",
+			},
+		})
+
+	if err := readResourceTags(d, config, client, "vpcs", "vpc-id"); err != nil {
+		t.Fatalf("Error reading tags: %s", err)
+	}
+
+	// owner is inherited unchanged, env is set on the resource, cost_center
+	// and project differ from the default tags and have to be updated.
+	expected := map[string]interface{}{
+		"env":         "test",
+		"cost_center": "9999",
+		"project":     "",
+		"foo":         "bar",
+	}
+	if actual := d.Get("tags").(map[string]interface{}); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("Expected tags %#v, got %#v", expected, actual)
+	}
+}
+
+func TestReadResourceTags_unsupported(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	client := &golangsdk.ServiceClient{
+		ProviderClient: &golangsdk.ProviderClient{},
+		Endpoint:       server.URL + "/",
+	}
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{"tags": tagsSchema()},
+		map[string]interface{}{})
+
+	if err := readResourceTags(d, &Config{}, client, "vpcs", "vpc-id"); err != nil {
+		t.Fatalf("Expected no error when tags are not supported, got %s", err)
+	}
+	if tags := d.Get("tags").(map[string]interface{}); len(tags) != 0 {
+		t.Fatalf("Expected no tags, got %#v", tags)
+	}
+}
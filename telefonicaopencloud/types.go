@@ -301,6 +301,8 @@ func (opts SubnetCreateOpts) ToSubnetCreateMap() (map[string]interface{}, error)
 // ZoneCreateOpts represents the attributes used when creating a new DNS zone.
 type ZoneCreateOpts struct {
 	zones.CreateOpts
+	ZoneType   string            `json:"zone_type,omitempty"`
+	Router     *dnsZoneV2Router  `json:"router,omitempty"`
 	ValueSpecs map[string]string `json:"value_specs,omitempty"`
 }
 
@@ -0,0 +1,128 @@
+package telefonicaopencloud
+
+import (
+	"github.com/huaweicloud/golangsdk"
+)
+
+// vpcBandwidthV2 is a shared bandwidth, as returned by the VPC v2.0 API.
+// Shared bandwidths are read and updated with the bandwidths package, the
+// VPC v2.0 API is only needed to create and delete them and to move EIPs in
+// and out of them.
+type vpcBandwidthV2 struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Size      int    `json:"size"`
+	ShareType string `json:"share_type"`
+}
+
+// vpcBandwidthV2CreateOpts contains the values needed to create a shared
+// bandwidth.
+type vpcBandwidthV2CreateOpts struct {
+	Name string `json:"name" required:"true"`
+	Size int    `json:"size" required:"true"`
+}
+
+// ToBandwidthCreateMap builds a request body from vpcBandwidthV2CreateOpts.
+func (opts vpcBandwidthV2CreateOpts) ToBandwidthCreateMap() (map[string]interface{}, error) {
+	return golangsdk.BuildRequestBody(opts, "bandwidth")
+}
+
+// vpcBandwidthV2PublicIP identifies an EIP added to or removed from a shared
+// bandwidth.
+type vpcBandwidthV2PublicIP struct {
+	PublicIPID string `json:"publicip_id" required:"true"`
+}
+
+// vpcBandwidthV2InsertOpts contains the EIPs to add to a shared bandwidth.
+type vpcBandwidthV2InsertOpts struct {
+	PublicIPs []vpcBandwidthV2PublicIP `json:"publicip_info" required:"true"`
+}
+
+// ToBandwidthInsertMap builds a request body from vpcBandwidthV2InsertOpts.
+func (opts vpcBandwidthV2InsertOpts) ToBandwidthInsertMap() (map[string]interface{}, error) {
+	return golangsdk.BuildRequestBody(opts, "bandwidth")
+}
+
+// vpcBandwidthV2RemoveOpts contains the EIPs to remove from a shared
+// bandwidth, and the dedicated bandwidth they get back.
+type vpcBandwidthV2RemoveOpts struct {
+	PublicIPs []vpcBandwidthV2PublicIP `json:"publicip_info" required:"true"`
+
+	// ChargeMode is the charge mode of the dedicated bandwidth, either
+	// "bandwidth" or "traffic".
+	ChargeMode string `json:"charge_mode" required:"true"`
+
+	// Size is the size of the dedicated bandwidth in Mbit/s.
+	Size int `json:"size" required:"true"`
+}
+
+// ToBandwidthRemoveMap builds a request body from vpcBandwidthV2RemoveOpts.
+func (opts vpcBandwidthV2RemoveOpts) ToBandwidthRemoveMap() (map[string]interface{}, error) {
+	return golangsdk.BuildRequestBody(opts, "bandwidth")
+}
+
+func vpcBandwidthsV2URL(client *golangsdk.ServiceClient) string {
+	return client.ServiceURL("bandwidths")
+}
+
+func vpcBandwidthV2URL(client *golangsdk.ServiceClient, id string) string {
+	return client.ServiceURL("bandwidths", id)
+}
+
+func vpcBandwidthV2ActionURL(client *golangsdk.ServiceClient, id, action string) string {
+	return client.ServiceURL("bandwidths", id, action)
+}
+
+// createVpcBandwidthV2 creates a shared bandwidth. The client must be scoped
+// to the project, like the one returned by networkingV2TagsClient.
+func createVpcBandwidthV2(client *golangsdk.ServiceClient, opts vpcBandwidthV2CreateOpts) (*vpcBandwidthV2, error) {
+	b, err := opts.ToBandwidthCreateMap()
+	if err != nil {
+		return nil, err
+	}
+
+	var r struct {
+		Bandwidth vpcBandwidthV2 `json:"bandwidth"`
+	}
+	_, err = client.Post(vpcBandwidthsV2URL(client), b, &r, &golangsdk.RequestOpts{
+		OkCodes: []int{200, 201},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &r.Bandwidth, nil
+}
+
+// deleteVpcBandwidthV2 deletes a shared bandwidth. It must not hold any EIP.
+func deleteVpcBandwidthV2(client *golangsdk.ServiceClient, id string) error {
+	_, err := client.Delete(vpcBandwidthV2URL(client, id), &golangsdk.RequestOpts{
+		OkCodes: []int{204},
+	})
+	return err
+}
+
+// insertVpcBandwidthV2 adds EIPs to a shared bandwidth. Their dedicated
+// bandwidths are released.
+func insertVpcBandwidthV2(client *golangsdk.ServiceClient, id string, opts vpcBandwidthV2InsertOpts) error {
+	b, err := opts.ToBandwidthInsertMap()
+	if err != nil {
+		return err
+	}
+	_, err = client.Post(vpcBandwidthV2ActionURL(client, id, "insert"), b, nil, &golangsdk.RequestOpts{
+		OkCodes: []int{200},
+	})
+	return err
+}
+
+// removeVpcBandwidthV2 moves EIPs out of a shared bandwidth, each into a new
+// dedicated bandwidth.
+func removeVpcBandwidthV2(client *golangsdk.ServiceClient, id string, opts vpcBandwidthV2RemoveOpts) error {
+	b, err := opts.ToBandwidthRemoveMap()
+	if err != nil {
+		return err
+	}
+	_, err = client.Post(vpcBandwidthV2ActionURL(client, id, "remove"), b, nil, &golangsdk.RequestOpts{
+		OkCodes: []int{204},
+	})
+	return err
+}
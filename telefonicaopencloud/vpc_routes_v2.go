@@ -0,0 +1,140 @@
+package telefonicaopencloud
+
+import (
+	"fmt"
+
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v1/vpcs"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/peerings"
+)
+
+// vpcRouteV2 is a custom route of a VPC, as returned by the VPC v2.0 API.
+type vpcRouteV2 struct {
+	ID          string `json:"id"`
+	Type        string `json:"type"`
+	NextHop     string `json:"nexthop"`
+	Destination string `json:"destination"`
+	VpcID       string `json:"vpc_id"`
+	TenantID    string `json:"tenant_id"`
+}
+
+// vpcRouteV2CreateOpts contains the values needed to create a VPC route.
+type vpcRouteV2CreateOpts struct {
+	// Type is the type of the next hop. Only "peering" is supported.
+	Type string `json:"type" required:"true"`
+
+	// NextHop is the ID of the next hop, the VPC peering connection for
+	// routes of type "peering".
+	NextHop string `json:"nexthop" required:"true"`
+
+	// Destination is the CIDR of the traffic sent to the next hop.
+	Destination string `json:"destination" required:"true"`
+
+	// VpcID is the VPC the route is added to.
+	VpcID string `json:"vpc_id" required:"true"`
+
+	// TenantID is the project of the VPC. Only an admin may set it.
+	TenantID string `json:"tenant_id,omitempty"`
+}
+
+// ToVpcRouteCreateMap builds a request body from vpcRouteV2CreateOpts.
+func (opts vpcRouteV2CreateOpts) ToVpcRouteCreateMap() (map[string]interface{}, error) {
+	return golangsdk.BuildRequestBody(opts, "route")
+}
+
+// vpcRouteV2ListOpts filters the VPC routes returned by listVpcRoutesV2.
+type vpcRouteV2ListOpts struct {
+	ID          string `q:"id"`
+	Type        string `q:"type"`
+	VpcID       string `q:"vpc_id"`
+	Destination string `q:"destination"`
+	TenantID    string `q:"tenant_id"`
+}
+
+func vpcRoutesV2URL(client *golangsdk.ServiceClient) string {
+	return client.ServiceURL("vpc", "routes")
+}
+
+func vpcRouteV2URL(client *golangsdk.ServiceClient, id string) string {
+	return client.ServiceURL("vpc", "routes", id)
+}
+
+// createVpcRouteV2 adds a custom route to a VPC.
+func createVpcRouteV2(client *golangsdk.ServiceClient, opts vpcRouteV2CreateOpts) (*vpcRouteV2, error) {
+	b, err := opts.ToVpcRouteCreateMap()
+	if err != nil {
+		return nil, err
+	}
+
+	var r struct {
+		Route vpcRouteV2 `json:"route"`
+	}
+	_, err = client.Post(vpcRoutesV2URL(client), b, &r, &golangsdk.RequestOpts{
+		OkCodes: []int{200, 201},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &r.Route, nil
+}
+
+// getVpcRouteV2 retrieves the VPC route with the given ID.
+func getVpcRouteV2(client *golangsdk.ServiceClient, id string) (*vpcRouteV2, error) {
+	var r struct {
+		Route vpcRouteV2 `json:"route"`
+	}
+	_, err := client.Get(vpcRouteV2URL(client, id), &r, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &r.Route, nil
+}
+
+// listVpcRoutesV2 retrieves the VPC routes matching the given filters.
+func listVpcRoutesV2(client *golangsdk.ServiceClient, opts vpcRouteV2ListOpts) ([]vpcRouteV2, error) {
+	q, err := golangsdk.BuildQueryString(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var r struct {
+		Routes []vpcRouteV2 `json:"routes"`
+	}
+	_, err = client.Get(vpcRoutesV2URL(client)+q.String(), &r, nil)
+	if err != nil {
+		return nil, err
+	}
+	return r.Routes, nil
+}
+
+// deleteVpcRouteV2 removes the VPC route with the given ID.
+func deleteVpcRouteV2(client *golangsdk.ServiceClient, id string) error {
+	_, err := client.Delete(vpcRouteV2URL(client, id), &golangsdk.RequestOpts{
+		OkCodes: []int{204},
+	})
+	return err
+}
+
+// checkVpcRouteV2NextHop makes sure a route of type "peering" can be added
+// to a VPC: the VPC must exist, and the next hop must be an accepted peering
+// connection of that VPC. The API only reports a generic error otherwise.
+func checkVpcRouteV2NextHop(vpcClient, peeringClient *golangsdk.ServiceClient, opts vpcRouteV2CreateOpts) error {
+	if _, err := vpcs.Get(vpcClient, opts.VpcID).Extract(); err != nil {
+		return fmt.Errorf("Error retrieving TelefonicaOpenCloud Vpc %s: %s", opts.VpcID, err)
+	}
+
+	peering, err := peerings.Get(peeringClient, opts.NextHop).Extract()
+	if err != nil {
+		return fmt.Errorf("Error retrieving TelefonicaOpenCloud Vpc Peering Connection %s: %s", opts.NextHop, err)
+	}
+
+	if peering.RequestVpcInfo.VpcId != opts.VpcID && peering.AcceptVpcInfo.VpcId != opts.VpcID {
+		return fmt.Errorf("Vpc Peering Connection %s does not connect Vpc %s", peering.ID, opts.VpcID)
+	}
+	if peering.Status != "ACTIVE" {
+		return fmt.Errorf("Vpc Peering Connection %s is %s, it must be accepted before routes can use it",
+			peering.ID, peering.Status)
+	}
+
+	return nil
+}